@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -20,42 +21,109 @@ const (
 	AccessPointPropertyMaxBitrate = AccessPointInterface + ".MaxBitrate" // readable   u
 	AccessPointPropertyStrength   = AccessPointInterface + ".Strength"   // readable   y
 	AccessPointPropertyLastSeen   = AccessPointInterface + ".LastSeen"   // readable   i
+	AccessPointPropertyBandwidth  = AccessPointInterface + ".Bandwidth"  // readable   u
 )
 
+// AccessPointSecurity is the security scheme of a Wi-Fi network, as
+// returned by AccessPoint.Security. The values are meant to be displayed.
+type AccessPointSecurity string
+
+const (
+	AccessPointSecurityOpen         AccessPointSecurity = "Open"
+	AccessPointSecurityWEP          AccessPointSecurity = "WEP"
+	AccessPointSecurityWPAPersonal  AccessPointSecurity = "WPA-Personal"
+	AccessPointSecurityWPA2Personal AccessPointSecurity = "WPA2-Personal"
+	AccessPointSecurityWPA3Personal AccessPointSecurity = "WPA3-Personal"
+	AccessPointSecurityEnterprise   AccessPointSecurity = "Enterprise"
+	AccessPointSecurityOWE          AccessPointSecurity = "OWE"
+)
+
+// AccessPointProperties holds the properties of an access point, read at once
+// by AccessPoint.GetProperties.
+type AccessPointProperties struct {
+	Flags      Nm80211APFlags
+	WpaFlags   Nm80211APSec
+	RsnFlags   Nm80211APSec
+	Ssid       []byte
+	Frequency  uint32
+	HwAddress  string
+	Mode       Nm80211Mode
+	MaxBitrate uint32
+	Strength   uint8
+	LastSeen   int32
+	Bandwidth  uint32
+}
+
 type AccessPoint interface {
 	GetPath() dbus.ObjectPath
 
 	// GetFlags gets flags describing the capabilities of the access point.
-	GetPropertyFlags() (uint32, error)
+	GetPropertyFlags() (Nm80211APFlags, error)
+	GetPropertyFlagsContext(ctx context.Context) (Nm80211APFlags, error)
 
 	// GetWPAFlags gets flags describing the access point's capabilities
 	// according to WPA (Wifi Protected Access).
-	GetPropertyWPAFlags() (uint32, error)
+	GetPropertyWPAFlags() (Nm80211APSec, error)
+	GetPropertyWPAFlagsContext(ctx context.Context) (Nm80211APSec, error)
 
 	// GetRSNFlags gets flags describing the access point's capabilities
 	// according to the RSN (Robust Secure Network) protocol.
-	GetPropertyRSNFlags() (uint32, error)
+	GetPropertyRSNFlags() (Nm80211APSec, error)
+	GetPropertyRSNFlagsContext(ctx context.Context) (Nm80211APSec, error)
 
 	// GetSSID returns the Service Set Identifier identifying the access point.
 	GetPropertySSID() (string, error)
+	GetPropertySSIDContext(ctx context.Context) (string, error)
 
 	// GetFrequency gets the radio channel frequency in use by the access point,
 	// in MHz.
 	GetPropertyFrequency() (uint32, error)
+	GetPropertyFrequencyContext(ctx context.Context) (uint32, error)
 
 	// GetHWAddress gets the hardware address (BSSID) of the access point.
 	GetPropertyHWAddress() (string, error)
+	GetPropertyHWAddressContext(ctx context.Context) (string, error)
 
 	// GetMode describes the operating mode of the access point.
 	GetPropertyMode() (Nm80211Mode, error)
+	GetPropertyModeContext(ctx context.Context) (Nm80211Mode, error)
 
 	// GetMaxBitrate gets the maximum bitrate this access point is capable of, in
 	// kilobits/second (Kb/s).
 	GetPropertyMaxBitrate() (uint32, error)
+	GetPropertyMaxBitrateContext(ctx context.Context) (uint32, error)
 
 	// GetStrength gets the current signal quality of the access point, in
 	// percent.
 	GetPropertyStrength() (uint8, error)
+	GetPropertyStrengthContext(ctx context.Context) (uint8, error)
+
+	// The timestamp (in CLOCK_BOOTTIME seconds) for the last time the access point was found in scan results. A value of -1 means the access point has never been found in scan results. Comparing it with DeviceWireless.GetPropertyLastScan, in milliseconds, tells whether the last scan still saw it.
+	GetPropertyLastSeen() (int32, error)
+	GetPropertyLastSeenContext(ctx context.Context) (int32, error)
+
+	// The bandwidth announced by the access point in MHz. Since NetworkManager 1.46.
+	GetPropertyBandwidth() (uint32, error)
+	GetPropertyBandwidthContext(ctx context.Context) (uint32, error)
+
+	// The band of the frequency of the access point.
+	Band() (WifiBand, error)
+	BandContext(ctx context.Context) (WifiBand, error)
+
+	// The channel number of the frequency of the access point, or 0 if it is not a channel center frequency.
+	Channel() (uint32, error)
+	ChannelContext(ctx context.Context) (uint32, error)
+
+	// Security summarizes the flags of the access point as the strongest security scheme it offers, e.g. WPA3-Personal for a WPA2/WPA3 transition network.
+	Security() (AccessPointSecurity, error)
+	SecurityContext(ctx context.Context) (AccessPointSecurity, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*AccessPointProperties, error)
+	GetPropertiesContext(ctx context.Context) (*AccessPointProperties, error)
+
+	// WithoutCache returns a copy of the access point whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() AccessPoint
 
 	MarshalJSON() ([]byte, error)
 }
@@ -65,28 +133,58 @@ func NewAccessPoint(objectPath dbus.ObjectPath) (AccessPoint, error) {
 	return &a, a.init(NetworkManagerInterface, objectPath)
 }
 
+func NewAccessPointWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (AccessPoint, error) {
+	var a accessPoint
+	return &a, a.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type accessPoint struct {
 	dbusBase
 }
 
+func (a *accessPoint) WithoutCache() AccessPoint {
+	copied := *a
+	copied.uncached = true
+	return &copied
+}
+
 func (a *accessPoint) GetPath() dbus.ObjectPath {
 	return a.obj.Path()
 }
 
-func (a *accessPoint) GetPropertyFlags() (uint32, error) {
-	return a.getUint32Property(AccessPointPropertyFlags)
+func (a *accessPoint) GetPropertyFlags() (Nm80211APFlags, error) {
+	return a.GetPropertyFlagsContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyFlagsContext(ctx context.Context) (Nm80211APFlags, error) {
+	r, err := a.getUint32Property(ctx, AccessPointPropertyFlags)
+	return Nm80211APFlags(r), err
+}
+
+func (a *accessPoint) GetPropertyWPAFlags() (Nm80211APSec, error) {
+	return a.GetPropertyWPAFlagsContext(context.Background())
 }
 
-func (a *accessPoint) GetPropertyWPAFlags() (uint32, error) {
-	return a.getUint32Property(AccessPointPropertyWpaFlags)
+func (a *accessPoint) GetPropertyWPAFlagsContext(ctx context.Context) (Nm80211APSec, error) {
+	r, err := a.getUint32Property(ctx, AccessPointPropertyWpaFlags)
+	return Nm80211APSec(r), err
 }
 
-func (a *accessPoint) GetPropertyRSNFlags() (uint32, error) {
-	return a.getUint32Property(AccessPointPropertyRsnFlags)
+func (a *accessPoint) GetPropertyRSNFlags() (Nm80211APSec, error) {
+	return a.GetPropertyRSNFlagsContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyRSNFlagsContext(ctx context.Context) (Nm80211APSec, error) {
+	r, err := a.getUint32Property(ctx, AccessPointPropertyRsnFlags)
+	return Nm80211APSec(r), err
 }
 
 func (a *accessPoint) GetPropertySSID() (string, error) {
-	r, err := a.getSliceByteProperty(AccessPointPropertySsid)
+	return a.GetPropertySSIDContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertySSIDContext(ctx context.Context) (string, error) {
+	r, err := a.getSliceByteProperty(ctx, AccessPointPropertySsid)
 	if err != nil {
 		return "", err
 	}
@@ -94,15 +192,27 @@ func (a *accessPoint) GetPropertySSID() (string, error) {
 }
 
 func (a *accessPoint) GetPropertyFrequency() (uint32, error) {
-	return a.getUint32Property(AccessPointPropertyFrequency)
+	return a.GetPropertyFrequencyContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyFrequencyContext(ctx context.Context) (uint32, error) {
+	return a.getUint32Property(ctx, AccessPointPropertyFrequency)
 }
 
 func (a *accessPoint) GetPropertyHWAddress() (string, error) {
-	return a.getStringProperty(AccessPointPropertyHwAddress)
+	return a.GetPropertyHWAddressContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyHWAddressContext(ctx context.Context) (string, error) {
+	return a.getStringProperty(ctx, AccessPointPropertyHwAddress)
 }
 
 func (a *accessPoint) GetPropertyMode() (Nm80211Mode, error) {
-	r, err := a.getUint32Property(AccessPointPropertyMode)
+	return a.GetPropertyModeContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyModeContext(ctx context.Context) (Nm80211Mode, error) {
+	r, err := a.getUint32Property(ctx, AccessPointPropertyMode)
 	if err != nil {
 		return Nm80211ModeUnknown, err
 	}
@@ -110,60 +220,132 @@ func (a *accessPoint) GetPropertyMode() (Nm80211Mode, error) {
 }
 
 func (a *accessPoint) GetPropertyMaxBitrate() (uint32, error) {
-	return a.getUint32Property(AccessPointPropertyMaxBitrate)
+	return a.GetPropertyMaxBitrateContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyMaxBitrateContext(ctx context.Context) (uint32, error) {
+	return a.getUint32Property(ctx, AccessPointPropertyMaxBitrate)
 }
 
 func (a *accessPoint) GetPropertyStrength() (uint8, error) {
-	return a.getUint8Property(AccessPointPropertyStrength)
+	return a.GetPropertyStrengthContext(context.Background())
 }
 
-func (a *accessPoint) MarshalJSON() ([]byte, error) {
-	Flags, err := a.GetPropertyFlags()
-	if err != nil {
-		return nil, err
-	}
-	WPAFlags, err := a.GetPropertyWPAFlags()
-	if err != nil {
-		return nil, err
-	}
-	RSNFlags, err := a.GetPropertyRSNFlags()
-	if err != nil {
-		return nil, err
-	}
-	SSID, err := a.GetPropertySSID()
-	if err != nil {
-		return nil, err
-	}
-	Frequency, err := a.GetPropertyFrequency()
-	if err != nil {
-		return nil, err
-	}
-	HWAddress, err := a.GetPropertyHWAddress()
+func (a *accessPoint) GetPropertyStrengthContext(ctx context.Context) (uint8, error) {
+	return a.getUint8Property(ctx, AccessPointPropertyStrength)
+}
+
+func (a *accessPoint) GetPropertyLastSeen() (int32, error) {
+	return a.GetPropertyLastSeenContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyLastSeenContext(ctx context.Context) (int32, error) {
+	return a.getInt32Property(ctx, AccessPointPropertyLastSeen)
+}
+
+func (a *accessPoint) GetPropertyBandwidth() (uint32, error) {
+	return a.GetPropertyBandwidthContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertyBandwidthContext(ctx context.Context) (uint32, error) {
+	return a.getUint32Property(ctx, AccessPointPropertyBandwidth)
+}
+
+func (a *accessPoint) Band() (WifiBand, error) {
+	return a.BandContext(context.Background())
+}
+
+func (a *accessPoint) BandContext(ctx context.Context) (WifiBand, error) {
+	frequency, err := a.GetPropertyFrequencyContext(ctx)
+	return WifiFrequencyBand(frequency), err
+}
+
+func (a *accessPoint) Channel() (uint32, error) {
+	return a.ChannelContext(context.Background())
+}
+
+func (a *accessPoint) ChannelContext(ctx context.Context) (uint32, error) {
+	frequency, err := a.GetPropertyFrequencyContext(ctx)
+	return WifiFrequencyChannel(frequency), err
+}
+
+func (a *accessPoint) GetProperties() (*AccessPointProperties, error) {
+	return a.GetPropertiesContext(context.Background())
+}
+
+func (a *accessPoint) GetPropertiesContext(ctx context.Context) (*AccessPointProperties, error) {
+	props, err := a.getAllProperties(ctx, AccessPointInterface)
 	if err != nil {
 		return nil, err
 	}
-	Mode, err := a.GetPropertyMode()
+
+	var p AccessPointProperties
+	return &p, decodeProperties(AccessPointInterface, props, &p)
+}
+
+func (a *accessPoint) Security() (AccessPointSecurity, error) {
+	return a.SecurityContext(context.Background())
+}
+
+func (a *accessPoint) SecurityContext(ctx context.Context) (AccessPointSecurity, error) {
+	p, err := a.GetPropertiesContext(ctx)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	MaxBitrate, err := a.GetPropertyMaxBitrate()
-	if err != nil {
-		return nil, err
+	return p.Security(), nil
+}
+
+// Security summarizes the flags as the strongest security scheme the access
+// point offers, like AccessPoint.Security.
+func (p *AccessPointProperties) Security() AccessPointSecurity {
+	switch {
+	case (p.WpaFlags|p.RsnFlags)&(Nm80211APSecKeyMgmt8021X|Nm80211APSecKeyMgmtEAPSuiteB192) != 0:
+		return AccessPointSecurityEnterprise
+	case p.RsnFlags&Nm80211APSecKeyMgmtSAE != 0:
+		return AccessPointSecurityWPA3Personal
+	case p.RsnFlags&(Nm80211APSecKeyMgmtOWE|Nm80211APSecKeyMgmtOWETM) != 0:
+		return AccessPointSecurityOWE
+	case p.RsnFlags&Nm80211APSecKeyMgmtPSK != 0:
+		return AccessPointSecurityWPA2Personal
+	case p.WpaFlags&Nm80211APSecKeyMgmtPSK != 0:
+		return AccessPointSecurityWPAPersonal
+	case p.Flags&Nm80211APFlagsPrivacy != 0:
+		return AccessPointSecurityWEP
 	}
-	Strength, err := a.GetPropertyStrength()
+	return AccessPointSecurityOpen
+}
+
+// Band returns the band of the frequency, like AccessPoint.Band.
+func (p *AccessPointProperties) Band() WifiBand {
+	return WifiFrequencyBand(p.Frequency)
+}
+
+// Channel returns the channel number of the frequency, like
+// AccessPoint.Channel.
+func (p *AccessPointProperties) Channel() uint32 {
+	return WifiFrequencyChannel(p.Frequency)
+}
+
+func (a *accessPoint) MarshalJSON() ([]byte, error) {
+	p, err := a.GetProperties()
 	if err != nil {
 		return nil, err
 	}
 
 	return json.Marshal(map[string]interface{}{
-		"Flags":      Flags,
-		"WPAFlags":   WPAFlags,
-		"RSNFlags":   RSNFlags,
-		"SSID":       SSID,
-		"Frequency":  Frequency,
-		"HWAddress":  HWAddress,
-		"Mode":       Mode.String(),
-		"MaxBitrate": MaxBitrate,
-		"Strength":   Strength,
+		"Flags":      p.Flags,
+		"WPAFlags":   p.WpaFlags,
+		"RSNFlags":   p.RsnFlags,
+		"SSID":       string(p.Ssid),
+		"Frequency":  p.Frequency,
+		"HWAddress":  p.HwAddress,
+		"Mode":       p.Mode.String(),
+		"MaxBitrate": p.MaxBitrate,
+		"Strength":   p.Strength,
+		"LastSeen":   p.LastSeen,
+		"Bandwidth":  p.Bandwidth,
+		"Band":       p.Band(),
+		"Channel":    p.Channel(),
+		"Security":   p.Security(),
 	})
 }
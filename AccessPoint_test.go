@@ -0,0 +1,107 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestAccessPointPropertiesSecurity(t *testing.T) {
+	tests := []struct {
+		name  string
+		props gnm.AccessPointProperties
+		want  gnm.AccessPointSecurity
+	}{
+		{
+			name: "open",
+			want: gnm.AccessPointSecurityOpen,
+		},
+		{
+			name:  "wep",
+			props: gnm.AccessPointProperties{Flags: gnm.Nm80211APFlagsPrivacy},
+			want:  gnm.AccessPointSecurityWEP,
+		},
+		{
+			name: "wpa",
+			props: gnm.AccessPointProperties{
+				Flags:    gnm.Nm80211APFlagsPrivacy,
+				WpaFlags: gnm.Nm80211APSecPairTKIP | gnm.Nm80211APSecGroupTKIP | gnm.Nm80211APSecKeyMgmtPSK,
+			},
+			want: gnm.AccessPointSecurityWPAPersonal,
+		},
+		{
+			name: "wpa and wpa2",
+			props: gnm.AccessPointProperties{
+				Flags:    gnm.Nm80211APFlagsPrivacy,
+				WpaFlags: gnm.Nm80211APSecPairTKIP | gnm.Nm80211APSecKeyMgmtPSK,
+				RsnFlags: gnm.Nm80211APSecPairCCMP | gnm.Nm80211APSecKeyMgmtPSK,
+			},
+			want: gnm.AccessPointSecurityWPA2Personal,
+		},
+		{
+			name: "wpa2 and wpa3 transition",
+			props: gnm.AccessPointProperties{
+				Flags:    gnm.Nm80211APFlagsPrivacy,
+				RsnFlags: gnm.Nm80211APSecPairCCMP | gnm.Nm80211APSecKeyMgmtPSK | gnm.Nm80211APSecKeyMgmtSAE,
+			},
+			want: gnm.AccessPointSecurityWPA3Personal,
+		},
+		{
+			name:  "owe",
+			props: gnm.AccessPointProperties{RsnFlags: gnm.Nm80211APSecPairCCMP | gnm.Nm80211APSecKeyMgmtOWE},
+			want:  gnm.AccessPointSecurityOWE,
+		},
+		{
+			name:  "owe transition",
+			props: gnm.AccessPointProperties{RsnFlags: gnm.Nm80211APSecKeyMgmtOWETM},
+			want:  gnm.AccessPointSecurityOWE,
+		},
+		{
+			name: "wpa2 enterprise",
+			props: gnm.AccessPointProperties{
+				Flags:    gnm.Nm80211APFlagsPrivacy,
+				RsnFlags: gnm.Nm80211APSecPairCCMP | gnm.Nm80211APSecKeyMgmt8021X,
+			},
+			want: gnm.AccessPointSecurityEnterprise,
+		},
+		{
+			name:  "wpa enterprise",
+			props: gnm.AccessPointProperties{WpaFlags: gnm.Nm80211APSecKeyMgmt8021X},
+			want:  gnm.AccessPointSecurityEnterprise,
+		},
+		{
+			name:  "wpa3 enterprise 192-bit",
+			props: gnm.AccessPointProperties{RsnFlags: gnm.Nm80211APSecKeyMgmtEAPSuiteB192},
+			want:  gnm.AccessPointSecurityEnterprise,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.props.Security(); got != tt.want {
+				t.Errorf("Security() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessPointSecurity(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, dobj := newWifiDevice(t, f)
+	obj := f.srv.AddAccessPoint(dobj, "home", 2412, 70)
+	obj.Set(gnm.AccessPointPropertyFlags, uint32(gnm.Nm80211APFlagsPrivacy))
+	obj.Set(gnm.AccessPointPropertyRsnFlags, uint32(gnm.Nm80211APSecPairCCMP|gnm.Nm80211APSecKeyMgmtSAE))
+
+	ap, err := gnm.NewAccessPointWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags, err := ap.GetPropertyFlags(); err != nil || flags != gnm.Nm80211APFlagsPrivacy {
+		t.Errorf("GetPropertyFlags() = %v, %v, want %v", flags, err, gnm.Nm80211APFlagsPrivacy)
+	}
+	if security, err := ap.Security(); err != nil || security != gnm.AccessPointSecurityWPA3Personal {
+		t.Errorf("Security() = %s, %v, want %s", security, err, gnm.AccessPointSecurityWPA3Personal)
+	}
+}
@@ -0,0 +1,144 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ActivationError is returned when an active connection is deactivated
+// before reaching the activated state.
+type ActivationError struct {
+	// The active connection that failed. It is usually gone from the bus by
+	// the time the error is returned.
+	ActiveConnection dbus.ObjectPath
+
+	// The reason reported by NetworkManager for the deactivation.
+	Reason NmActiveConnectionStateReason
+
+	// The last state and reason reported for the device of the connection,
+	// if it has one.
+	DeviceState  NmDeviceState
+	DeviceReason NmDeviceStateReason
+}
+
+func (e *ActivationError) Error() string {
+	msg := fmt.Sprintf("activation of %s failed: %v", e.ActiveConnection, e.Reason)
+	if e.DeviceState != NmDeviceStateUnknown {
+		msg += fmt.Sprintf(" (device %v, reason %v)", e.DeviceState, e.DeviceReason)
+	}
+	return msg
+}
+
+func (nm *networkManager) WaitForActivation(ctx context.Context, ac ActiveConnection) error {
+	sub, err := newEventSubscription(ctx, nm.dbusBase, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	return nm.waitForActivation(ctx, sub, ac.GetPath())
+}
+
+func (nm *networkManager) ActivateAndWait(ctx context.Context, c Connection, d Device) (ActiveConnection, error) {
+	sub, err := newEventSubscription(ctx, nm.dbusBase, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	ac, err := nm.ActivateConnectionContext(ctx, c, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return ac, nm.waitForActivation(ctx, sub, ac.GetPath())
+}
+
+// waitForActivation follows an active connection until it is activated or
+// deactivated. The subscription must be created before the state is read so
+// that no state change can be missed.
+func (nm *networkManager) waitForActivation(ctx context.Context, sub *eventSubscription, path dbus.ObjectPath) error {
+	activationErr := &ActivationError{ActiveConnection: path}
+
+	var ac activeConnection
+	if err := ac.initWithConn(nm.conn, NetworkManagerInterface, path); err != nil {
+		return err
+	}
+	// Changes made before the subscription are not all in the cache yet.
+	ac.uncached = true
+
+	devices := make(map[dbus.ObjectPath]bool)
+	state, err := ac.GetPropertyStateContext(ctx)
+	if err == nil {
+		var paths []dbus.ObjectPath
+		if paths, err = ac.getSliceObjectProperty(ctx, ActiveConnectionPropertyDevices); err == nil {
+			for _, p := range paths {
+				devices[p] = true
+			}
+		}
+	}
+	if err != nil {
+		// The active connection is gone before we could look at it, which
+		// means its activation is already over and failed.
+		if dbusErr, ok := err.(dbus.Error); ok && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownObject" {
+			return activationErr
+		}
+		return err
+	}
+
+	switch state {
+	case NmActiveConnectionStateActivated:
+		return nil
+	case NmActiveConnectionStateDeactivated:
+		for p := range devices {
+			activationErr.DeviceState, activationErr.DeviceReason, _ = nm.deviceStateReason(ctx, p)
+		}
+		return activationErr
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return fmt.Errorf("event subscription closed while waiting for %s", path)
+			}
+
+			switch e := event.(type) {
+			case *DeviceStateChangedEvent:
+				// Keep the reason of the failure rather than the one of the
+				// cleanup that follows it.
+				if devices[e.Path] && activationErr.DeviceState != NmDeviceStateFailed {
+					activationErr.DeviceState = e.New
+					activationErr.DeviceReason = e.Reason
+				}
+
+			case *ActiveConnectionStateChangedEvent:
+				if e.Path != path {
+					continue
+				}
+				switch e.State {
+				case NmActiveConnectionStateActivated:
+					return nil
+				case NmActiveConnectionStateDeactivated:
+					activationErr.Reason = e.Reason
+					return activationErr
+				}
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (nm *networkManager) deviceStateReason(ctx context.Context, path dbus.ObjectPath) (NmDeviceState, NmDeviceStateReason, error) {
+	var d device
+	if err := d.initWithConn(nm.conn, NetworkManagerInterface, path); err != nil {
+		return NmDeviceStateUnknown, NmDeviceStateReasonNone, err
+	}
+	d.uncached = true
+
+	return d.GetPropertyStateReasonContext(ctx)
+}
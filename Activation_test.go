@@ -0,0 +1,135 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+func TestWaitForActivation(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, cobj := f.addConnection(t)
+	_, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	// Leave the activation pending until the client waits for it.
+	f.srv.SetActivationFunc(nil)
+	acobj := f.srv.Activate(cobj, dobj, "/")
+
+	ac, err := gnm.NewActiveConnectionWithConn(f.conn, acobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ac.GetPropertyConnection(); err != nil || got.GetPath() != c.GetPath() {
+		t.Fatalf("GetPropertyConnection() = %v, %v, want %s", got, err, c.GetPath())
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- f.nm.WaitForActivation(context.Background(), ac) }()
+
+	f.srv.SetActiveConnectionState(acobj, gnm.NmActiveConnectionStateActivating, gnm.NmActiveConnectionStateReasonNone)
+	select {
+	case err := <-done:
+		t.Fatalf("WaitForActivation() returned %v while activating", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	nmfake.DefaultActivation(f.srv, acobj)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("WaitForActivation() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForActivation() did not return once activated")
+	}
+}
+
+func TestWaitForActivationFailure(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, _ := f.addConnection(t)
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	f.srv.SetActivationFunc(nil)
+	ac, err := f.nm.ActivateConnection(c, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- f.nm.WaitForActivation(context.Background(), ac) }()
+	time.Sleep(100 * time.Millisecond)
+
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateFailed, gnm.NmDeviceStateReasonNoSecrets)
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateDisconnected, gnm.NmDeviceStateReasonNone)
+	f.srv.SetActiveConnectionState(f.srv.Object(ac.GetPath()), gnm.NmActiveConnectionStateDeactivated, gnm.NmActiveConnectionStateReasonNoSecrets)
+
+	var activationErr *gnm.ActivationError
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForActivation() did not return once deactivated")
+	}
+	if !errors.As(err, &activationErr) {
+		t.Fatalf("WaitForActivation() = %v, want an *ActivationError", err)
+	}
+	if activationErr.Reason != gnm.NmActiveConnectionStateReasonNoSecrets {
+		t.Errorf("Reason = %v, want %v", activationErr.Reason, gnm.NmActiveConnectionStateReasonNoSecrets)
+	}
+	if activationErr.DeviceState != gnm.NmDeviceStateFailed || activationErr.DeviceReason != gnm.NmDeviceStateReasonNoSecrets {
+		t.Errorf("device = %v, %v, want %v, %v", activationErr.DeviceState, activationErr.DeviceReason,
+			gnm.NmDeviceStateFailed, gnm.NmDeviceStateReasonNoSecrets)
+	}
+}
+
+func TestWaitForActivationContext(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, _ := f.addConnection(t)
+	d, _ := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	f.srv.SetActivationFunc(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	ac, err := f.nm.ActivateAndWait(ctx, c, d)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ActivateAndWait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if ac == nil {
+		t.Fatal("ActivateAndWait() did not return the pending active connection")
+	}
+}
+
+func TestAddAndActivateConnection(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, _ := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	settings, err := gnm.NewConnectionSettings(&gnm.SettingConnection{Id: "new", Type: gnm.SettingWiredSettingName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac, err := f.nm.AddAndActivateConnection(settings, d)
+	if err != nil {
+		t.Fatalf("AddAndActivateConnection() = %v", err)
+	}
+
+	c, err := ac.GetPropertyConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := f.srv.Object(c.GetPath()).ConnectionSettings()["connection"]["id"]; id != "new" {
+		t.Errorf("id = %v, want new", id)
+	}
+}
@@ -1,6 +1,8 @@
 package gonetworkmanager
 
 import (
+	"context"
+
 	"github.com/godbus/dbus/v5"
 )
 
@@ -26,56 +28,100 @@ const (
 	ActiveConnectionPropertyMaster         = ActiveConnectionInterface + ".Master"         // readable   o
 )
 
+// ActiveConnectionProperties holds the properties of an active connection,
+// read at once by ActiveConnection.GetProperties.
+type ActiveConnectionProperties struct {
+	Connection     dbus.ObjectPath
+	SpecificObject dbus.ObjectPath
+	Id             string
+	Uuid           string
+	Type           string
+	Devices        []dbus.ObjectPath
+	State          NmActiveConnectionState
+	StateFlags     uint32
+	Default        bool
+	Ip4Config      dbus.ObjectPath
+	Dhcp4Config    dbus.ObjectPath
+	Default6       bool
+	Ip6Config      dbus.ObjectPath
+	Dhcp6Config    dbus.ObjectPath
+	Vpn            bool
+	Master         dbus.ObjectPath
+}
+
 type ActiveConnection interface {
 	GetPath() dbus.ObjectPath
 
 	// GetConnectionSettings gets connection object of the connection.
 	GetPropertyConnection() (Connection, error)
+	GetPropertyConnectionContext(ctx context.Context) (Connection, error)
 
 	// GetSpecificObject gets a specific object associated with the active connection.
 	GetPropertySpecificObject() (AccessPoint, error)
+	GetPropertySpecificObjectContext(ctx context.Context) (AccessPoint, error)
 
 	// GetID gets the ID of the connection.
 	GetPropertyID() (string, error)
+	GetPropertyIDContext(ctx context.Context) (string, error)
 
 	// GetUUID gets the UUID of the connection.
 	GetPropertyUUID() (string, error)
+	GetPropertyUUIDContext(ctx context.Context) (string, error)
 
 	// GetType gets the type of the connection.
 	GetPropertyType() (string, error)
+	GetPropertyTypeContext(ctx context.Context) (string, error)
 
 	// GetDevices gets array of device objects which are part of this active connection.
 	GetPropertyDevices() ([]Device, error)
+	GetPropertyDevicesContext(ctx context.Context) ([]Device, error)
 
 	// GetState gets the state of the connection.
 	GetPropertyState() (NmActiveConnectionState, error)
+	GetPropertyStateContext(ctx context.Context) (NmActiveConnectionState, error)
 
 	// GetStateFlags gets the state flags of the connection.
 	GetPropertyStateFlags() (uint32, error)
+	GetPropertyStateFlagsContext(ctx context.Context) (uint32, error)
 
 	// GetDefault gets the default IPv4 flag of the connection.
 	GetPropertyDefault() (bool, error)
+	GetPropertyDefaultContext(ctx context.Context) (bool, error)
 
 	// GetIP4Config gets the IP4Config of the connection.
 	GetPropertyIP4Config() (IP4Config, error)
+	GetPropertyIP4ConfigContext(ctx context.Context) (IP4Config, error)
 
 	// GetDHCP4Config gets the DHCP6Config of the connection.
 	GetPropertyDHCP4Config() (DHCP4Config, error)
+	GetPropertyDHCP4ConfigContext(ctx context.Context) (DHCP4Config, error)
 
 	// GetDefault gets the default IPv6 flag of the connection.
 	GetPropertyDefault6() (bool, error)
+	GetPropertyDefault6Context(ctx context.Context) (bool, error)
 
 	// GetIP6Config gets the IP6Config of the connection.
 	GetPropertyIP6Config() (IP6Config, error)
+	GetPropertyIP6ConfigContext(ctx context.Context) (IP6Config, error)
 
 	// GetDHCP6Config gets the DHCP4Config of the connection.
 	GetPropertyDHCP6Config() (DHCP6Config, error)
+	GetPropertyDHCP6ConfigContext(ctx context.Context) (DHCP6Config, error)
 
 	// GetVPN gets the VPN flag of the connection.
 	GetPropertyVPN() (bool, error)
+	GetPropertyVPNContext(ctx context.Context) (bool, error)
 
 	// GetMaster gets the master device of the connection.
 	GetPropertyMaster() (Device, error)
+	GetPropertyMasterContext(ctx context.Context) (Device, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*ActiveConnectionProperties, error)
+	GetPropertiesContext(ctx context.Context) (*ActiveConnectionProperties, error)
+
+	// WithoutCache returns a copy of the active connection whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() ActiveConnection
 }
 
 func NewActiveConnection(objectPath dbus.ObjectPath) (ActiveConnection, error) {
@@ -83,20 +129,35 @@ func NewActiveConnection(objectPath dbus.ObjectPath) (ActiveConnection, error) {
 	return &a, a.init(NetworkManagerInterface, objectPath)
 }
 
+func NewActiveConnectionWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (ActiveConnection, error) {
+	var a activeConnection
+	return &a, a.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type activeConnection struct {
 	dbusBase
 }
 
+func (a *activeConnection) WithoutCache() ActiveConnection {
+	copied := *a
+	copied.uncached = true
+	return &copied
+}
+
 func (a *activeConnection) GetPath() dbus.ObjectPath {
 	return a.obj.Path()
 }
 
 func (a *activeConnection) GetPropertyConnection() (Connection, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyConnection)
+	return a.GetPropertyConnectionContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyConnectionContext(ctx context.Context) (Connection, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyConnection)
 	if err != nil {
 		return nil, err
 	}
-	con, err := NewConnection(path)
+	con, err := NewConnectionWithConn(a.conn, path)
 	if err != nil {
 		return nil, err
 	}
@@ -104,11 +165,15 @@ func (a *activeConnection) GetPropertyConnection() (Connection, error) {
 }
 
 func (a *activeConnection) GetPropertySpecificObject() (AccessPoint, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertySpecificObject)
+	return a.GetPropertySpecificObjectContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertySpecificObjectContext(ctx context.Context) (AccessPoint, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertySpecificObject)
 	if err != nil {
 		return nil, err
 	}
-	ap, err := NewAccessPoint(path)
+	ap, err := NewAccessPointWithConn(a.conn, path)
 	if err != nil {
 		return nil, err
 	}
@@ -116,25 +181,41 @@ func (a *activeConnection) GetPropertySpecificObject() (AccessPoint, error) {
 }
 
 func (a *activeConnection) GetPropertyID() (string, error) {
-	return a.getStringProperty(ActiveConnectionPropertyId)
+	return a.GetPropertyIDContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyIDContext(ctx context.Context) (string, error) {
+	return a.getStringProperty(ctx, ActiveConnectionPropertyId)
 }
 
 func (a *activeConnection) GetPropertyUUID() (string, error) {
-	return a.getStringProperty(ActiveConnectionPropertyUuid)
+	return a.GetPropertyUUIDContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyUUIDContext(ctx context.Context) (string, error) {
+	return a.getStringProperty(ctx, ActiveConnectionPropertyUuid)
 }
 
 func (a *activeConnection) GetPropertyType() (string, error) {
-	return a.getStringProperty(ActiveConnectionPropertyType)
+	return a.GetPropertyTypeContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyTypeContext(ctx context.Context) (string, error) {
+	return a.getStringProperty(ctx, ActiveConnectionPropertyType)
 }
 
 func (a *activeConnection) GetPropertyDevices() ([]Device, error) {
-	paths, err := a.getSliceObjectProperty(ActiveConnectionPropertyDevices)
+	return a.GetPropertyDevicesContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyDevicesContext(ctx context.Context) ([]Device, error) {
+	paths, err := a.getSliceObjectProperty(ctx, ActiveConnectionPropertyDevices)
 	if err != nil {
 		return nil, err
 	}
 	devices := make([]Device, len(paths))
 	for i, path := range paths {
-		devices[i], err = DeviceFactory(path)
+		devices[i], err = DeviceFactoryWithConnContext(ctx, a.conn, path)
 		if err != nil {
 			return nil, err
 		}
@@ -142,16 +223,28 @@ func (a *activeConnection) GetPropertyDevices() ([]Device, error) {
 	return devices, nil
 }
 func (a *activeConnection) GetPropertyState() (NmActiveConnectionState, error) {
-	v, err := a.getUint32Property(ActiveConnectionPropertyState)
+	return a.GetPropertyStateContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyStateContext(ctx context.Context) (NmActiveConnectionState, error) {
+	v, err := a.getUint32Property(ctx, ActiveConnectionPropertyState)
 	return NmActiveConnectionState(v), err
 }
 
 func (a *activeConnection) GetPropertyStateFlags() (uint32, error) {
-	return a.getUint32Property(ActiveConnectionPropertyStateFlags)
+	return a.GetPropertyStateFlagsContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyStateFlagsContext(ctx context.Context) (uint32, error) {
+	return a.getUint32Property(ctx, ActiveConnectionPropertyStateFlags)
 }
 
 func (a *activeConnection) GetPropertyDefault() (bool, error) {
-	b, err := a.getProperty(ActiveConnectionPropertyDefault)
+	return a.GetPropertyDefaultContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyDefaultContext(ctx context.Context) (bool, error) {
+	b, err := a.getProperty(ctx, ActiveConnectionPropertyDefault)
 	if err != nil {
 		return false, err
 	}
@@ -159,45 +252,69 @@ func (a *activeConnection) GetPropertyDefault() (bool, error) {
 }
 
 func (a *activeConnection) GetPropertyIP4Config() (IP4Config, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyIp4Config)
+	return a.GetPropertyIP4ConfigContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyIP4ConfigContext(ctx context.Context) (IP4Config, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyIp4Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
-	return NewIP4Config(path)
+	return NewIP4ConfigWithConn(a.conn, path)
 }
 
 func (a *activeConnection) GetPropertyDHCP4Config() (DHCP4Config, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyDhcp4Config)
+	return a.GetPropertyDHCP4ConfigContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyDHCP4ConfigContext(ctx context.Context) (DHCP4Config, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyDhcp4Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
-	return NewDHCP4Config(path)
+	return NewDHCP4ConfigWithConn(a.conn, path)
 }
 
 func (a *activeConnection) GetPropertyDefault6() (bool, error) {
-	return a.getBoolProperty(ActiveConnectionPropertyDefault6)
+	return a.GetPropertyDefault6Context(context.Background())
+}
+
+func (a *activeConnection) GetPropertyDefault6Context(ctx context.Context) (bool, error) {
+	return a.getBoolProperty(ctx, ActiveConnectionPropertyDefault6)
 }
 
 func (a *activeConnection) GetPropertyIP6Config() (IP6Config, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyIp6Config)
+	return a.GetPropertyIP6ConfigContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyIP6ConfigContext(ctx context.Context) (IP6Config, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyIp6Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewIP6Config(path)
+	return NewIP6ConfigWithConn(a.conn, path)
 }
 
 func (a *activeConnection) GetPropertyDHCP6Config() (DHCP6Config, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyDhcp6Config)
+	return a.GetPropertyDHCP6ConfigContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyDHCP6ConfigContext(ctx context.Context) (DHCP6Config, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyDhcp6Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewDHCP6Config(path)
+	return NewDHCP6ConfigWithConn(a.conn, path)
 }
 
 func (a *activeConnection) GetPropertyVPN() (bool, error) {
-	ret, err := a.getProperty(ActiveConnectionPropertyVpn)
+	return a.GetPropertyVPNContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyVPNContext(ctx context.Context) (bool, error) {
+	ret, err := a.getProperty(ctx, ActiveConnectionPropertyVpn)
 	if err != nil {
 		return false, err
 	}
@@ -205,9 +322,27 @@ func (a *activeConnection) GetPropertyVPN() (bool, error) {
 }
 
 func (a *activeConnection) GetPropertyMaster() (Device, error) {
-	path, err := a.getObjectProperty(ActiveConnectionPropertyMaster)
+	return a.GetPropertyMasterContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertyMasterContext(ctx context.Context) (Device, error) {
+	path, err := a.getObjectProperty(ctx, ActiveConnectionPropertyMaster)
 	if err != nil || path == "/" {
 		return nil, err
 	}
-	return DeviceFactory(path)
+	return DeviceFactoryWithConnContext(ctx, a.conn, path)
+}
+
+func (a *activeConnection) GetProperties() (*ActiveConnectionProperties, error) {
+	return a.GetPropertiesContext(context.Background())
+}
+
+func (a *activeConnection) GetPropertiesContext(ctx context.Context) (*ActiveConnectionProperties, error) {
+	props, err := a.getAllProperties(ctx, ActiveConnectionInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p ActiveConnectionProperties
+	return &p, decodeProperties(ActiveConnectionInterface, props, &p)
 }
@@ -0,0 +1,125 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	AgentManagerInterface  = NetworkManagerInterface + ".AgentManager"
+	AgentManagerObjectPath = NetworkManagerObjectPath + "/AgentManager"
+
+	/* Methods */
+	AgentManagerRegister                 = AgentManagerInterface + ".Register"
+	AgentManagerRegisterWithCapabilities = AgentManagerInterface + ".RegisterWithCapabilities"
+	AgentManagerUnregister               = AgentManagerInterface + ".Unregister"
+)
+
+// AgentManager registers secret agents with NetworkManager.
+//
+// NetworkManager knows an agent by the D-Bus connection it registered from,
+// and calls it on SecretAgentObjectPath of that connection. Each AgentManager
+// therefore serves at most one SecretAgent, on the D-Bus connection it was
+// created with.
+type AgentManager interface {
+	GetPath() dbus.ObjectPath
+
+	// Register exports the agent on the D-Bus connection and registers it with NetworkManager. The identifier is a unique identifier of the agent, between 3 and 255 characters in length, without '/' and using only ASCII characters, e.g. "com.example.my-agent".
+	Register(agent SecretAgent, identifier string) error
+	RegisterContext(ctx context.Context, agent SecretAgent, identifier string) error
+
+	// Like Register, but indicates agent capabilities to NetworkManager.
+	RegisterWithCapabilities(agent SecretAgent, identifier string, capabilities NmSecretAgentCapabilities) error
+	RegisterWithCapabilitiesContext(ctx context.Context, agent SecretAgent, identifier string, capabilities NmSecretAgentCapabilities) error
+
+	// Unregister tells NetworkManager the agent is no longer available, and stops exporting it on the D-Bus connection.
+	Unregister() error
+	UnregisterContext(ctx context.Context) error
+}
+
+func NewAgentManager() (AgentManager, error) {
+	var a agentManager
+	return &a, a.init(NetworkManagerInterface, AgentManagerObjectPath)
+}
+
+func NewAgentManagerWithConn(conn *dbus.Conn) (AgentManager, error) {
+	var a agentManager
+	return &a, a.initWithConn(conn, NetworkManagerInterface, AgentManagerObjectPath)
+}
+
+type agentManager struct {
+	dbusBase
+
+	lock     sync.Mutex
+	exported *secretAgentExport
+}
+
+func (a *agentManager) GetPath() dbus.ObjectPath {
+	return a.obj.Path()
+}
+
+func (a *agentManager) Register(agent SecretAgent, identifier string) error {
+	return a.RegisterContext(context.Background(), agent, identifier)
+}
+
+func (a *agentManager) RegisterContext(ctx context.Context, agent SecretAgent, identifier string) error {
+	return a.register(ctx, agent, AgentManagerRegister, identifier)
+}
+
+func (a *agentManager) RegisterWithCapabilities(agent SecretAgent, identifier string, capabilities NmSecretAgentCapabilities) error {
+	return a.RegisterWithCapabilitiesContext(context.Background(), agent, identifier, capabilities)
+}
+
+func (a *agentManager) RegisterWithCapabilitiesContext(ctx context.Context, agent SecretAgent, identifier string, capabilities NmSecretAgentCapabilities) error {
+	return a.register(ctx, agent, AgentManagerRegisterWithCapabilities, identifier, uint32(capabilities))
+}
+
+func (a *agentManager) register(ctx context.Context, agent SecretAgent, method string, args ...interface{}) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	previous := a.exported
+	export := newSecretAgentExport(a.conn, agent)
+	if err := a.conn.Export(export, SecretAgentObjectPath, SecretAgentInterface); err != nil {
+		return err
+	}
+
+	if err := a.call(ctx, method, args...); err != nil {
+		a.restoreExport(previous)
+		return err
+	}
+
+	if previous != nil {
+		previous.cancelAll()
+	}
+	a.exported = export
+	return nil
+}
+
+func (a *agentManager) restoreExport(export *secretAgentExport) {
+	var v interface{}
+	if export != nil {
+		v = export
+	}
+	a.conn.Export(v, SecretAgentObjectPath, SecretAgentInterface)
+}
+
+func (a *agentManager) Unregister() error {
+	return a.UnregisterContext(context.Background())
+}
+
+func (a *agentManager) UnregisterContext(ctx context.Context) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	err := a.call(ctx, AgentManagerUnregister)
+
+	a.restoreExport(nil)
+	if a.exported != nil {
+		a.exported.cancelAll()
+		a.exported = nil
+	}
+	return err
+}
@@ -0,0 +1,345 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusObjectManagerInterface  = "org.freedesktop.DBus.ObjectManager"
+	dbusObjectManagerPath       = dbus.ObjectPath("/org/freedesktop")
+	dbusMethodGetManagedObjects = dbusObjectManagerInterface + ".GetManagedObjects"
+	dbusSignalInterfacesAdded   = dbusObjectManagerInterface + ".InterfacesAdded"
+	dbusSignalInterfacesRemoved = dbusObjectManagerInterface + ".InterfacesRemoved"
+	dbusSignalNameOwnerChanged  = "org.freedesktop.DBus.NameOwnerChanged"
+	networkManagerOwnerRule     = "type='signal',sender='org.freedesktop.DBus',interface='org.freedesktop.DBus',member='NameOwnerChanged',arg0='" + NetworkManagerInterface + "'"
+	cacheSignalRule             = "type='signal',sender='" + NetworkManagerInterface + "',path_namespace='" + string(dbusObjectManagerPath) + "'"
+	cacheSignalBufferSize       = 1024
+)
+
+// cacheRestartTimeout bounds the load of the objects of a restarted
+// NetworkManager, during which no signal is applied.
+const cacheRestartTimeout = 25 * time.Second
+
+// ErrCacheEnabled is returned by NetworkManager.EnableCache when a cache is
+// already enabled on the D-Bus connection.
+var ErrCacheEnabled = errors.New("a cache is already enabled on this D-Bus connection")
+
+// Cache keeps a copy of the properties of every NetworkManager object,
+// returned by NetworkManager.EnableCache.
+//
+// While it is enabled, the GetProperty* methods of all the objects created
+// with the same D-Bus connection read from it instead of calling
+// org.freedesktop.DBus.Properties.Get. The cache is updated asynchronously
+// from signals, so a property read right after a change, e.g. after a
+// method call, may still return the previous value; the copy of an object
+// returned by its WithoutCache method reads it over D-Bus. Objects,
+// interfaces and properties the cache does not know are always read over
+// D-Bus.
+type Cache interface {
+	// Reload replaces the content of the cache with a fresh copy of the object tree, e.g. in case signals were lost.
+	Reload() error
+	ReloadContext(ctx context.Context) error
+
+	// Close stops updating the cache and makes the getters read the properties over D-Bus again.
+	Close() error
+}
+
+var (
+	cachesLock sync.RWMutex
+	caches     = make(map[*dbus.Conn]*cache)
+)
+
+// cachedProperty returns the value of a property from the cache enabled on
+// conn, if any.
+func cachedProperty(conn *dbus.Conn, path dbus.ObjectPath, iface, name string) (interface{}, bool) {
+	c := enabledCache(conn)
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	v, ok := c.objects[path][iface][name]
+	if !ok {
+		return nil, false
+	}
+	return v.Value(), true
+}
+
+// cachedProperties returns a copy of the properties of an interface from the
+// cache enabled on conn, if any.
+func cachedProperties(conn *dbus.Conn, path dbus.ObjectPath, iface string) (map[string]dbus.Variant, bool) {
+	c := enabledCache(conn)
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	props, ok := c.objects[path][iface]
+	if !ok {
+		return nil, false
+	}
+
+	rv := make(map[string]dbus.Variant, len(props))
+	for name, v := range props {
+		rv[name] = v
+	}
+	return rv, true
+}
+
+func enabledCache(conn *dbus.Conn) *cache {
+	cachesLock.RLock()
+	defer cachesLock.RUnlock()
+
+	return caches[conn]
+}
+
+type cache struct {
+	dbusBase
+
+	// lock guards owner, the unique bus name of NetworkManager whose signals
+	// are applied, and objects.
+	lock    sync.RWMutex
+	owner   string
+	objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+
+	signal  chan *dbus.Signal
+	reloads chan cacheReload
+	done    chan struct{}
+	once    sync.Once
+}
+
+type cacheReload struct {
+	ctx context.Context
+	err chan error
+}
+
+func newCache(ctx context.Context, conn *dbus.Conn) (*cache, error) {
+	c := &cache{
+		signal:  make(chan *dbus.Signal, cacheSignalBufferSize),
+		reloads: make(chan cacheReload),
+		done:    make(chan struct{}),
+	}
+	if err := c.initWithConn(conn, NetworkManagerInterface, dbusObjectManagerPath); err != nil {
+		return nil, err
+	}
+
+	// Subscribe before loading, so that no change made in between is
+	// missed. Signals queued during the load are applied on top of it;
+	// they carry absolute values, so the cache converges to the current
+	// state.
+	for _, rule := range []string{cacheSignalRule, networkManagerOwnerRule} {
+		if err := c.conn.BusObject().CallWithContext(ctx, dbusMethodAddMatch, 0, rule).Err; err != nil {
+			c.removeMatches()
+			return nil, err
+		}
+	}
+	c.conn.Signal(c.signal)
+
+	if err := c.load(ctx); err != nil {
+		c.conn.RemoveSignal(c.signal)
+		c.removeMatches()
+		return nil, err
+	}
+
+	cachesLock.Lock()
+	if _, ok := caches[conn]; ok {
+		cachesLock.Unlock()
+		c.conn.RemoveSignal(c.signal)
+		c.removeMatches()
+		return nil, ErrCacheEnabled
+	}
+	caches[conn] = c
+	cachesLock.Unlock()
+
+	go c.run()
+
+	return c, nil
+}
+
+func (c *cache) Reload() error {
+	return c.ReloadContext(context.Background())
+}
+
+func (c *cache) ReloadContext(ctx context.Context) error {
+	// The load happens in run, between two signals, so that none of them is
+	// applied before it and then overwritten.
+	r := cacheReload{ctx: ctx, err: make(chan error, 1)}
+	select {
+	case c.reloads <- r:
+		return <-r.err
+	case <-c.done:
+		return errors.New("cache closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *cache) Close() (err error) {
+	c.once.Do(func() {
+		c.unregister()
+		close(c.done)
+		c.conn.RemoveSignal(c.signal)
+		err = c.removeMatches()
+	})
+	return
+}
+
+func (c *cache) unregister() {
+	cachesLock.Lock()
+	defer cachesLock.Unlock()
+
+	if caches[c.conn] == c {
+		delete(caches, c.conn)
+	}
+}
+
+func (c *cache) removeMatches() (err error) {
+	for _, rule := range []string{cacheSignalRule, networkManagerOwnerRule} {
+		if e := c.conn.BusObject().Call(dbusMethodRemoveMatch, 0, rule).Err; e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// load replaces the content of the cache with the result of
+// GetManagedObjects.
+func (c *cache) load(ctx context.Context) error {
+	var owner string
+	if err := c.conn.BusObject().CallWithContext(ctx, dbusMethodGetNameOwner, 0, NetworkManagerInterface).Store(&owner); err != nil {
+		return err
+	}
+
+	// The bodies are read as is, here and in apply: Store would rebuild the
+	// variants and lose the signature of the struct properties, which
+	// getAllProperties relies on.
+	call := c.obj.CallWithContext(ctx, dbusMethodGetManagedObjects, 0)
+	if call.Err != nil {
+		return fmt.Errorf("loading the NetworkManager objects: %w", call.Err)
+	}
+	if len(call.Body) != 1 {
+		return makeErrVariantType(dbusMethodGetManagedObjects)
+	}
+	objects, ok := call.Body[0].(map[dbus.ObjectPath]map[string]map[string]dbus.Variant)
+	if !ok {
+		return makeErrVariantType(dbusMethodGetManagedObjects)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.owner = owner
+	c.objects = objects
+	return nil
+}
+
+func (c *cache) run() {
+	for {
+		select {
+		case sig, ok := <-c.signal:
+			if !ok {
+				// The D-Bus connection is gone; the content can no longer be
+				// kept up to date.
+				c.unregister()
+				return
+			}
+			c.apply(sig)
+
+		case r := <-c.reloads:
+			r.err <- c.load(r.ctx)
+
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *cache) apply(sig *dbus.Signal) {
+	if sig.Name == dbusSignalNameOwnerChanged {
+		var name, oldOwner, newOwner string
+		if dbus.Store(sig.Body, &name, &oldOwner, &newOwner) != nil || name != NetworkManagerInterface {
+			return
+		}
+
+		// NetworkManager exited or restarted: none of its objects exist
+		// anymore, and the signals of a new instance may have been ignored
+		// until now.
+		c.lock.Lock()
+		c.owner = newOwner
+		c.objects = make(map[dbus.ObjectPath]map[string]map[string]dbus.Variant)
+		c.lock.Unlock()
+
+		if newOwner != "" {
+			ctx, cancel := context.WithTimeout(context.Background(), cacheRestartTimeout)
+			c.load(ctx)
+			cancel()
+		}
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if sig.Sender != c.owner {
+		return
+	}
+
+	switch sig.Name {
+	case dbusPropertiesChanged:
+		if len(sig.Body) != 3 {
+			return
+		}
+		iface, _ := sig.Body[0].(string)
+		changed, _ := sig.Body[1].(map[string]dbus.Variant)
+		invalidated, _ := sig.Body[2].([]string)
+
+		props, ok := c.objects[sig.Path][iface]
+		if !ok {
+			return
+		}
+		for name, value := range changed {
+			props[name] = value
+		}
+		for _, name := range invalidated {
+			delete(props, name)
+		}
+
+	case dbusSignalInterfacesAdded:
+		if len(sig.Body) != 2 {
+			return
+		}
+		path, _ := sig.Body[0].(dbus.ObjectPath)
+		interfaces, _ := sig.Body[1].(map[string]map[string]dbus.Variant)
+
+		if c.objects[path] == nil {
+			c.objects[path] = make(map[string]map[string]dbus.Variant)
+		}
+		for iface, props := range interfaces {
+			c.objects[path][iface] = props
+		}
+
+	case dbusSignalInterfacesRemoved:
+		var path dbus.ObjectPath
+		var interfaces []string
+		if dbus.Store(sig.Body, &path, &interfaces) != nil {
+			return
+		}
+
+		for _, iface := range interfaces {
+			delete(c.objects[path], iface)
+		}
+		if len(c.objects[path]) == 0 {
+			delete(c.objects, path)
+		}
+	}
+}
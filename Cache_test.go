@@ -0,0 +1,100 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+	"time"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// eventually calls cond until it returns true, failing the test after a few
+// seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCache(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	cache, err := f.nm.EnableCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { cache.Close() }()
+
+	if _, err := f.nm.EnableCache(); err != gnm.ErrCacheEnabled {
+		t.Errorf("second EnableCache() = %v, want %v", err, gnm.ErrCacheEnabled)
+	}
+
+	// Only the cache can answer from now on.
+	if err := f.srv.RemoveMethod("org.freedesktop.DBus.Properties.Get"); err != nil {
+		t.Fatal(err)
+	}
+
+	if iface, err := d.GetPropertyInterface(); err != nil || iface != "eth0" {
+		t.Errorf("GetPropertyInterface() = %q, %v, want eth0", iface, err)
+	}
+	if _, err := d.WithoutCache().GetPropertyInterface(); err == nil {
+		t.Error("GetPropertyInterface() of WithoutCache() did not call Properties.Get")
+	}
+	if _, err := d.GetPropertyInterface(); err != nil {
+		t.Errorf("GetPropertyInterface() after WithoutCache() = %v, want the cached value", err)
+	}
+	w, _ := newWifiDevice(t, f)
+	if _, ok := w.WithoutCache().(gnm.DeviceWireless); !ok {
+		t.Errorf("WithoutCache() of a Wi-Fi device = %T, want a DeviceWireless", w.WithoutCache())
+	}
+
+	// Property changes are applied from the signals.
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateActivated, gnm.NmDeviceStateReasonNone)
+	eventually(t, "the device state", func() bool {
+		state, err := d.GetPropertyState()
+		return err == nil && state == gnm.NmDeviceStateActivated
+	})
+
+	// So are new objects.
+	d2, _ := f.addDevice(t, "eth1", gnm.NmDeviceTypeEthernet)
+	eventually(t, "the new device", func() bool {
+		iface, err := d2.GetPropertyInterface()
+		return err == nil && iface == "eth1"
+	})
+
+	if err := cache.Reload(); err != nil {
+		t.Fatalf("Reload() = %v", err)
+	}
+	if iface, err := d2.GetPropertyInterface(); err != nil || iface != "eth1" {
+		t.Errorf("GetPropertyInterface() after Reload() = %q, %v, want eth1", iface, err)
+	}
+
+	if err := f.srv.RemoveMethod("org.freedesktop.DBus.Properties.GetAll"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetProperties(); err != nil {
+		t.Errorf("GetProperties() = %v, want the cached values", err)
+	}
+	if _, err := d.WithoutCache().GetProperties(); err == nil {
+		t.Error("GetProperties() of WithoutCache() did not call Properties.GetAll")
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetPropertyInterface(); err == nil {
+		t.Error("GetPropertyInterface() still reads from the closed cache")
+	}
+	cache, err = f.nm.EnableCache()
+	if err != nil {
+		t.Fatalf("EnableCache() after Close() = %v", err)
+	}
+}
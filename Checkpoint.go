@@ -1,7 +1,11 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 )
@@ -15,17 +19,35 @@ const (
 	CheckpointPropertyRollbackTimeout = CheckpointInterface + ".RollbackTimeout" // readable   u
 )
 
+// CheckpointProperties holds the properties of a checkpoint, read at once by
+// Checkpoint.GetProperties.
+type CheckpointProperties struct {
+	Devices         []dbus.ObjectPath
+	Created         int64
+	RollbackTimeout uint32
+}
+
 type Checkpoint interface {
 	GetPath() dbus.ObjectPath
 
 	// Array of object paths for devices which are part of this checkpoint.
 	GetPropertyDevices() ([]Device, error)
+	GetPropertyDevicesContext(ctx context.Context) ([]Device, error)
 
 	// The timestamp (in CLOCK_BOOTTIME milliseconds) of checkpoint creation.
 	GetPropertyCreated() (int64, error)
+	GetPropertyCreatedContext(ctx context.Context) (int64, error)
 
 	// Timeout in seconds for automatic rollback, or zero.
 	GetPropertyRollbackTimeout() (uint32, error)
+	GetPropertyRollbackTimeoutContext(ctx context.Context) (uint32, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*CheckpointProperties, error)
+	GetPropertiesContext(ctx context.Context) (*CheckpointProperties, error)
+
+	// WithoutCache returns a copy of the checkpoint whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() Checkpoint
 
 	MarshalJSON() ([]byte, error)
 }
@@ -35,19 +57,34 @@ func NewCheckpoint(objectPath dbus.ObjectPath) (Checkpoint, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewCheckpointWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (Checkpoint, error) {
+	var c checkpoint
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type checkpoint struct {
 	dbusBase
 }
 
+func (c *checkpoint) WithoutCache() Checkpoint {
+	copied := *c
+	copied.uncached = true
+	return &copied
+}
+
 func (c *checkpoint) GetPropertyDevices() ([]Device, error) {
-	devicesPaths, err := c.getSliceObjectProperty(CheckpointPropertyDevices)
+	return c.GetPropertyDevicesContext(context.Background())
+}
+
+func (c *checkpoint) GetPropertyDevicesContext(ctx context.Context) ([]Device, error) {
+	devicesPaths, err := c.getSliceObjectProperty(ctx, CheckpointPropertyDevices)
 	if err != nil {
 		return nil, err
 	}
 
 	devices := make([]Device, len(devicesPaths))
 	for i, path := range devicesPaths {
-		devices[i], err = NewDevice(path)
+		devices[i], err = NewDeviceWithConn(c.conn, path)
 		if err != nil {
 			return devices, err
 		}
@@ -57,23 +94,134 @@ func (c *checkpoint) GetPropertyDevices() ([]Device, error) {
 }
 
 func (c *checkpoint) GetPropertyCreated() (int64, error) {
-	return c.getInt64Property(CheckpointPropertyCreated)
+	return c.GetPropertyCreatedContext(context.Background())
+}
+
+func (c *checkpoint) GetPropertyCreatedContext(ctx context.Context) (int64, error) {
+	return c.getInt64Property(ctx, CheckpointPropertyCreated)
 }
 
 func (c *checkpoint) GetPropertyRollbackTimeout() (uint32, error) {
-	return c.getUint32Property(CheckpointPropertyRollbackTimeout)
+	return c.GetPropertyRollbackTimeoutContext(context.Background())
+}
+
+func (c *checkpoint) GetPropertyRollbackTimeoutContext(ctx context.Context) (uint32, error) {
+	return c.getUint32Property(ctx, CheckpointPropertyRollbackTimeout)
 }
 
 func (c *checkpoint) GetPath() dbus.ObjectPath {
 	return c.obj.Path()
 }
 
+func (c *checkpoint) GetProperties() (*CheckpointProperties, error) {
+	return c.GetPropertiesContext(context.Background())
+}
+
+func (c *checkpoint) GetPropertiesContext(ctx context.Context) (*CheckpointProperties, error) {
+	props, err := c.getAllProperties(ctx, CheckpointInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p CheckpointProperties
+	return &p, decodeProperties(CheckpointInterface, props, &p)
+}
+
 func (c *checkpoint) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
 
-	m["Devices"], _ = c.GetPropertyDevices()
-	m["Created"], _ = c.GetPropertyCreated()
-	m["RollbackTimeout"], _ = c.GetPropertyRollbackTimeout()
+	p, err := c.GetProperties()
+	if err != nil {
+		p = &CheckpointProperties{}
+	}
+
+	devices := make([]Device, 0, len(p.Devices))
+	for _, path := range p.Devices {
+		if device, err := NewDeviceWithConn(c.conn, path); err == nil {
+			devices = append(devices, device)
+		}
+	}
+	m["Devices"] = devices
+	m["Created"] = p.Created
+	m["RollbackTimeout"] = p.RollbackTimeout
 
 	return json.Marshal(m)
 }
+
+func (nm *networkManager) WithCheckpoint(ctx context.Context, devices []Device, rollbackTimeout uint32, fn func() error) (err error) {
+	flags := []NmCheckpointCreateFlags{NmCheckpointCreateFlagsDeleteNewConnections, NmCheckpointCreateFlagsDisconnectNewDevices}
+	cp, err := nm.CheckpointCreateContext(ctx, devices, rollbackTimeout, flags)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go nm.extendCheckpoint(ctx, cp, rollbackTimeout, stop, stopped)
+
+	defer func() {
+		close(stop)
+		<-stopped
+
+		if r := recover(); r != nil {
+			err = fmt.Errorf("checkpoint function panicked: %v", r)
+		}
+
+		cleanupCtx, cancel := cleanupContext()
+		defer cancel()
+
+		if err != nil {
+			err = nm.rollbackCheckpoint(cleanupCtx, cp, err)
+		} else {
+			err = nm.CheckpointDestroyContext(cleanupCtx, cp)
+		}
+	}()
+
+	return fn()
+}
+
+// extendCheckpoint resets the rollback timeout of the checkpoint every half
+// timeout until stop is closed or ctx is done.
+func (nm *networkManager) extendCheckpoint(ctx context.Context, cp Checkpoint, rollbackTimeout uint32, stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	if rollbackTimeout == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(rollbackTimeout) * time.Second / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			nm.CheckpointAdjustRollbackTimeoutContext(ctx, cp, rollbackTimeout)
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// rollbackCheckpoint rolls back the checkpoint after the transaction failed
+// with err, adding the rollback failures to the returned error.
+func (nm *networkManager) rollbackCheckpoint(ctx context.Context, cp Checkpoint, err error) error {
+	results, rollbackErr := nm.CheckpointRollbackContext(ctx, cp)
+	if rollbackErr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+	}
+
+	var failed []string
+	for path, result := range results {
+		if result != NmRollbackResultOk {
+			failed = append(failed, fmt.Sprintf("%s: %v", path, result))
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("%w (rollback failed for %v)", err, failed)
+	}
+
+	return err
+}
@@ -0,0 +1,99 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestWithCheckpoint(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name            string
+		rollbackTimeout uint32
+		cancel          bool
+		fnErr           error
+		wantProfiles    int
+	}{
+		{name: "success", fnErr: nil, wantProfiles: 1},
+		{name: "failure", fnErr: errFn, wantProfiles: 0},
+		{name: "success with timeout", rollbackTimeout: 60, fnErr: nil, wantProfiles: 1},
+		{name: "failure with timeout", rollbackTimeout: 60, fnErr: errFn, wantProfiles: 0},
+		{name: "cancelled success", cancel: true, fnErr: nil, wantProfiles: 1},
+		{name: "cancelled failure", cancel: true, fnErr: errFn, wantProfiles: 0},
+		{name: "cancelled success with timeout", rollbackTimeout: 60, cancel: true, fnErr: nil, wantProfiles: 1},
+		{name: "cancelled failure with timeout", rollbackTimeout: 60, cancel: true, fnErr: errFn, wantProfiles: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake(t)
+			defer f.Close()
+
+			s, err := gnm.NewSettingsWithConn(f.conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			err = f.nm.WithCheckpoint(ctx, nil, tt.rollbackTimeout, func() error {
+				settings, err := gnm.NewConnectionSettings(&gnm.SettingConnection{Id: "new", Type: gnm.SettingWiredSettingName})
+				if err != nil {
+					return err
+				}
+				if _, err = s.AddConnectionContext(ctx, settings); err != nil {
+					return err
+				}
+				if tt.cancel {
+					cancel()
+				}
+				return tt.fnErr
+			})
+			if err != tt.fnErr {
+				t.Errorf("WithCheckpoint() = %v, want %v", err, tt.fnErr)
+			}
+
+			checkpoints, err := f.nm.GetPropertyCheckpoints()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(checkpoints) != 0 {
+				t.Errorf("%d checkpoints left", len(checkpoints))
+			}
+
+			profiles, err := s.ListConnections()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(profiles) != tt.wantProfiles {
+				t.Errorf("%d connection profiles, want %d", len(profiles), tt.wantProfiles)
+			}
+		})
+	}
+}
+
+func TestWithCheckpointPanic(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	err := f.nm.WithCheckpoint(context.Background(), nil, 0, func() error {
+		panic("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("WithCheckpoint() = %v, want the panic as an error", err)
+	}
+
+	checkpoints, err := f.nm.GetPropertyCheckpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checkpoints) != 0 {
+		t.Errorf("%d checkpoints left", len(checkpoints))
+	}
+}
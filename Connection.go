@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -33,39 +34,68 @@ type Connection interface {
 
 	// Update the connection with new settings and properties (replacing all previous settings and properties) and save the connection to disk. Secrets may be part of the update request, and will be either stored in persistent storage or sent to a Secret Agent for storage, depending on the flags associated with each secret.
 	Update(settings ConnectionSettings) error
+	UpdateContext(ctx context.Context, settings ConnectionSettings) error
+
+	// UpdateFromSettings updates the connection with typed settings, replacing all previous settings, and saves it to disk. Unlike NewConnectionSettings, no UUID is generated: the SettingConnection must carry the UUID of the connection.
+	UpdateFromSettings(settings ...Setting) error
+	UpdateFromSettingsContext(ctx context.Context, settings ...Setting) error
 
 	// Update the connection with new settings and properties (replacing all previous settings and properties) but do not immediately save the connection to disk. Secrets may be part of the update request and may sent to a Secret Agent for storage, depending on the flags associated with each secret. Use the 'Save' method to save these changes to disk. Note that unsaved changes will be lost if the connection is reloaded from disk (either automatically on file change or due to an explicit ReloadConnections call).
 	UpdateUnsaved(settings ConnectionSettings) error
+	UpdateUnsavedContext(ctx context.Context, settings ConnectionSettings) error
+
+	// Update the connection with new settings and properties (replacing all previous settings and properties). If the flag arguments do not specify otherwise, the connection is saved to disk. Secrets may be part of the update request and may sent to a Secret Agent for storage, depending on the flags associated with each secret.
+	// Since: 1.12
+	// settings: New connection settings, properties, and (optionally) secrets. Provide an empty map to not modify the settings.
+	// flags: Flags modifying the behavior, see NmSettingsUpdate2Flags.
+	// args: Optional arguments dictionary, for extentibility. Currently no arguments are accepted. Specifying unknown keys causes the call to fail.
+	// returns: Currently no results are returned.
+	Update2(settings ConnectionSettings, flags NmSettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error)
+	Update2Context(ctx context.Context, settings ConnectionSettings, flags NmSettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error)
 
 	// Delete the connection.
 	Delete() error
+	DeleteContext(ctx context.Context) error
 
 	// GetSettings gets the settings maps describing this network configuration.
 	// This will never include any secrets required for connection to the
 	// network, as those are often protected. Secrets must be requested
 	// separately using the GetSecrets() method.
 	GetSettings() (ConnectionSettings, error)
+	GetSettingsContext(ctx context.Context) (ConnectionSettings, error)
 
 	// Get the secrets belonging to this network configuration. Only secrets from
 	// persistent storage or a Secret Agent running in the requestor's session
 	// will be returned. The user will never be prompted for secrets as a result
 	// of this request.
 	GetSecrets(settingName string) (ConnectionSettings, error)
+	GetSecretsContext(ctx context.Context, settingName string) (ConnectionSettings, error)
 
 	// Clear the secrets belonging to this network connection profile.
 	ClearSecrets() error
+	ClearSecretsContext(ctx context.Context) error
 
 	// Saves a "dirty" connection (that had previously been updated with UpdateUnsaved) to persistent storage.
 	Save() error
+	SaveContext(ctx context.Context) error
 
 	// If set, indicates that the in-memory state of the connection does not match the on-disk state. This flag will be set when UpdateUnsaved() is called or when any connection details change, and cleared when the connection is saved to disk via Save() or from internal operations.
 	GetPropertyUnsaved() (bool, error)
+	GetPropertyUnsavedContext(ctx context.Context) (bool, error)
 
 	// Additional flags of the connection profile.
 	GetPropertyFlags() (uint32, error)
+	GetPropertyFlagsContext(ctx context.Context) (uint32, error)
 
 	// File that stores the connection in case the connection is file-backed.
 	GetPropertyFilename() (string, error)
+	GetPropertyFilenameContext(ctx context.Context) (string, error)
+
+	// WithValidation returns a copy of the connection whose Update, UpdateUnsaved and Update2 methods, with or without a context, check the settings with ValidateSettings and return its error instead of sending an invalid profile to NetworkManager.
+	WithValidation() Connection
+
+	// WithoutCache returns a copy of the connection whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() Connection
 
 	MarshalJSON() ([]byte, error)
 }
@@ -75,8 +105,27 @@ func NewConnection(objectPath dbus.ObjectPath) (Connection, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewConnectionWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (Connection, error) {
+	var c connection
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type connection struct {
 	dbusBase
+
+	validate bool
+}
+
+func (c *connection) WithValidation() Connection {
+	copied := *c
+	copied.validate = true
+	return &copied
+}
+
+func (c *connection) WithoutCache() Connection {
+	copied := *c
+	copied.uncached = true
+	return &copied
 }
 
 func (c *connection) GetPath() dbus.ObjectPath {
@@ -84,20 +133,100 @@ func (c *connection) GetPath() dbus.ObjectPath {
 }
 
 func (c *connection) Update(settings ConnectionSettings) error {
-	return c.call(ConnectionUpdate, settings)
+	return c.UpdateContext(context.Background(), settings)
+}
+
+func (c *connection) UpdateContext(ctx context.Context, settings ConnectionSettings) error {
+	return c.update(ctx, settings, NmSettingsUpdate2FlagsToDisk, ConnectionUpdate)
+}
+
+func (c *connection) UpdateFromSettings(settings ...Setting) error {
+	return c.UpdateFromSettingsContext(context.Background(), settings...)
+}
+
+func (c *connection) UpdateFromSettingsContext(ctx context.Context, settings ...Setting) error {
+	connectionSettings := make(ConnectionSettings)
+	for _, setting := range settings {
+		if err := connectionSettings.SetSetting(setting); err != nil {
+			return err
+		}
+	}
+
+	return c.UpdateContext(ctx, connectionSettings)
 }
 
 func (c *connection) UpdateUnsaved(settings ConnectionSettings) error {
-	return c.call(ConnectionUpdateUnsaved, settings)
+	return c.UpdateUnsavedContext(context.Background(), settings)
+}
+
+func (c *connection) UpdateUnsavedContext(ctx context.Context, settings ConnectionSettings) error {
+	return c.update(ctx, settings, NmSettingsUpdate2FlagsInMemory, ConnectionUpdateUnsaved)
+}
+
+// update calls Update2 with flags, falling back to the legacy method when the
+// daemon is older than NetworkManager 1.12.
+func (c *connection) update(ctx context.Context, settings ConnectionSettings, flags NmSettingsUpdate2Flags, legacyMethod string) error {
+	if err := validateBeforeSend(c.validate, settings); err != nil {
+		return err
+	}
+
+	_, err := c.update2(ctx, settings, flags, nil)
+	if isUnknownMethod(err) {
+		return c.call(ctx, legacyMethod, settings)
+	}
+	return err
+}
+
+func (c *connection) Update2(settings ConnectionSettings, flags NmSettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error) {
+	return c.Update2Context(context.Background(), settings, flags, args)
+}
+
+func (c *connection) Update2Context(ctx context.Context, settings ConnectionSettings, flags NmSettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error) {
+	if len(settings) > 0 {
+		if err := validateBeforeSend(c.validate, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.update2(ctx, settings, flags, args)
+}
+
+func (c *connection) update2(ctx context.Context, settings ConnectionSettings, flags NmSettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error) {
+	if settings == nil {
+		settings = ConnectionSettings{}
+	}
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	var result map[string]dbus.Variant
+	err := c.callWithReturn(ctx, &result, ConnectionUpdate2, settings, uint32(flags), args)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make(map[string]interface{}, len(result))
+	for k, v := range result {
+		rv[k] = v.Value()
+	}
+	return rv, nil
 }
 
 func (c *connection) Delete() error {
-	return c.call(ConnectionDelete)
+	return c.DeleteContext(context.Background())
+}
+
+func (c *connection) DeleteContext(ctx context.Context) error {
+	return c.call(ctx, ConnectionDelete)
 }
 
 func (c *connection) GetSettings() (ConnectionSettings, error) {
+	return c.GetSettingsContext(context.Background())
+}
+
+func (c *connection) GetSettingsContext(ctx context.Context) (ConnectionSettings, error) {
 	var settings map[string]map[string]dbus.Variant
-	err := c.callWithReturn(&settings, ConnectionGetSettings)
+	err := c.callWithReturn(ctx, &settings, ConnectionGetSettings)
 
 	if err != nil {
 		return nil, err
@@ -117,8 +246,12 @@ func (c *connection) GetSettings() (ConnectionSettings, error) {
 }
 
 func (c *connection) GetSecrets(settingName string) (ConnectionSettings, error) {
+	return c.GetSecretsContext(context.Background(), settingName)
+}
+
+func (c *connection) GetSecretsContext(ctx context.Context, settingName string) (ConnectionSettings, error) {
 	var settings map[string]map[string]dbus.Variant
-	err := c.callWithReturn(&settings, ConnectionGetSecrets, settingName)
+	err := c.callWithReturn(ctx, &settings, ConnectionGetSecrets, settingName)
 
 	if err != nil {
 		return nil, err
@@ -138,23 +271,43 @@ func (c *connection) GetSecrets(settingName string) (ConnectionSettings, error)
 }
 
 func (c *connection) ClearSecrets() error {
-	return c.call(ConnectionClearSecrets)
+	return c.ClearSecretsContext(context.Background())
+}
+
+func (c *connection) ClearSecretsContext(ctx context.Context) error {
+	return c.call(ctx, ConnectionClearSecrets)
 }
 
 func (c *connection) Save() error {
-	return c.call(ConnectionSave)
+	return c.SaveContext(context.Background())
+}
+
+func (c *connection) SaveContext(ctx context.Context) error {
+	return c.call(ctx, ConnectionSave)
 }
 
 func (c *connection) GetPropertyUnsaved() (bool, error) {
-	return c.getBoolProperty(ConnectionPropertyUnsaved)
+	return c.GetPropertyUnsavedContext(context.Background())
+}
+
+func (c *connection) GetPropertyUnsavedContext(ctx context.Context) (bool, error) {
+	return c.getBoolProperty(ctx, ConnectionPropertyUnsaved)
 }
 
 func (c *connection) GetPropertyFlags() (uint32, error) {
-	return c.getUint32Property(ConnectionPropertyFlags)
+	return c.GetPropertyFlagsContext(context.Background())
+}
+
+func (c *connection) GetPropertyFlagsContext(ctx context.Context) (uint32, error) {
+	return c.getUint32Property(ctx, ConnectionPropertyFlags)
 }
 
 func (c *connection) GetPropertyFilename() (string, error) {
-	return c.getStringProperty(ConnectionPropertyFilename)
+	return c.GetPropertyFilenameContext(context.Background())
+}
+
+func (c *connection) GetPropertyFilenameContext(ctx context.Context) (string, error) {
+	return c.getStringProperty(ctx, ConnectionPropertyFilename)
 }
 
 func (c *connection) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,105 @@
+package gonetworkmanager
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ConnectionIndex is a snapshot of the settings of the saved connections,
+// returned by Settings.GetConnectionIndex, to look connections up without
+// calling GetSettings on each of them. It is not updated when the
+// connections change.
+type ConnectionIndex struct {
+	entries []connectionIndexEntry
+}
+
+type connectionIndexEntry struct {
+	connection Connection
+	settings   ConnectionSettings
+}
+
+func newConnectionIndex(ctx context.Context, connections []Connection) (*ConnectionIndex, error) {
+	index := &ConnectionIndex{}
+	for _, connection := range connections {
+		settings, err := connection.GetSettingsContext(ctx)
+		if err != nil {
+			// The connection was deleted since it was listed.
+			if dbusErr, ok := err.(dbus.Error); ok && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownObject" {
+				continue
+			}
+			return nil, err
+		}
+
+		index.entries = append(index.entries, connectionIndexEntry{connection, settings})
+	}
+
+	return index, nil
+}
+
+// Connections returns every indexed connection.
+func (i *ConnectionIndex) Connections() []Connection {
+	return i.filter(func(ConnectionSettings) bool { return true })
+}
+
+// Settings returns the settings of a connection as they were when the index
+// was built, or nil if the connection is not indexed.
+func (i *ConnectionIndex) Settings(path dbus.ObjectPath) ConnectionSettings {
+	for _, entry := range i.entries {
+		if entry.connection.GetPath() == path {
+			return entry.settings
+		}
+	}
+	return nil
+}
+
+// ByUuid returns the connection with this connection.uuid, or nil.
+func (i *ConnectionIndex) ByUuid(uuid string) Connection {
+	connections := i.filter(settingEquals(SettingConnectionSettingName, "uuid", uuid))
+	if len(connections) == 0 {
+		return nil
+	}
+	return connections[0]
+}
+
+// ById returns the connections with this connection.id. Ids are not unique.
+func (i *ConnectionIndex) ById(id string) []Connection {
+	return i.filter(settingEquals(SettingConnectionSettingName, "id", id))
+}
+
+// ByInterfaceName returns the connections bound to this interface by their
+// connection.interface-name.
+func (i *ConnectionIndex) ByInterfaceName(name string) []Connection {
+	return i.filter(settingEquals(SettingConnectionSettingName, "interface-name", name))
+}
+
+// ByType returns the connections with this connection.type, e.g.
+// SettingWirelessSettingName.
+func (i *ConnectionIndex) ByType(connectionType string) []Connection {
+	return i.filter(settingEquals(SettingConnectionSettingName, "type", connectionType))
+}
+
+// BySsid returns the Wi-Fi connections to this SSID.
+func (i *ConnectionIndex) BySsid(ssid string) []Connection {
+	return i.filter(func(settings ConnectionSettings) bool {
+		value, _ := settings[SettingWirelessSettingName]["ssid"].([]byte)
+		return value != nil && string(value) == ssid
+	})
+}
+
+func (i *ConnectionIndex) filter(match func(ConnectionSettings) bool) []Connection {
+	var rv []Connection
+	for _, entry := range i.entries {
+		if match(entry.settings) {
+			rv = append(rv, entry.connection)
+		}
+	}
+	return rv
+}
+
+func settingEquals(setting, key, value string) func(ConnectionSettings) bool {
+	return func(settings ConnectionSettings) bool {
+		v, ok := settings[setting][key].(string)
+		return ok && v == value
+	}
+}
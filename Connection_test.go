@@ -0,0 +1,151 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestConnectionUpdate(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		name := "Update2"
+		if legacy {
+			name = "legacy"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			f := newFake(t)
+			defer f.Close()
+
+			if legacy {
+				// Mimic NetworkManager before 1.12.
+				if err := f.srv.RemoveMethod(gnm.ConnectionUpdate2); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c, obj := f.addConnection(t)
+
+			update := func(id string, unsaved bool) {
+				t.Helper()
+
+				settings, err := gnm.NewConnectionSettings(&gnm.SettingConnection{Id: id, Type: gnm.SettingWiredSettingName})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if unsaved {
+					err = c.UpdateUnsaved(settings)
+				} else {
+					err = c.Update(settings)
+				}
+				if err != nil {
+					t.Fatalf("update to %s: %v", id, err)
+				}
+
+				if got := obj.ConnectionSettings()["connection"]["id"]; got != id {
+					t.Errorf("id = %v, want %s", got, id)
+				}
+				if got, err := c.GetPropertyUnsaved(); err != nil || got != unsaved {
+					t.Errorf("GetPropertyUnsaved() = %v, %v, want %v", got, err, unsaved)
+				}
+			}
+
+			update("unsaved", true)
+			update("saved", false)
+
+			_, err := c.Update2(nil, gnm.NmSettingsUpdate2FlagsToDisk, nil)
+			if legacy && err == nil {
+				t.Error("Update2() succeeded without Update2")
+			}
+			if !legacy && err != nil {
+				t.Errorf("Update2() = %v", err)
+			}
+		})
+	}
+}
+
+func TestConnectionUpdateValidation(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, obj := f.addConnection(t)
+
+	invalid := gnm.ConnectionSettings{
+		"connection": {"id": "invalid", "type": gnm.SettingWiredSettingName},
+		"ipv4":       {"method": "manual"},
+	}
+
+	if err := c.WithValidation().Update(invalid); err == nil {
+		t.Fatal("Update() with WithValidation accepted an invalid profile")
+	} else if _, ok := err.(gnm.ValidationErrors); !ok {
+		t.Fatalf("Update() = %v, want ValidationErrors", err)
+	}
+	if _, err := c.WithValidation().Update2Context(context.Background(), invalid, gnm.NmSettingsUpdate2FlagsNone, nil); err == nil {
+		t.Fatal("Update2Context() with WithValidation accepted an invalid profile")
+	}
+	if got := obj.ConnectionSettings()["connection"]["id"]; got != "test" {
+		t.Errorf("id = %v after a rejected update, want test", got)
+	}
+
+	// Without WithValidation, even after it was used, the profile is left to
+	// NetworkManager.
+	if err := c.Update(invalid); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if got := obj.ConnectionSettings()["connection"]["id"]; got != "invalid" {
+		t.Errorf("id = %v, want invalid", got)
+	}
+}
+
+func TestSettingsAddConnectionValidation(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validating := s.WithValidation()
+
+	invalid := gnm.ConnectionSettings{
+		"connection": {"id": "invalid", "type": gnm.SettingWiredSettingName},
+		"ipv4":       {"method": "manual"},
+	}
+	if _, err := validating.AddConnection(invalid); err == nil {
+		t.Fatal("AddConnection() with WithValidation accepted an invalid profile")
+	} else if _, ok := err.(gnm.ValidationErrors); !ok {
+		t.Fatalf("AddConnection() = %v, want ValidationErrors", err)
+	}
+	if _, err := validating.AddConnectionUnsaved(invalid); err == nil {
+		t.Fatal("AddConnectionUnsaved() with WithValidation accepted an invalid profile")
+	}
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Fatalf("%d profiles added, want none", len(profiles))
+	}
+
+	// The connections of a validating settings object validate too.
+	valid, err := gnm.NewConnectionSettings(&gnm.SettingConnection{Id: "valid", Type: gnm.SettingWiredSettingName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := validating.AddConnection(valid)
+	if err != nil {
+		t.Fatalf("AddConnection() = %v", err)
+	}
+	if err := c.Update(invalid); err == nil {
+		t.Error("Update() of a connection added with WithValidation accepted an invalid profile")
+	}
+	connections, err := validating.ListConnections()
+	if err != nil || len(connections) != 1 {
+		t.Fatalf("ListConnections() = %v, %v, want one connection", connections, err)
+	}
+	if err := connections[0].Update(invalid); err == nil {
+		t.Error("Update() of a connection listed with WithValidation accepted an invalid profile")
+	}
+
+	// The original settings object does not validate.
+	if _, err := s.AddConnection(invalid); err != nil {
+		t.Errorf("AddConnection() without WithValidation = %v", err)
+	}
+}
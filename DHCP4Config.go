@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -15,9 +16,23 @@ const (
 
 type DHCP4Options map[string]interface{}
 
+// DHCP4ConfigProperties holds the properties of a DHCP4 configuration, read at once
+// by DHCP4Config.GetProperties.
+type DHCP4ConfigProperties struct {
+	Options DHCP4Options
+}
+
 type DHCP4Config interface {
 	// GetOptions gets options map of configuration returned by the IPv4 DHCP server.
 	GetPropertyOptions() (DHCP4Options, error)
+	GetPropertyOptionsContext(ctx context.Context) (DHCP4Options, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*DHCP4ConfigProperties, error)
+	GetPropertiesContext(ctx context.Context) (*DHCP4ConfigProperties, error)
+
+	// WithoutCache returns a copy of the DHCPv4 configuration whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() DHCP4Config
 
 	MarshalJSON() ([]byte, error)
 }
@@ -27,12 +42,27 @@ func NewDHCP4Config(objectPath dbus.ObjectPath) (DHCP4Config, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDHCP4ConfigWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DHCP4Config, error) {
+	var c dhcp4Config
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type dhcp4Config struct {
 	dbusBase
 }
 
+func (c *dhcp4Config) WithoutCache() DHCP4Config {
+	copied := *c
+	copied.uncached = true
+	return &copied
+}
+
 func (c *dhcp4Config) GetPropertyOptions() (DHCP4Options, error) {
-	options, err := c.getMapStringVariantProperty(DHCP4ConfigPropertyOptions)
+	return c.GetPropertyOptionsContext(context.Background())
+}
+
+func (c *dhcp4Config) GetPropertyOptionsContext(ctx context.Context) (DHCP4Options, error) {
+	options, err := c.getMapStringVariantProperty(ctx, DHCP4ConfigPropertyOptions)
 	rv := make(DHCP4Options)
 	if err != nil {
 		return rv, err
@@ -45,12 +75,35 @@ func (c *dhcp4Config) GetPropertyOptions() (DHCP4Options, error) {
 	return rv, nil
 }
 
+func (c *dhcp4Config) GetProperties() (*DHCP4ConfigProperties, error) {
+	return c.GetPropertiesContext(context.Background())
+}
+
+func (c *dhcp4Config) GetPropertiesContext(ctx context.Context) (*DHCP4ConfigProperties, error) {
+	props, err := c.getAllProperties(ctx, DHCP4ConfigInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	p := DHCP4ConfigProperties{Options: make(DHCP4Options)}
+	if v, ok := props["Options"]; ok {
+		options, ok := v.Value().(map[string]dbus.Variant)
+		if !ok {
+			return nil, makeErrVariantType(DHCP4ConfigPropertyOptions)
+		}
+		for k, v := range options {
+			p.Options[k] = v.Value()
+		}
+	}
+	return &p, nil
+}
+
 func (c *dhcp4Config) MarshalJSON() ([]byte, error) {
-	Options, err := c.GetPropertyOptions()
+	p, err := c.GetProperties()
 	if err != nil {
 		return nil, err
 	}
 	return json.Marshal(map[string]interface{}{
-		"Options": Options,
+		"Options": p.Options,
 	})
 }
@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -15,9 +16,23 @@ const (
 
 type DHCP6Options map[string]interface{}
 
+// DHCP6ConfigProperties holds the properties of a DHCP6 configuration, read at once
+// by DHCP6Config.GetProperties.
+type DHCP6ConfigProperties struct {
+	Options DHCP6Options
+}
+
 type DHCP6Config interface {
 	// GetOptions gets options map of configuration returned by the IPv4 DHCP server.
 	GetPropertyOptions() (DHCP6Options, error)
+	GetPropertyOptionsContext(ctx context.Context) (DHCP6Options, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*DHCP6ConfigProperties, error)
+	GetPropertiesContext(ctx context.Context) (*DHCP6ConfigProperties, error)
+
+	// WithoutCache returns a copy of the DHCPv6 configuration whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() DHCP6Config
 
 	MarshalJSON() ([]byte, error)
 }
@@ -27,12 +42,27 @@ func NewDHCP6Config(objectPath dbus.ObjectPath) (DHCP6Config, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDHCP6ConfigWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DHCP6Config, error) {
+	var c dhcp6Config
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type dhcp6Config struct {
 	dbusBase
 }
 
+func (c *dhcp6Config) WithoutCache() DHCP6Config {
+	copied := *c
+	copied.uncached = true
+	return &copied
+}
+
 func (c *dhcp6Config) GetPropertyOptions() (DHCP6Options, error) {
-	options, err := c.getMapStringVariantProperty(DHCP6ConfigPropertyOptions)
+	return c.GetPropertyOptionsContext(context.Background())
+}
+
+func (c *dhcp6Config) GetPropertyOptionsContext(ctx context.Context) (DHCP6Options, error) {
+	options, err := c.getMapStringVariantProperty(ctx, DHCP6ConfigPropertyOptions)
 	rv := make(DHCP6Options)
 
 	if err != nil {
@@ -46,9 +76,37 @@ func (c *dhcp6Config) GetPropertyOptions() (DHCP6Options, error) {
 	return rv, nil
 }
 
+func (c *dhcp6Config) GetProperties() (*DHCP6ConfigProperties, error) {
+	return c.GetPropertiesContext(context.Background())
+}
+
+func (c *dhcp6Config) GetPropertiesContext(ctx context.Context) (*DHCP6ConfigProperties, error) {
+	props, err := c.getAllProperties(ctx, DHCP6ConfigInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	p := DHCP6ConfigProperties{Options: make(DHCP6Options)}
+	if v, ok := props["Options"]; ok {
+		options, ok := v.Value().(map[string]dbus.Variant)
+		if !ok {
+			return nil, makeErrVariantType(DHCP6ConfigPropertyOptions)
+		}
+		for k, v := range options {
+			p.Options[k] = v.Value()
+		}
+	}
+	return &p, nil
+}
+
 func (c *dhcp6Config) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
-	m["Options"], _ = c.GetPropertyOptions()
+
+	p, err := c.GetProperties()
+	if err != nil {
+		p = &DHCP6ConfigProperties{Options: make(DHCP6Options)}
+	}
+	m["Options"] = p.Options
 
 	return json.Marshal(m)
 }
@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -45,103 +46,244 @@ const (
 )
 
 func DeviceFactory(objectPath dbus.ObjectPath) (Device, error) {
-	d, err := NewDevice(objectPath)
+	return DeviceFactoryContext(context.Background(), objectPath)
+}
+
+func DeviceFactoryContext(ctx context.Context, objectPath dbus.ObjectPath) (Device, error) {
+	conn, err := dbus.SystemBus()
 	if err != nil {
 		return nil, err
 	}
 
-	deviceType, err := d.GetPropertyDeviceType()
+	return DeviceFactoryWithConnContext(ctx, conn, objectPath)
+}
+
+func DeviceFactoryWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (Device, error) {
+	return DeviceFactoryWithConnContext(context.Background(), conn, objectPath)
+}
+
+// DeviceFactoryWithConnContext looks up the device type to return the
+// matching specialized Device. The context bounds that D-Bus round-trip.
+func DeviceFactoryWithConnContext(ctx context.Context, conn *dbus.Conn, objectPath dbus.ObjectPath) (Device, error) {
+	d, err := NewDeviceWithConn(conn, objectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceType, err := d.GetPropertyDeviceTypeContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	switch deviceType {
 	case NmDeviceTypeDummy:
-		return NewDeviceDummy(objectPath)
+		return NewDeviceDummyWithConn(conn, objectPath)
 	case NmDeviceTypeGeneric:
-		return NewDeviceGeneric(objectPath)
+		return NewDeviceGenericWithConn(conn, objectPath)
 	case NmDeviceTypeIpTunnel:
-		return NewDeviceIpTunnel(objectPath)
+		return NewDeviceIpTunnelWithConn(conn, objectPath)
 	case NmDeviceTypeEthernet:
-		return NewDeviceWired(objectPath)
+		return NewDeviceWiredWithConn(conn, objectPath)
 	case NmDeviceTypeWifi:
-		return NewDeviceWireless(objectPath)
+		return NewDeviceWirelessWithConn(conn, objectPath)
+	case NmDeviceTypeBond:
+		return NewDeviceBondWithConn(conn, objectPath)
+	case NmDeviceTypeBridge:
+		return NewDeviceBridgeWithConn(conn, objectPath)
+	case NmDeviceTypeTeam:
+		return NewDeviceTeamWithConn(conn, objectPath)
+	case NmDeviceTypeVlan:
+		return NewDeviceVlanWithConn(conn, objectPath)
+	case NmDeviceTypeMacvlan:
+		return NewDeviceMacvlanWithConn(conn, objectPath)
+	case NmDeviceTypeVxlan:
+		return NewDeviceVxlanWithConn(conn, objectPath)
+	case NmDeviceTypeWireguard:
+		return NewDeviceWireGuardWithConn(conn, objectPath)
+	case NmDeviceTypeModem:
+		return NewDeviceModemWithConn(conn, objectPath)
+	case NmDeviceTypeOvsInterface:
+		return NewDeviceOvsInterfaceWithConn(conn, objectPath)
+	case NmDeviceTypeOvsPort:
+		return NewDeviceOvsPortWithConn(conn, objectPath)
+	case NmDeviceTypeOvsBridge:
+		return NewDeviceOvsBridgeWithConn(conn, objectPath)
 	}
 
 	return d, nil
 }
 
+// devicesWithConn returns the specialized Device of each object path.
+func devicesWithConn(ctx context.Context, conn *dbus.Conn, paths []dbus.ObjectPath) ([]Device, error) {
+	devices := make([]Device, len(paths))
+	for i, path := range paths {
+		var err error
+		if devices[i], err = DeviceFactoryWithConnContext(ctx, conn, path); err != nil {
+			return nil, err
+		}
+	}
+	return devices, nil
+}
+
+// DeviceStateReason is the StateReason property of a device.
+type DeviceStateReason struct {
+	State  NmDeviceState
+	Reason NmDeviceStateReason
+}
+
+// DeviceProperties holds the properties of the DeviceInterface of a device,
+// read at once by Device.GetProperties.
+type DeviceProperties struct {
+	Udi                  string
+	Interface            string
+	IpInterface          string
+	Driver               string
+	DriverVersion        string
+	FirmwareVersion      string
+	Capabilities         uint32
+	State                NmDeviceState
+	StateReason          DeviceStateReason
+	ActiveConnection     dbus.ObjectPath
+	Ip4Config            dbus.ObjectPath
+	Dhcp4Config          dbus.ObjectPath
+	Ip6Config            dbus.ObjectPath
+	Dhcp6Config          dbus.ObjectPath
+	Managed              bool
+	Autoconnect          bool
+	FirmwareMissing      bool
+	NmPluginMissing      bool
+	DeviceType           NmDeviceType
+	AvailableConnections []dbus.ObjectPath
+	PhysicalPortId       string
+	Mtu                  uint32
+	Metered              NmMetered
+	LldpNeighbors        []map[string]dbus.Variant
+	Real                 bool
+	Ip4Connectivity      NmConnectivity
+}
+
 type Device interface {
 	GetPath() dbus.ObjectPath
 
+	// Attempts to update the configuration of a device without deactivating it. NetworkManager has the concept of connections, which are profiles that contain the configuration for a networking device. Those connections are exposed via D-Bus as individual objects that can be created, modified and deleted. When activating such a settings-connection on a device, the settings-connection is cloned to become an applied-connection and used to configure the device (see GetAppliedConnection). Subsequent modification of the settings-connection don't propagate automatically to the device's applied-connection (with exception of the firewall-zone and the metered property). For the changes to take effect, you can either re-activate the settings-connection, or call Reapply. The Reapply call allows you to directly update the applied-connection and reconfigure the device. Reapply can also be useful if the currently applied-connection is equal to the connection that is about to be reapplied. This allows to reconfigure the device and revert external changes like removing or adding an IP address (which NetworkManager doesn't revert automatically because it is assumed that the user made these changes intentionally outside of NetworkManager). Reapply can make the applied-connection different from the settings-connection, just like updating the settings-connection can make them different.
+	// connection: The optional connection settings that will be reapplied on the device. If empty, the currently active settings-connection will be used. The connection cannot arbitrarily differ from the current applied-connection otherwise the call will fail. Only certain changes are supported, like adding or removing IP addresses.
+	// versionId: If non-zero, the current version id of the applied-connection must match. The current version id can be retrieved via GetAppliedConnection. This optional argument allows to catch concurrent modifications between the GetAppliedConnection call and Reapply.
+	// flags: Flags which would modify the behavior of the Reapply call. There are no flags defined currently and the users should use the value of 0.
+	Reapply(connection ConnectionSettings, versionId uint64, flags uint32) error
+	ReapplyContext(ctx context.Context, connection ConnectionSettings, versionId uint64, flags uint32) error
+
+	// Get the currently applied connection on the device. This is a snapshot of the last activated connection on the device, that is the configuration that is currently applied on the device. Usually this is the same as GetSettings of the referenced settings connection. However, it can differ if the settings connection was subsequently modified or the applied connection was modified by Reapply. The applied connection is set when activating a device or when calling Reapply.
+	// flags: Flags which would modify the behavior of the GetAppliedConnection call. There are no flags defined currently and the users should use the value of 0.
+	// returns: The effective connection settings that the connection has currently applied, and the version id of the applied connection, for use with Reapply.
+	GetAppliedConnection(flags uint32) (ConnectionSettings, uint64, error)
+	GetAppliedConnectionContext(ctx context.Context, flags uint32) (ConnectionSettings, uint64, error)
+
+	// ReapplyIPSettings updates the "ipv4" and "ipv6" settings of the applied connection, such as addresses, gateway, DNS and routes, without bouncing the link. Only the keys of those settings present in settings are considered, other settings are ignored; the keys whose value differs from the applied connection are merged into it and sent with Reapply, guarded by its version id. Changing address-data, gateway or route-data drops the deprecated addresses and routes keys of the applied connection unless settings has them. It returns whether anything was reapplied.
+	ReapplyIPSettings(settings ConnectionSettings) (bool, error)
+	ReapplyIPSettingsContext(ctx context.Context, settings ConnectionSettings) (bool, error)
+
 	// Disconnects a device and prevents the device from automatically activating further connections without user intervention.
 	Disconnect() error
+	DisconnectContext(ctx context.Context) error
 
 	// Deletes a software device from NetworkManager and removes the interface from the system. The method returns an error when called for a hardware device.
 	Delete() error
+	DeleteContext(ctx context.Context) error
 
 	// Operating-system specific transient device hardware identifier. This is an opaque string representing the underlying hardware for the device, and shouldn't be used to keep track of individual devices. For some device types (Bluetooth, Modems) it is an identifier used by the hardware service (ie bluez or ModemManager) to refer to that device, and client programs use it get additional information from those services which NM does not provide. The Udi is not guaranteed to be consistent across reboots or hotplugs of the hardware. If you're looking for a way to uniquely track each device in your application, use the object path. If you're looking for a way to track a specific piece of hardware across reboot or hotplug, use a MAC address or USB serial number.
 	GetPropertyUdi() (string, error)
+	GetPropertyUdiContext(ctx context.Context) (string, error)
 
 	// The name of the device's control (and often data) interface. Note that non UTF-8 characters are backslash escaped, so the resulting name may be longer then 15 characters. Use g_strcompress() to revert the escaping.
 	GetPropertyInterface() (string, error)
+	GetPropertyInterfaceContext(ctx context.Context) (string, error)
 
 	// The name of the device's data interface when available. This property may not refer to the actual data interface until the device has successfully established a data connection, indicated by the device's State becoming ACTIVATED. Note that non UTF-8 characters are backslash escaped, so the resulting name may be longer then 15 characters. Use g_strcompress() to revert the escaping.
 	GetPropertyIpInterface() (string, error)
+	GetPropertyIpInterfaceContext(ctx context.Context) (string, error)
 
 	// The driver handling the device. Non-UTF-8 sequences are backslash escaped. Use g_strcompress() to revert.
 	GetPropertyDriver() (string, error)
+	GetPropertyDriverContext(ctx context.Context) (string, error)
 
 	// The version of the driver handling the device. Non-UTF-8 sequences are backslash escaped. Use g_strcompress() to revert.
 	GetPropertyDriverVersion() (string, error)
+	GetPropertyDriverVersionContext(ctx context.Context) (string, error)
 
 	// The firmware version for the device. Non-UTF-8 sequences are backslash escaped. Use g_strcompress() to revert.
 	GetPropertyFirmwareVersion() (string, error)
+	GetPropertyFirmwareVersionContext(ctx context.Context) (string, error)
 
 	// The current state of the device.
 	GetPropertyState() (NmDeviceState, error)
+	GetPropertyStateContext(ctx context.Context) (NmDeviceState, error)
+
+	// The current state of the device and the reason for that state.
+	GetPropertyStateReason() (NmDeviceState, NmDeviceStateReason, error)
+	GetPropertyStateReasonContext(ctx context.Context) (NmDeviceState, NmDeviceStateReason, error)
 
 	// Object path of an ActiveConnection object that "owns" this device during activation. The ActiveConnection object tracks the life-cycle of a connection to a specific network and implements the org.freedesktop.NetworkManager.Connection.Active D-Bus interface.
 	GetPropertyActiveConnection() (ActiveConnection, error)
+	GetPropertyActiveConnectionContext(ctx context.Context) (ActiveConnection, error)
 
 	// Object path of the Ip4Config object describing the configuration of the device. Only valid when the device is in the NM_DEVICE_STATE_ACTIVATED state.
 	GetPropertyIP4Config() (IP4Config, error)
+	GetPropertyIP4ConfigContext(ctx context.Context) (IP4Config, error)
 
 	// Object path of the Dhcp4Config object describing the DHCP options returned by the DHCP server. Only valid when the device is in the NM_DEVICE_STATE_ACTIVATED state.
 	GetPropertyDHCP4Config() (DHCP4Config, error)
+	GetPropertyDHCP4ConfigContext(ctx context.Context) (DHCP4Config, error)
 
 	// Object path of the Ip6Config object describing the configuration of the device. Only valid when the device is in the NM_DEVICE_STATE_ACTIVATED state.
 	GetPropertyIP6Config() (IP6Config, error)
+	GetPropertyIP6ConfigContext(ctx context.Context) (IP6Config, error)
 
 	// Object path of the Dhcp6Config object describing the DHCP options returned by the DHCP server. Only valid when the device is in the NM_DEVICE_STATE_ACTIVATED state.
 	GetPropertyDHCP6Config() (DHCP6Config, error)
+	GetPropertyDHCP6ConfigContext(ctx context.Context) (DHCP6Config, error)
 
 	// Whether or not this device is managed by NetworkManager. Setting this property has a similar effect to configuring the device as unmanaged via the keyfile.unmanaged-devices setting in NetworkManager.conf. Changes to this value are not persistent and lost after NetworkManager restart.
 	GetPropertyManaged() (bool, error)
+	GetPropertyManagedContext(ctx context.Context) (bool, error)
 
 	// If TRUE, indicates the device is allowed to autoconnect. If FALSE, manual intervention is required before the device will automatically connect to a known network, such as activating a connection using the device, or setting this property to TRUE. This property cannot be set to TRUE for default-unmanaged devices, since they never autoconnect.
 	GetPropertyAutoConnect() (bool, error)
+	GetPropertyAutoConnectContext(ctx context.Context) (bool, error)
 
 	// If TRUE, indicates the device is likely missing firmware necessary for its operation.
 	GetPropertyFirmwareMissing() (bool, error)
+	GetPropertyFirmwareMissingContext(ctx context.Context) (bool, error)
 
 	// If TRUE, indicates the NetworkManager plugin for the device is likely missing or misconfigured.
 	GetPropertyNmPluginMissing() (bool, error)
+	GetPropertyNmPluginMissingContext(ctx context.Context) (bool, error)
 
 	// The general type of the network device; ie Ethernet, Wi-Fi, etc.
 	GetPropertyDeviceType() (NmDeviceType, error)
+	GetPropertyDeviceTypeContext(ctx context.Context) (NmDeviceType, error)
 
 	// An array of object paths of every configured connection that is currently 'available' through this device.
 	GetPropertyAvailableConnections() ([]Connection, error)
+	GetPropertyAvailableConnectionsContext(ctx context.Context) ([]Connection, error)
 
 	// If non-empty, an (opaque) indicator of the physical network port associated with the device. This can be used to recognize when two seemingly-separate hardware devices are actually just different virtual interfaces to the same physical port.
 	GetPropertyPhysicalPortId() (string, error)
+	GetPropertyPhysicalPortIdContext(ctx context.Context) (string, error)
 
 	// The device MTU (maximum transmission unit).
 	GetPropertyMtu() (uint32, error)
+	GetPropertyMtuContext(ctx context.Context) (uint32, error)
 
 	// True if the device exists, or False for placeholder devices that do not yet exist but could be automatically created by NetworkManager if one of their AvailableConnections was activated.
 	GetPropertyReal() (bool, error)
+	GetPropertyRealContext(ctx context.Context) (bool, error)
+
+	// Read all the properties of the DeviceInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*DeviceProperties, error)
+	GetPropertiesContext(ctx context.Context) (*DeviceProperties, error)
+
+	// WithoutCache returns a copy of the device whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled. The copy has the type of the device, e.g. it can still be asserted to a DeviceWireless.
+	WithoutCache() Device
 
 	MarshalJSON() ([]byte, error)
 	// Get map of device properties
@@ -153,129 +295,312 @@ func NewDevice(objectPath dbus.ObjectPath) (Device, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (Device, error) {
+	var d device
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type device struct {
 	dbusBase
 }
 
+func (d *device) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *device) GetPath() dbus.ObjectPath {
 	return d.obj.Path()
 }
 
+func (d *device) Reapply(connection ConnectionSettings, versionId uint64, flags uint32) error {
+	return d.ReapplyContext(context.Background(), connection, versionId, flags)
+}
+
+func (d *device) ReapplyContext(ctx context.Context, connection ConnectionSettings, versionId uint64, flags uint32) error {
+	if connection == nil {
+		connection = ConnectionSettings{}
+	}
+	return d.call(ctx, DeviceReapply, connection, versionId, flags)
+}
+
+func (d *device) GetAppliedConnection(flags uint32) (ConnectionSettings, uint64, error) {
+	return d.GetAppliedConnectionContext(context.Background(), flags)
+}
+
+func (d *device) GetAppliedConnectionContext(ctx context.Context, flags uint32) (ConnectionSettings, uint64, error) {
+	var settings map[string]map[string]dbus.Variant
+	var versionId uint64
+	err := d.callWithReturn2(ctx, &settings, &versionId, DeviceGetAppliedConnection, flags)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return settingsFromVariants(settings), versionId, nil
+}
+
+func (d *device) ReapplyIPSettings(settings ConnectionSettings) (bool, error) {
+	return d.ReapplyIPSettingsContext(context.Background(), settings)
+}
+
+func (d *device) ReapplyIPSettingsContext(ctx context.Context, settings ConnectionSettings) (bool, error) {
+	applied, versionId, err := d.GetAppliedConnectionContext(ctx, 0)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, name := range []string{SettingIP4ConfigSettingName, SettingIP6ConfigSettingName} {
+		for key, value := range settings[name] {
+			if current, ok := applied[name][key]; ok && sameSettingValue(current, value) {
+				continue
+			}
+
+			if applied[name] == nil {
+				applied[name] = make(map[string]interface{})
+			}
+			applied[name][key] = value
+			changed = true
+
+			// The applied connection also holds the deprecated keys, which
+			// would otherwise keep the previous addresses and routes.
+			switch key {
+			case "address-data", "gateway", "route-data":
+				for _, legacy := range []string{"addresses", "routes"} {
+					if _, ok := settings[name][legacy]; !ok {
+						delete(applied[name], legacy)
+					}
+				}
+			}
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, d.ReapplyContext(ctx, applied, versionId, 0)
+}
+
 func (d *device) Disconnect() error {
-	return d.call(DeviceDisconnect)
+	return d.DisconnectContext(context.Background())
+}
+
+func (d *device) DisconnectContext(ctx context.Context) error {
+	return d.call(ctx, DeviceDisconnect)
 }
 
 func (d *device) Delete() error {
-	return d.call(DeviceDelete)
+	return d.DeleteContext(context.Background())
+}
+
+func (d *device) DeleteContext(ctx context.Context) error {
+	return d.call(ctx, DeviceDelete)
 }
 
 func (d *device) GetPropertyUdi() (string, error) {
-	return d.getStringProperty(DevicePropertyUdi)
+	return d.GetPropertyUdiContext(context.Background())
+}
+
+func (d *device) GetPropertyUdiContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyUdi)
 }
 
 func (d *device) GetPropertyInterface() (string, error) {
-	return d.getStringProperty(DevicePropertyInterface)
+	return d.GetPropertyInterfaceContext(context.Background())
+}
+
+func (d *device) GetPropertyInterfaceContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyInterface)
 }
 
 func (d *device) GetPropertyIpInterface() (string, error) {
-	return d.getStringProperty(DevicePropertyIpInterface)
+	return d.GetPropertyIpInterfaceContext(context.Background())
+}
+
+func (d *device) GetPropertyIpInterfaceContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyIpInterface)
 }
 
 func (d *device) GetPropertyDriver() (string, error) {
-	return d.getStringProperty(DevicePropertyDriver)
+	return d.GetPropertyDriverContext(context.Background())
+}
+
+func (d *device) GetPropertyDriverContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyDriver)
 }
 
 func (d *device) GetPropertyDriverVersion() (string, error) {
-	return d.getStringProperty(DevicePropertyDriverVersion)
+	return d.GetPropertyDriverVersionContext(context.Background())
+}
+
+func (d *device) GetPropertyDriverVersionContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyDriverVersion)
 }
 
 func (d *device) GetPropertyFirmwareVersion() (string, error) {
-	return d.getStringProperty(DevicePropertyFirmwareVersion)
+	return d.GetPropertyFirmwareVersionContext(context.Background())
+}
+
+func (d *device) GetPropertyFirmwareVersionContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyFirmwareVersion)
 }
 
 func (d *device) GetPropertyState() (NmDeviceState, error) {
-	r, err := d.getUint32Property(DevicePropertyState)
+	return d.GetPropertyStateContext(context.Background())
+}
+
+func (d *device) GetPropertyStateContext(ctx context.Context) (NmDeviceState, error) {
+	r, err := d.getUint32Property(ctx, DevicePropertyState)
 	if err != nil {
 		return NmDeviceStateFailed, err
 	}
 	return NmDeviceState(r), nil
 }
 
+func (d *device) GetPropertyStateReason() (NmDeviceState, NmDeviceStateReason, error) {
+	return d.GetPropertyStateReasonContext(context.Background())
+}
+
+func (d *device) GetPropertyStateReasonContext(ctx context.Context) (NmDeviceState, NmDeviceStateReason, error) {
+	value, err := d.getProperty(ctx, DevicePropertyStateReason)
+	if err != nil {
+		return NmDeviceStateUnknown, NmDeviceStateReasonNone, err
+	}
+
+	fields, ok := value.([]interface{})
+	if !ok || len(fields) != 2 {
+		return NmDeviceStateUnknown, NmDeviceStateReasonNone, makeErrVariantType(DevicePropertyStateReason)
+	}
+	state, ok1 := fields[0].(uint32)
+	reason, ok2 := fields[1].(uint32)
+	if !ok1 || !ok2 {
+		return NmDeviceStateUnknown, NmDeviceStateReasonNone, makeErrVariantType(DevicePropertyStateReason)
+	}
+
+	return NmDeviceState(state), NmDeviceStateReason(reason), nil
+}
+
 func (d *device) GetPropertyActiveConnection() (ActiveConnection, error) {
-	path, err := d.getObjectProperty(DevicePropertyActiveConnection)
+	return d.GetPropertyActiveConnectionContext(context.Background())
+}
+
+func (d *device) GetPropertyActiveConnectionContext(ctx context.Context) (ActiveConnection, error) {
+	path, err := d.getObjectProperty(ctx, DevicePropertyActiveConnection)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewActiveConnection(path)
+	return NewActiveConnectionWithConn(d.conn, path)
 }
 
 func (d *device) GetPropertyIP4Config() (IP4Config, error) {
-	path, err := d.getObjectProperty(DevicePropertyIp4Config)
+	return d.GetPropertyIP4ConfigContext(context.Background())
+}
+
+func (d *device) GetPropertyIP4ConfigContext(ctx context.Context) (IP4Config, error) {
+	path, err := d.getObjectProperty(ctx, DevicePropertyIp4Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewIP4Config(path)
+	return NewIP4ConfigWithConn(d.conn, path)
 }
 
 func (d *device) GetPropertyDHCP4Config() (DHCP4Config, error) {
-	path, err := d.getObjectProperty(DevicePropertyDhcp4Config)
+	return d.GetPropertyDHCP4ConfigContext(context.Background())
+}
+
+func (d *device) GetPropertyDHCP4ConfigContext(ctx context.Context) (DHCP4Config, error) {
+	path, err := d.getObjectProperty(ctx, DevicePropertyDhcp4Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewDHCP4Config(path)
+	return NewDHCP4ConfigWithConn(d.conn, path)
 }
 
 func (d *device) GetPropertyIP6Config() (IP6Config, error) {
-	path, err := d.getObjectProperty(DevicePropertyIp6Config)
+	return d.GetPropertyIP6ConfigContext(context.Background())
+}
+
+func (d *device) GetPropertyIP6ConfigContext(ctx context.Context) (IP6Config, error) {
+	path, err := d.getObjectProperty(ctx, DevicePropertyIp6Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewIP6Config(path)
+	return NewIP6ConfigWithConn(d.conn, path)
 }
 
 func (d *device) GetPropertyDHCP6Config() (DHCP6Config, error) {
-	path, err := d.getObjectProperty(DevicePropertyDhcp6Config)
+	return d.GetPropertyDHCP6ConfigContext(context.Background())
+}
+
+func (d *device) GetPropertyDHCP6ConfigContext(ctx context.Context) (DHCP6Config, error) {
+	path, err := d.getObjectProperty(ctx, DevicePropertyDhcp6Config)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewDHCP6Config(path)
+	return NewDHCP6ConfigWithConn(d.conn, path)
 }
 
 func (d *device) GetPropertyManaged() (bool, error) {
-	return d.getBoolProperty(DevicePropertyManaged)
+	return d.GetPropertyManagedContext(context.Background())
+}
+
+func (d *device) GetPropertyManagedContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DevicePropertyManaged)
 }
 
 func (d *device) GetPropertyAutoConnect() (bool, error) {
-	return d.getBoolProperty(DevicePropertyAutoconnect)
+	return d.GetPropertyAutoConnectContext(context.Background())
+}
+
+func (d *device) GetPropertyAutoConnectContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DevicePropertyAutoconnect)
 }
 
 func (d *device) GetPropertyFirmwareMissing() (bool, error) {
-	return d.getBoolProperty(DevicePropertyFirmwareMissing)
+	return d.GetPropertyFirmwareMissingContext(context.Background())
+}
+
+func (d *device) GetPropertyFirmwareMissingContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DevicePropertyFirmwareMissing)
 }
 
 func (d *device) GetPropertyNmPluginMissing() (bool, error) {
-	return d.getBoolProperty(DevicePropertyNmPluginMissing)
+	return d.GetPropertyNmPluginMissingContext(context.Background())
+}
+
+func (d *device) GetPropertyNmPluginMissingContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DevicePropertyNmPluginMissing)
 }
 
 func (d *device) GetPropertyDeviceType() (NmDeviceType, error) {
-	v, err := d.getUint32Property(DevicePropertyDeviceType)
+	return d.GetPropertyDeviceTypeContext(context.Background())
+}
+
+func (d *device) GetPropertyDeviceTypeContext(ctx context.Context) (NmDeviceType, error) {
+	v, err := d.getUint32Property(ctx, DevicePropertyDeviceType)
 	return NmDeviceType(v), err
 }
 
 func (d *device) GetPropertyAvailableConnections() ([]Connection, error) {
-	connPaths, err := d.getSliceObjectProperty(DevicePropertyAvailableConnections)
+	return d.GetPropertyAvailableConnectionsContext(context.Background())
+}
+
+func (d *device) GetPropertyAvailableConnectionsContext(ctx context.Context) ([]Connection, error) {
+	connPaths, err := d.getSliceObjectProperty(ctx, DevicePropertyAvailableConnections)
 	if err != nil {
 		return nil, err
 	}
 
 	conns := make([]Connection, len(connPaths))
 	for i, path := range connPaths {
-		conns[i], err = NewConnection(path)
+		conns[i], err = NewConnectionWithConn(d.conn, path)
 		if err != nil {
 			return conns, err
 		}
@@ -285,54 +610,71 @@ func (d *device) GetPropertyAvailableConnections() ([]Connection, error) {
 }
 
 func (d *device) GetPropertyPhysicalPortId() (string, error) {
-	return d.getStringProperty(DevicePropertyPhysicalPortId)
+	return d.GetPropertyPhysicalPortIdContext(context.Background())
+}
+
+func (d *device) GetPropertyPhysicalPortIdContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DevicePropertyPhysicalPortId)
 }
 
 func (d *device) GetPropertyMtu() (uint32, error) {
-	return d.getUint32Property(DevicePropertyMtu)
+	return d.GetPropertyMtuContext(context.Background())
+}
+
+func (d *device) GetPropertyMtuContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DevicePropertyMtu)
 }
 
 func (d *device) GetPropertyReal() (bool, error) {
-	return d.getBoolProperty(DevicePropertyReal)
+	return d.GetPropertyRealContext(context.Background())
 }
 
-func (d *device) marshalMap() (map[string]interface{}, error) {
-	Interface, err := d.GetPropertyInterface()
-	if err != nil {
-		return nil, err
-	}
-	IpInterface, err := d.GetPropertyIpInterface()
-	if err != nil {
-		return nil, err
-	}
-	State, err := d.GetPropertyState()
+func (d *device) GetPropertyRealContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DevicePropertyReal)
+}
+
+func (d *device) GetProperties() (*DeviceProperties, error) {
+	return d.GetPropertiesContext(context.Background())
+}
+
+func (d *device) GetPropertiesContext(ctx context.Context) (*DeviceProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceInterface)
 	if err != nil {
 		return nil, err
 	}
-	IP4Config, err := d.GetPropertyIP4Config()
+
+	var p DeviceProperties
+	return &p, decodeProperties(DeviceInterface, props, &p)
+}
+
+func (d *device) marshalMap() (map[string]interface{}, error) {
+	p, err := d.GetProperties()
 	if err != nil {
 		return nil, err
 	}
-	DHCP4Config, err := d.GetPropertyDHCP4Config()
-	if err != nil {
-		return nil, err
+
+	var IP4Config IP4Config
+	if p.Ip4Config != "/" {
+		IP4Config, _ = NewIP4ConfigWithConn(d.conn, p.Ip4Config)
 	}
-	DeviceType, err := d.GetPropertyDeviceType()
-	if err != nil {
-		return nil, err
+
+	var DHCP4Config DHCP4Config
+	if p.Dhcp4Config != "/" {
+		DHCP4Config, _ = NewDHCP4ConfigWithConn(d.conn, p.Dhcp4Config)
 	}
-	AvailableConnections, err := d.GetPropertyAvailableConnections()
-	if err != nil {
-		return nil, err
+
+	AvailableConnections := make([]Connection, len(p.AvailableConnections))
+	for i, path := range p.AvailableConnections {
+		AvailableConnections[i], _ = NewConnectionWithConn(d.conn, path)
 	}
 
 	return map[string]interface{}{
-		"Interface":            Interface,
-		"IP interface":         IpInterface,
-		"State":                State.String(),
+		"Interface":            p.Interface,
+		"IP interface":         p.IpInterface,
+		"State":                p.State.String(),
 		"IP4Config":            IP4Config,
 		"DHCP4Config":          DHCP4Config,
-		"DeviceType":           DeviceType.String(),
+		"DeviceType":           p.DeviceType.String(),
 		"AvailableConnections": AvailableConnections,
 	}, nil
 }
@@ -345,7 +687,6 @@ func (d *device) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
-
-func (d *device) GetPropertyMAP() (map[string]interface{}, error){
+func (d *device) GetPropertyMAP() (map[string]interface{}, error) {
 	return d.marshalMap()
 }
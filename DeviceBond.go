@@ -0,0 +1,129 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceBondInterface = DeviceInterface + ".Bond"
+
+	/* Properties */
+	DeviceBondPropertyHwAddress = DeviceBondInterface + ".HwAddress" // readable   s
+	DeviceBondPropertyCarrier   = DeviceBondInterface + ".Carrier"   // readable   b
+	DeviceBondPropertySlaves    = DeviceBondInterface + ".Slaves"    // readable   ao
+)
+
+// DeviceBondProperties holds the properties of the DeviceBondInterface of a
+// device, read at once by DeviceBond.GetBondProperties.
+type DeviceBondProperties struct {
+	HwAddress string
+	Carrier   bool
+	Slaves    []dbus.ObjectPath
+}
+
+type DeviceBond interface {
+	Device
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Indicates whether the physical carrier is found (e.g. whether a cable is plugged in or not).
+	GetPropertyCarrier() (bool, error)
+	GetPropertyCarrierContext(ctx context.Context) (bool, error)
+
+	// Array of object paths representing devices which are currently enslaved to this device.
+	GetPropertySlaves() ([]Device, error)
+	GetPropertySlavesContext(ctx context.Context) ([]Device, error)
+
+	// Read all the properties of the DeviceBondInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetBondProperties() (*DeviceBondProperties, error)
+	GetBondPropertiesContext(ctx context.Context) (*DeviceBondProperties, error)
+}
+
+func NewDeviceBond(objectPath dbus.ObjectPath) (DeviceBond, error) {
+	var d deviceBond
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceBondWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceBond, error) {
+	var d deviceBond
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceBond struct {
+	device
+}
+
+func (d *deviceBond) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceBond) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceBond) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceBondPropertyHwAddress)
+}
+
+func (d *deviceBond) GetPropertyCarrier() (bool, error) {
+	return d.GetPropertyCarrierContext(context.Background())
+}
+
+func (d *deviceBond) GetPropertyCarrierContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceBondPropertyCarrier)
+}
+
+func (d *deviceBond) GetPropertySlaves() ([]Device, error) {
+	return d.GetPropertySlavesContext(context.Background())
+}
+
+func (d *deviceBond) GetPropertySlavesContext(ctx context.Context) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(ctx, DeviceBondPropertySlaves)
+	if err != nil {
+		return nil, err
+	}
+	return devicesWithConn(ctx, d.conn, paths)
+}
+
+func (d *deviceBond) GetBondProperties() (*DeviceBondProperties, error) {
+	return d.GetBondPropertiesContext(context.Background())
+}
+
+func (d *deviceBond) GetBondPropertiesContext(ctx context.Context) (*DeviceBondProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceBondInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceBondProperties
+	return &p, decodeProperties(DeviceBondInterface, props, &p)
+}
+
+func (d *deviceBond) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetBondProperties()
+	if err != nil {
+		p = &DeviceBondProperties{}
+	}
+
+	Slaves := make([]Device, len(p.Slaves))
+	for i, path := range p.Slaves {
+		Slaves[i], _ = NewDeviceWithConn(d.conn, path)
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["Carrier"] = p.Carrier
+	m["Slaves"] = Slaves
+	return json.Marshal(m)
+}
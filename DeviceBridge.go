@@ -0,0 +1,129 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceBridgeInterface = DeviceInterface + ".Bridge"
+
+	/* Properties */
+	DeviceBridgePropertyHwAddress = DeviceBridgeInterface + ".HwAddress" // readable   s
+	DeviceBridgePropertyCarrier   = DeviceBridgeInterface + ".Carrier"   // readable   b
+	DeviceBridgePropertySlaves    = DeviceBridgeInterface + ".Slaves"    // readable   ao
+)
+
+// DeviceBridgeProperties holds the properties of the DeviceBridgeInterface of a
+// device, read at once by DeviceBridge.GetBridgeProperties.
+type DeviceBridgeProperties struct {
+	HwAddress string
+	Carrier   bool
+	Slaves    []dbus.ObjectPath
+}
+
+type DeviceBridge interface {
+	Device
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Indicates whether the physical carrier is found (e.g. whether a cable is plugged in or not).
+	GetPropertyCarrier() (bool, error)
+	GetPropertyCarrierContext(ctx context.Context) (bool, error)
+
+	// Array of object paths representing devices which are currently enslaved to this device.
+	GetPropertySlaves() ([]Device, error)
+	GetPropertySlavesContext(ctx context.Context) ([]Device, error)
+
+	// Read all the properties of the DeviceBridgeInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetBridgeProperties() (*DeviceBridgeProperties, error)
+	GetBridgePropertiesContext(ctx context.Context) (*DeviceBridgeProperties, error)
+}
+
+func NewDeviceBridge(objectPath dbus.ObjectPath) (DeviceBridge, error) {
+	var d deviceBridge
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceBridgeWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceBridge, error) {
+	var d deviceBridge
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceBridge struct {
+	device
+}
+
+func (d *deviceBridge) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceBridge) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceBridge) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceBridgePropertyHwAddress)
+}
+
+func (d *deviceBridge) GetPropertyCarrier() (bool, error) {
+	return d.GetPropertyCarrierContext(context.Background())
+}
+
+func (d *deviceBridge) GetPropertyCarrierContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceBridgePropertyCarrier)
+}
+
+func (d *deviceBridge) GetPropertySlaves() ([]Device, error) {
+	return d.GetPropertySlavesContext(context.Background())
+}
+
+func (d *deviceBridge) GetPropertySlavesContext(ctx context.Context) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(ctx, DeviceBridgePropertySlaves)
+	if err != nil {
+		return nil, err
+	}
+	return devicesWithConn(ctx, d.conn, paths)
+}
+
+func (d *deviceBridge) GetBridgeProperties() (*DeviceBridgeProperties, error) {
+	return d.GetBridgePropertiesContext(context.Background())
+}
+
+func (d *deviceBridge) GetBridgePropertiesContext(ctx context.Context) (*DeviceBridgeProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceBridgeInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceBridgeProperties
+	return &p, decodeProperties(DeviceBridgeInterface, props, &p)
+}
+
+func (d *deviceBridge) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetBridgeProperties()
+	if err != nil {
+		p = &DeviceBridgeProperties{}
+	}
+
+	Slaves := make([]Device, len(p.Slaves))
+	for i, path := range p.Slaves {
+		Slaves[i], _ = NewDeviceWithConn(d.conn, path)
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["Carrier"] = p.Carrier
+	m["Slaves"] = Slaves
+	return json.Marshal(m)
+}
@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -13,11 +14,22 @@ const (
 	DeviceDummyPropertyHwAddress = DeviceDummyInterface + ".HwAddress" // readable   s
 )
 
+// DeviceDummyProperties holds the properties of the DeviceDummyInterface of a
+// device, read at once by DeviceDummy.GetDummyProperties.
+type DeviceDummyProperties struct {
+	HwAddress string
+}
+
 type DeviceDummy interface {
 	Device
 
 	// Hardware address of the device.
 	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the DeviceDummyInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetDummyProperties() (*DeviceDummyProperties, error)
+	GetDummyPropertiesContext(ctx context.Context) (*DeviceDummyProperties, error)
 }
 
 func NewDeviceDummy(objectPath dbus.ObjectPath) (DeviceDummy, error) {
@@ -25,12 +37,41 @@ func NewDeviceDummy(objectPath dbus.ObjectPath) (DeviceDummy, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceDummyWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceDummy, error) {
+	var d deviceDummy
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceDummy struct {
 	device
 }
 
+func (d *deviceDummy) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceDummy) GetPropertyHwAddress() (string, error) {
-	return d.getStringProperty(DeviceDummyPropertyHwAddress)
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceDummy) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceDummyPropertyHwAddress)
+}
+
+func (d *deviceDummy) GetDummyProperties() (*DeviceDummyProperties, error) {
+	return d.GetDummyPropertiesContext(context.Background())
+}
+
+func (d *deviceDummy) GetDummyPropertiesContext(ctx context.Context) (*DeviceDummyProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceDummyInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceDummyProperties
+	return &p, decodeProperties(DeviceDummyInterface, props, &p)
 }
 
 func (d *deviceDummy) MarshalJSON() ([]byte, error) {
@@ -39,6 +80,11 @@ func (d *deviceDummy) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	m["HwAddress"], _ = d.GetPropertyHwAddress()
+	p, err := d.GetDummyProperties()
+	if err != nil {
+		p = &DeviceDummyProperties{}
+	}
+
+	m["HwAddress"] = p.HwAddress
 	return json.Marshal(m)
 }
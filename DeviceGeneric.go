@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -14,14 +15,27 @@ const (
 	DeviceGenericPropertyTypeDescription = DeviceGenericInterface + ".TypeDescription" // readable   s
 )
 
+// DeviceGenericProperties holds the properties of the DeviceGenericInterface of a
+// device, read at once by DeviceGeneric.GetGenericProperties.
+type DeviceGenericProperties struct {
+	HwAddress       string
+	TypeDescription string
+}
+
 type DeviceGeneric interface {
 	Device
 
 	// Active hardware address of the device.
 	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
 
 	// A (non-localized) description of the interface type, if known.
 	GetPropertyTypeDescription() (string, error)
+	GetPropertyTypeDescriptionContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the DeviceGenericInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetGenericProperties() (*DeviceGenericProperties, error)
+	GetGenericPropertiesContext(ctx context.Context) (*DeviceGenericProperties, error)
 }
 
 func NewDeviceGeneric(objectPath dbus.ObjectPath) (DeviceGeneric, error) {
@@ -29,16 +43,49 @@ func NewDeviceGeneric(objectPath dbus.ObjectPath) (DeviceGeneric, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceGenericWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceGeneric, error) {
+	var d deviceGeneric
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceGeneric struct {
 	device
 }
 
+func (d *deviceGeneric) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceGeneric) GetPropertyHwAddress() (string, error) {
-	return d.getStringProperty(DeviceGenericPropertyHwAddress)
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceGeneric) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceGenericPropertyHwAddress)
 }
 
 func (d *deviceGeneric) GetPropertyTypeDescription() (string, error) {
-	return d.getStringProperty(DeviceGenericPropertyTypeDescription)
+	return d.GetPropertyTypeDescriptionContext(context.Background())
+}
+
+func (d *deviceGeneric) GetPropertyTypeDescriptionContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceGenericPropertyTypeDescription)
+}
+
+func (d *deviceGeneric) GetGenericProperties() (*DeviceGenericProperties, error) {
+	return d.GetGenericPropertiesContext(context.Background())
+}
+
+func (d *deviceGeneric) GetGenericPropertiesContext(ctx context.Context) (*DeviceGenericProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceGenericInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceGenericProperties
+	return &p, decodeProperties(DeviceGenericInterface, props, &p)
 }
 
 func (d *deviceGeneric) MarshalJSON() ([]byte, error) {
@@ -47,7 +94,12 @@ func (d *deviceGeneric) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	m["HwAddress"], _ = d.GetPropertyHwAddress()
-	m["TypeDescription"], _ = d.GetPropertyTypeDescription()
+	p, err := d.GetGenericProperties()
+	if err != nil {
+		p = &DeviceGenericProperties{}
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["TypeDescription"] = p.TypeDescription
 	return json.Marshal(m)
 }
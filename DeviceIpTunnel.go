@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -26,44 +27,78 @@ const (
 
 )
 
+// DeviceIpTunnelProperties holds the properties of the DeviceIpTunnelInterface of a
+// device, read at once by DeviceIpTunnel.GetIpTunnelProperties.
+type DeviceIpTunnelProperties struct {
+	HwAddress          string
+	Mode               uint32
+	Parent             dbus.ObjectPath
+	Local              string
+	Remote             string
+	Ttl                uint8
+	Tos                uint8
+	PathMtuDiscovery   bool
+	InputKey           string
+	OutputKey          string
+	EncapsulationLimit uint8
+	FlowLabel          uint32
+	Flags              uint32
+}
+
 type DeviceIpTunnel interface {
 	Device
 
 	// The tunneling mode
 	GetPropertyMode() (uint32, error)
+	GetPropertyModeContext(ctx context.Context) (uint32, error)
 
 	// The object path of the parent device.
 	GetPropertyParent() (Device, error)
+	GetPropertyParentContext(ctx context.Context) (Device, error)
 
 	// The local endpoint of the tunnel.
 	GetPropertyLocal() (string, error)
+	GetPropertyLocalContext(ctx context.Context) (string, error)
 
 	// The remote endpoint of the tunnel.
 	GetPropertyRemote() (string, error)
+	GetPropertyRemoteContext(ctx context.Context) (string, error)
 
 	// The TTL assigned to tunneled packets. 0 is a special value meaning that packets inherit the TTL value
 	GetPropertyTtl() (uint8, error)
+	GetPropertyTtlContext(ctx context.Context) (uint8, error)
 
 	// The type of service (IPv4) or traffic class (IPv6) assigned to tunneled packets.
 	GetPropertyTos() (uint8, error)
+	GetPropertyTosContext(ctx context.Context) (uint8, error)
 
 	// Whether path MTU discovery is enabled on this tunnel.
 	GetPropertyPathMtuDiscovery() (bool, error)
+	GetPropertyPathMtuDiscoveryContext(ctx context.Context) (bool, error)
 
 	// The key used for incoming packets.
 	GetPropertyInputKey() (string, error)
+	GetPropertyInputKeyContext(ctx context.Context) (string, error)
 
 	// The key used for outgoing packets.
 	GetPropertyOutputKey() (string, error)
+	GetPropertyOutputKeyContext(ctx context.Context) (string, error)
 
 	// How many additional levels of encapsulation are permitted to be prepended to packets. This property applies only to IPv6 tunnels.
 	GetPropertyEncapsulationLimit() (uint8, error)
+	GetPropertyEncapsulationLimitContext(ctx context.Context) (uint8, error)
 
 	// The flow label to assign to tunnel packets. This property applies only to IPv6 tunnels.
 	GetPropertyFlowLabel() (uint32, error)
+	GetPropertyFlowLabelContext(ctx context.Context) (uint32, error)
 
 	// Tunnel flags.
 	GetPropertyFlags() (uint32, error)
+	GetPropertyFlagsContext(ctx context.Context) (uint32, error)
+
+	// Read all the properties of the DeviceIpTunnelInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetIpTunnelProperties() (*DeviceIpTunnelProperties, error)
+	GetIpTunnelPropertiesContext(ctx context.Context) (*DeviceIpTunnelProperties, error)
 }
 
 func NewDeviceIpTunnel(objectPath dbus.ObjectPath) (DeviceIpTunnel, error) {
@@ -71,80 +106,163 @@ func NewDeviceIpTunnel(objectPath dbus.ObjectPath) (DeviceIpTunnel, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceIpTunnelWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceIpTunnel, error) {
+	var d deviceIpTunnel
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceIpTunnel struct {
 	device
 }
 
+func (d *deviceIpTunnel) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceIpTunnel) GetPropertyMode() (uint32, error) {
-	return d.getUint32Property(DeviceIpTunnelPropertyMode)
+	return d.GetPropertyModeContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyModeContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceIpTunnelPropertyMode)
 }
 
 func (d *deviceIpTunnel) GetPropertyParent() (Device, error) {
-	path, err := d.getObjectProperty(DeviceIpTunnelPropertyParent)
+	return d.GetPropertyParentContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyParentContext(ctx context.Context) (Device, error) {
+	path, err := d.getObjectProperty(ctx, DeviceIpTunnelPropertyParent)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return DeviceFactory(path)
+	return DeviceFactoryWithConnContext(ctx, d.conn, path)
 }
 
 func (d *deviceIpTunnel) GetPropertyLocal() (string, error) {
-	return d.getStringProperty(DeviceIpTunnelPropertyLocal)
+	return d.GetPropertyLocalContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyLocalContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceIpTunnelPropertyLocal)
 }
 
 func (d *deviceIpTunnel) GetPropertyRemote() (string, error) {
-	return d.getStringProperty(DeviceIpTunnelPropertyRemote)
+	return d.GetPropertyRemoteContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyRemoteContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceIpTunnelPropertyRemote)
 }
 
 func (d *deviceIpTunnel) GetPropertyTtl() (uint8, error) {
-	return d.getUint8Property(DeviceIpTunnelPropertyTtl)
+	return d.GetPropertyTtlContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyTtlContext(ctx context.Context) (uint8, error) {
+	return d.getUint8Property(ctx, DeviceIpTunnelPropertyTtl)
 }
 
 func (d *deviceIpTunnel) GetPropertyTos() (uint8, error) {
-	return d.getUint8Property(DeviceIpTunnelPropertyTos)
+	return d.GetPropertyTosContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyTosContext(ctx context.Context) (uint8, error) {
+	return d.getUint8Property(ctx, DeviceIpTunnelPropertyTos)
 }
 
 func (d *deviceIpTunnel) GetPropertyPathMtuDiscovery() (bool, error) {
-	return d.getBoolProperty(DeviceIpTunnelPropertyPathMtuDiscovery)
+	return d.GetPropertyPathMtuDiscoveryContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyPathMtuDiscoveryContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceIpTunnelPropertyPathMtuDiscovery)
 }
 
 func (d *deviceIpTunnel) GetPropertyInputKey() (string, error) {
-	return d.getStringProperty(DeviceIpTunnelPropertyInputKey)
+	return d.GetPropertyInputKeyContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyInputKeyContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceIpTunnelPropertyInputKey)
 }
 
 func (d *deviceIpTunnel) GetPropertyOutputKey() (string, error) {
-	return d.getStringProperty(DeviceIpTunnelPropertyOutputKey)
+	return d.GetPropertyOutputKeyContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyOutputKeyContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceIpTunnelPropertyOutputKey)
 }
 
 func (d *deviceIpTunnel) GetPropertyEncapsulationLimit() (uint8, error) {
-	return d.getUint8Property(DeviceIpTunnelPropertyEncapsulationLimit)
+	return d.GetPropertyEncapsulationLimitContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyEncapsulationLimitContext(ctx context.Context) (uint8, error) {
+	return d.getUint8Property(ctx, DeviceIpTunnelPropertyEncapsulationLimit)
 }
 
 func (d *deviceIpTunnel) GetPropertyFlowLabel() (uint32, error) {
-	return d.getUint32Property(DeviceIpTunnelPropertyFlowLabel)
+	return d.GetPropertyFlowLabelContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyFlowLabelContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceIpTunnelPropertyFlowLabel)
 }
 
 func (d *deviceIpTunnel) GetPropertyFlags() (uint32, error) {
-	return d.getUint32Property(DeviceIpTunnelPropertyFlags)
+	return d.GetPropertyFlagsContext(context.Background())
+}
+
+func (d *deviceIpTunnel) GetPropertyFlagsContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceIpTunnelPropertyFlags)
+}
+
+func (d *deviceIpTunnel) GetIpTunnelProperties() (*DeviceIpTunnelProperties, error) {
+	return d.GetIpTunnelPropertiesContext(context.Background())
 }
 
-func (d *deviceIpTunnel) MarshalJSON() ([]uint8, error) {
+func (d *deviceIpTunnel) GetIpTunnelPropertiesContext(ctx context.Context) (*DeviceIpTunnelProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceIpTunnelInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceIpTunnelProperties
+	return &p, decodeProperties(DeviceIpTunnelInterface, props, &p)
+}
+
+func (d *deviceIpTunnel) MarshalJSON() ([]byte, error) {
 	m, err := d.device.marshalMap()
 	if err != nil {
 		return nil, err
 	}
 
-	m["Mode"], _ = d.GetPropertyMode()
-	m["Parent"], _ = d.GetPropertyParent()
-	m["Local"], _ = d.GetPropertyLocal()
-	m["Remote"], _ = d.GetPropertyRemote()
-	m["Ttl"], _ = d.GetPropertyTtl()
-	m["Tos"], _ = d.GetPropertyTos()
-	m["PathMtuDiscovery"], _ = d.GetPropertyPathMtuDiscovery()
-	m["InputKey"], _ = d.GetPropertyInputKey()
-	m["OutputKey"], _ = d.GetPropertyOutputKey()
-	m["EncapsulationLimit"], _ = d.GetPropertyEncapsulationLimit()
-	m["FlowLabel"], _ = d.GetPropertyFlowLabel()
-	m["Flags"], _ = d.GetPropertyFlags()
+	p, err := d.GetIpTunnelProperties()
+	if err != nil {
+		p = &DeviceIpTunnelProperties{}
+	}
+
+	var Parent Device
+	if p.Parent != "" && p.Parent != "/" {
+		Parent, _ = DeviceFactoryWithConn(d.conn, p.Parent)
+	}
+
+	m["Mode"] = p.Mode
+	m["Parent"] = Parent
+	m["Local"] = p.Local
+	m["Remote"] = p.Remote
+	m["Ttl"] = p.Ttl
+	m["Tos"] = p.Tos
+	m["PathMtuDiscovery"] = p.PathMtuDiscovery
+	m["InputKey"] = p.InputKey
+	m["OutputKey"] = p.OutputKey
+	m["EncapsulationLimit"] = p.EncapsulationLimit
+	m["FlowLabel"] = p.FlowLabel
+	m["Flags"] = p.Flags
 	return json.Marshal(m)
 }
@@ -0,0 +1,160 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceMacvlanInterface = DeviceInterface + ".Macvlan"
+
+	/* Properties */
+	DeviceMacvlanPropertyParent    = DeviceMacvlanInterface + ".Parent"    // readable   o
+	DeviceMacvlanPropertyMode      = DeviceMacvlanInterface + ".Mode"      // readable   s
+	DeviceMacvlanPropertyNoPromisc = DeviceMacvlanInterface + ".NoPromisc" // readable   b
+	DeviceMacvlanPropertyTap       = DeviceMacvlanInterface + ".Tap"       // readable   b
+	DeviceMacvlanPropertyHwAddress = DeviceMacvlanInterface + ".HwAddress" // readable   s
+)
+
+// DeviceMacvlanProperties holds the properties of the DeviceMacvlanInterface of a
+// device, read at once by DeviceMacvlan.GetMacvlanProperties.
+type DeviceMacvlanProperties struct {
+	Parent    dbus.ObjectPath
+	Mode      string
+	NoPromisc bool
+	Tap       bool
+	HwAddress string
+}
+
+type DeviceMacvlan interface {
+	Device
+
+	// The object path of the parent device.
+	GetPropertyParent() (Device, error)
+	GetPropertyParentContext(ctx context.Context) (Device, error)
+
+	// The macvlan mode, one of "private", "vepa", "bridge" or "passthru".
+	GetPropertyMode() (string, error)
+	GetPropertyModeContext(ctx context.Context) (string, error)
+
+	// Whether the device is blocked from going into promiscuous mode.
+	GetPropertyNoPromisc() (bool, error)
+	GetPropertyNoPromiscContext(ctx context.Context) (bool, error)
+
+	// Whether the device is a macvtap.
+	GetPropertyTap() (bool, error)
+	GetPropertyTapContext(ctx context.Context) (bool, error)
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the DeviceMacvlanInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetMacvlanProperties() (*DeviceMacvlanProperties, error)
+	GetMacvlanPropertiesContext(ctx context.Context) (*DeviceMacvlanProperties, error)
+}
+
+func NewDeviceMacvlan(objectPath dbus.ObjectPath) (DeviceMacvlan, error) {
+	var d deviceMacvlan
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceMacvlanWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceMacvlan, error) {
+	var d deviceMacvlan
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceMacvlan struct {
+	device
+}
+
+func (d *deviceMacvlan) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceMacvlan) GetPropertyParent() (Device, error) {
+	return d.GetPropertyParentContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetPropertyParentContext(ctx context.Context) (Device, error) {
+	path, err := d.getObjectProperty(ctx, DeviceMacvlanPropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+
+	return DeviceFactoryWithConnContext(ctx, d.conn, path)
+}
+
+func (d *deviceMacvlan) GetPropertyMode() (string, error) {
+	return d.GetPropertyModeContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetPropertyModeContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceMacvlanPropertyMode)
+}
+
+func (d *deviceMacvlan) GetPropertyNoPromisc() (bool, error) {
+	return d.GetPropertyNoPromiscContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetPropertyNoPromiscContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceMacvlanPropertyNoPromisc)
+}
+
+func (d *deviceMacvlan) GetPropertyTap() (bool, error) {
+	return d.GetPropertyTapContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetPropertyTapContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceMacvlanPropertyTap)
+}
+
+func (d *deviceMacvlan) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceMacvlanPropertyHwAddress)
+}
+
+func (d *deviceMacvlan) GetMacvlanProperties() (*DeviceMacvlanProperties, error) {
+	return d.GetMacvlanPropertiesContext(context.Background())
+}
+
+func (d *deviceMacvlan) GetMacvlanPropertiesContext(ctx context.Context) (*DeviceMacvlanProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceMacvlanInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceMacvlanProperties
+	return &p, decodeProperties(DeviceMacvlanInterface, props, &p)
+}
+
+func (d *deviceMacvlan) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetMacvlanProperties()
+	if err != nil {
+		p = &DeviceMacvlanProperties{}
+	}
+
+	var Parent Device
+	if p.Parent != "" && p.Parent != "/" {
+		Parent, _ = DeviceFactoryWithConn(d.conn, p.Parent)
+	}
+
+	m["Parent"] = Parent
+	m["Mode"] = p.Mode
+	m["NoPromisc"] = p.NoPromisc
+	m["Tap"] = p.Tap
+	m["HwAddress"] = p.HwAddress
+	return json.Marshal(m)
+}
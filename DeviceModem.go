@@ -0,0 +1,180 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceModemInterface = DeviceInterface + ".Modem"
+
+	/* Properties */
+	DeviceModemPropertyModemCapabilities   = DeviceModemInterface + ".ModemCapabilities"   // readable   u
+	DeviceModemPropertyCurrentCapabilities = DeviceModemInterface + ".CurrentCapabilities" // readable   u
+	DeviceModemPropertyDeviceId            = DeviceModemInterface + ".DeviceId"            // readable   s
+	DeviceModemPropertyOperatorCode        = DeviceModemInterface + ".OperatorCode"        // readable   s
+	DeviceModemPropertyApn                 = DeviceModemInterface + ".Apn"                 // readable   s
+)
+
+// ErrNotModemManagerModem is returned by DeviceModem.GetModemManagerModem
+// when the modem is not handled by ModemManager, e.g. with oFono.
+var ErrNotModemManagerModem = errors.New("the device is not a ModemManager modem")
+
+// DeviceModemProperties holds the properties of the DeviceModemInterface of a
+// device, read at once by DeviceModem.GetModemProperties.
+type DeviceModemProperties struct {
+	ModemCapabilities   NmDeviceModemCapabilities
+	CurrentCapabilities NmDeviceModemCapabilities
+	DeviceId            string
+	OperatorCode        string
+	Apn                 string
+}
+
+type DeviceModem interface {
+	Device
+
+	// The generic family of access technologies the modem supports. Not all capabilities are available at the same time however; some modems require a firmware reload or other reinitialization to switch between eg CDMA/EVDO and GSM/UMTS.
+	GetPropertyModemCapabilities() (NmDeviceModemCapabilities, error)
+	GetPropertyModemCapabilitiesContext(ctx context.Context) (NmDeviceModemCapabilities, error)
+
+	// The generic family of access technologies the modem currently supports without a firmware reload or reinitialization.
+	GetPropertyCurrentCapabilities() (NmDeviceModemCapabilities, error)
+	GetPropertyCurrentCapabilitiesContext(ctx context.Context) (NmDeviceModemCapabilities, error)
+
+	// An identifier used by the modem backend (ModemManager) that aims to uniquely identify a device. Can be used to match a connection to a particular device.
+	GetPropertyDeviceId() (string, error)
+	GetPropertyDeviceIdContext(ctx context.Context) (string, error)
+
+	// The MCC and MNC (concatenated) of the network the modem is connected to. Blank if disconnected or not a 3GPP modem.
+	GetPropertyOperatorCode() (string, error)
+	GetPropertyOperatorCodeContext(ctx context.Context) (string, error)
+
+	// The access point name the modem is connected to. Blank if disconnected.
+	GetPropertyApn() (string, error)
+	GetPropertyApnContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the DeviceModemInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetModemProperties() (*DeviceModemProperties, error)
+	GetModemPropertiesContext(ctx context.Context) (*DeviceModemProperties, error)
+
+	// Get the ModemManager modem of the device, e.g. for its signal quality or operator name, which NetworkManager does not provide. The device Udi is the object path of the modem in ModemManager; ErrNotModemManagerModem is returned when it is not. The modem is reached with the D-Bus connection of the device.
+	GetModemManagerModem() (ModemManagerModem, error)
+	GetModemManagerModemContext(ctx context.Context) (ModemManagerModem, error)
+}
+
+func NewDeviceModem(objectPath dbus.ObjectPath) (DeviceModem, error) {
+	var d deviceModem
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceModemWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceModem, error) {
+	var d deviceModem
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceModem struct {
+	device
+}
+
+func (d *deviceModem) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceModem) GetPropertyModemCapabilities() (NmDeviceModemCapabilities, error) {
+	return d.GetPropertyModemCapabilitiesContext(context.Background())
+}
+
+func (d *deviceModem) GetPropertyModemCapabilitiesContext(ctx context.Context) (NmDeviceModemCapabilities, error) {
+	v, err := d.getUint32Property(ctx, DeviceModemPropertyModemCapabilities)
+	return NmDeviceModemCapabilities(v), err
+}
+
+func (d *deviceModem) GetPropertyCurrentCapabilities() (NmDeviceModemCapabilities, error) {
+	return d.GetPropertyCurrentCapabilitiesContext(context.Background())
+}
+
+func (d *deviceModem) GetPropertyCurrentCapabilitiesContext(ctx context.Context) (NmDeviceModemCapabilities, error) {
+	v, err := d.getUint32Property(ctx, DeviceModemPropertyCurrentCapabilities)
+	return NmDeviceModemCapabilities(v), err
+}
+
+func (d *deviceModem) GetPropertyDeviceId() (string, error) {
+	return d.GetPropertyDeviceIdContext(context.Background())
+}
+
+func (d *deviceModem) GetPropertyDeviceIdContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceModemPropertyDeviceId)
+}
+
+func (d *deviceModem) GetPropertyOperatorCode() (string, error) {
+	return d.GetPropertyOperatorCodeContext(context.Background())
+}
+
+func (d *deviceModem) GetPropertyOperatorCodeContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceModemPropertyOperatorCode)
+}
+
+func (d *deviceModem) GetPropertyApn() (string, error) {
+	return d.GetPropertyApnContext(context.Background())
+}
+
+func (d *deviceModem) GetPropertyApnContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceModemPropertyApn)
+}
+
+func (d *deviceModem) GetModemProperties() (*DeviceModemProperties, error) {
+	return d.GetModemPropertiesContext(context.Background())
+}
+
+func (d *deviceModem) GetModemPropertiesContext(ctx context.Context) (*DeviceModemProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceModemInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceModemProperties
+	return &p, decodeProperties(DeviceModemInterface, props, &p)
+}
+
+func (d *deviceModem) GetModemManagerModem() (ModemManagerModem, error) {
+	return d.GetModemManagerModemContext(context.Background())
+}
+
+func (d *deviceModem) GetModemManagerModemContext(ctx context.Context) (ModemManagerModem, error) {
+	udi, err := d.GetPropertyUdiContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := dbus.ObjectPath(udi)
+	if !path.IsValid() || !strings.HasPrefix(udi, ModemManagerObjectPath+"/Modem/") {
+		return nil, ErrNotModemManagerModem
+	}
+
+	return NewModemManagerModemWithConn(d.conn, path)
+}
+
+func (d *deviceModem) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetModemProperties()
+	if err != nil {
+		p = &DeviceModemProperties{}
+	}
+
+	m["ModemCapabilities"] = p.ModemCapabilities
+	m["CurrentCapabilities"] = p.CurrentCapabilities
+	m["DeviceId"] = p.DeviceId
+	m["OperatorCode"] = p.OperatorCode
+	m["Apn"] = p.Apn
+	return json.Marshal(m)
+}
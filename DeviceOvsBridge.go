@@ -0,0 +1,99 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceOvsBridgeInterface = DeviceInterface + ".OvsBridge"
+
+	/* Properties */
+	DeviceOvsBridgePropertySlaves = DeviceOvsBridgeInterface + ".Slaves" // readable   ao
+)
+
+// DeviceOvsBridgeProperties holds the properties of the DeviceOvsBridgeInterface of a
+// device, read at once by DeviceOvsBridge.GetOvsBridgeProperties.
+type DeviceOvsBridgeProperties struct {
+	Slaves []dbus.ObjectPath
+}
+
+type DeviceOvsBridge interface {
+	Device
+
+	// Array of object paths representing devices which are currently enslaved to this device.
+	GetPropertySlaves() ([]Device, error)
+	GetPropertySlavesContext(ctx context.Context) ([]Device, error)
+
+	// Read all the properties of the DeviceOvsBridgeInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetOvsBridgeProperties() (*DeviceOvsBridgeProperties, error)
+	GetOvsBridgePropertiesContext(ctx context.Context) (*DeviceOvsBridgeProperties, error)
+}
+
+func NewDeviceOvsBridge(objectPath dbus.ObjectPath) (DeviceOvsBridge, error) {
+	var d deviceOvsBridge
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceOvsBridgeWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceOvsBridge, error) {
+	var d deviceOvsBridge
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceOvsBridge struct {
+	device
+}
+
+func (d *deviceOvsBridge) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceOvsBridge) GetPropertySlaves() ([]Device, error) {
+	return d.GetPropertySlavesContext(context.Background())
+}
+
+func (d *deviceOvsBridge) GetPropertySlavesContext(ctx context.Context) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(ctx, DeviceOvsBridgePropertySlaves)
+	if err != nil {
+		return nil, err
+	}
+	return devicesWithConn(ctx, d.conn, paths)
+}
+
+func (d *deviceOvsBridge) GetOvsBridgeProperties() (*DeviceOvsBridgeProperties, error) {
+	return d.GetOvsBridgePropertiesContext(context.Background())
+}
+
+func (d *deviceOvsBridge) GetOvsBridgePropertiesContext(ctx context.Context) (*DeviceOvsBridgeProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceOvsBridgeInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceOvsBridgeProperties
+	return &p, decodeProperties(DeviceOvsBridgeInterface, props, &p)
+}
+
+func (d *deviceOvsBridge) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetOvsBridgeProperties()
+	if err != nil {
+		p = &DeviceOvsBridgeProperties{}
+	}
+
+	Slaves := make([]Device, len(p.Slaves))
+	for i, path := range p.Slaves {
+		Slaves[i], _ = NewDeviceWithConn(d.conn, path)
+	}
+
+	m["Slaves"] = Slaves
+	return json.Marshal(m)
+}
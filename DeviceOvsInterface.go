@@ -0,0 +1,35 @@
+package gonetworkmanager
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceOvsInterfaceInterface = DeviceInterface + ".OvsInterface"
+)
+
+// DeviceOvsInterface is an Open vSwitch interface, enslaved to a
+// DeviceOvsPort. Its interface has no properties.
+type DeviceOvsInterface interface {
+	Device
+}
+
+func NewDeviceOvsInterface(objectPath dbus.ObjectPath) (DeviceOvsInterface, error) {
+	var d deviceOvsInterface
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceOvsInterfaceWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceOvsInterface, error) {
+	var d deviceOvsInterface
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceOvsInterface struct {
+	device
+}
+
+func (d *deviceOvsInterface) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
@@ -0,0 +1,99 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceOvsPortInterface = DeviceInterface + ".OvsPort"
+
+	/* Properties */
+	DeviceOvsPortPropertySlaves = DeviceOvsPortInterface + ".Slaves" // readable   ao
+)
+
+// DeviceOvsPortProperties holds the properties of the DeviceOvsPortInterface of a
+// device, read at once by DeviceOvsPort.GetOvsPortProperties.
+type DeviceOvsPortProperties struct {
+	Slaves []dbus.ObjectPath
+}
+
+type DeviceOvsPort interface {
+	Device
+
+	// Array of object paths representing devices which are currently enslaved to this device.
+	GetPropertySlaves() ([]Device, error)
+	GetPropertySlavesContext(ctx context.Context) ([]Device, error)
+
+	// Read all the properties of the DeviceOvsPortInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetOvsPortProperties() (*DeviceOvsPortProperties, error)
+	GetOvsPortPropertiesContext(ctx context.Context) (*DeviceOvsPortProperties, error)
+}
+
+func NewDeviceOvsPort(objectPath dbus.ObjectPath) (DeviceOvsPort, error) {
+	var d deviceOvsPort
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceOvsPortWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceOvsPort, error) {
+	var d deviceOvsPort
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceOvsPort struct {
+	device
+}
+
+func (d *deviceOvsPort) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceOvsPort) GetPropertySlaves() ([]Device, error) {
+	return d.GetPropertySlavesContext(context.Background())
+}
+
+func (d *deviceOvsPort) GetPropertySlavesContext(ctx context.Context) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(ctx, DeviceOvsPortPropertySlaves)
+	if err != nil {
+		return nil, err
+	}
+	return devicesWithConn(ctx, d.conn, paths)
+}
+
+func (d *deviceOvsPort) GetOvsPortProperties() (*DeviceOvsPortProperties, error) {
+	return d.GetOvsPortPropertiesContext(context.Background())
+}
+
+func (d *deviceOvsPort) GetOvsPortPropertiesContext(ctx context.Context) (*DeviceOvsPortProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceOvsPortInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceOvsPortProperties
+	return &p, decodeProperties(DeviceOvsPortInterface, props, &p)
+}
+
+func (d *deviceOvsPort) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetOvsPortProperties()
+	if err != nil {
+		p = &DeviceOvsPortProperties{}
+	}
+
+	Slaves := make([]Device, len(p.Slaves))
+	for i, path := range p.Slaves {
+		Slaves[i], _ = NewDeviceWithConn(d.conn, path)
+	}
+
+	m["Slaves"] = Slaves
+	return json.Marshal(m)
+}
@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -15,17 +16,35 @@ const (
 	DeviceStatisticsPropertyRxBytes       = DeviceStatisticsInterface + ".RxBytes"       // readable   t
 )
 
+// DeviceStatisticsProperties holds the statistics of a device, read at once
+// by DeviceStatistics.GetProperties.
+type DeviceStatisticsProperties struct {
+	RefreshRateMs uint32
+	TxBytes       uint64
+	RxBytes       uint64
+}
+
 type DeviceStatistics interface {
 	GetPath() dbus.ObjectPath
 
 	// Refresh rate of the rest of properties of this interface. The properties are guaranteed to be refreshed each RefreshRateMs milliseconds in case the underlying counter has changed too. If zero, there is no guaranteed refresh rate of the properties.
 	GetPropertyRefreshRateMs() (uint32, error)
+	GetPropertyRefreshRateMsContext(ctx context.Context) (uint32, error)
 
 	// Number of transmitted bytes
 	GetPropertyTxBytes() (uint64, error)
+	GetPropertyTxBytesContext(ctx context.Context) (uint64, error)
 
 	// Number of received bytes
 	GetPropertyRxBytes() (uint64, error)
+	GetPropertyRxBytesContext(ctx context.Context) (uint64, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*DeviceStatisticsProperties, error)
+	GetPropertiesContext(ctx context.Context) (*DeviceStatisticsProperties, error)
+
+	// WithoutCache returns a copy of the statistics whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() DeviceStatistics
 }
 
 func NewDeviceStatistics(objectPath dbus.ObjectPath) (DeviceStatistics, error) {
@@ -33,36 +52,77 @@ func NewDeviceStatistics(objectPath dbus.ObjectPath) (DeviceStatistics, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceStatisticsWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceStatistics, error) {
+	var d deviceStatistics
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceStatistics struct {
 	dbusBase
 }
 
+func (d *deviceStatistics) WithoutCache() DeviceStatistics {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceStatistics) GetPath() dbus.ObjectPath {
 	return d.obj.Path()
 }
 
 func (d *deviceStatistics) GetPropertyRefreshRateMs() (uint32, error) {
-	return d.getUint32Property(DeviceStatisticsPropertyRefreshRateMs)
+	return d.GetPropertyRefreshRateMsContext(context.Background())
+}
+
+func (d *deviceStatistics) GetPropertyRefreshRateMsContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceStatisticsPropertyRefreshRateMs)
 }
 
 func (d *deviceStatistics) GetPropertyTxBytes() (uint64, error) {
-	return d.getUint64Property(DeviceStatisticsPropertyTxBytes)
+	return d.GetPropertyTxBytesContext(context.Background())
+}
+
+func (d *deviceStatistics) GetPropertyTxBytesContext(ctx context.Context) (uint64, error) {
+	return d.getUint64Property(ctx, DeviceStatisticsPropertyTxBytes)
 }
 
 func (d *deviceStatistics) GetPropertyRxBytes() (uint64, error) {
-	return d.getUint64Property(DeviceStatisticsPropertyRxBytes)
+	return d.GetPropertyRxBytesContext(context.Background())
+}
+
+func (d *deviceStatistics) GetPropertyRxBytesContext(ctx context.Context) (uint64, error) {
+	return d.getUint64Property(ctx, DeviceStatisticsPropertyRxBytes)
 }
 
 func (d *deviceStatistics) marshalMap() map[string]interface{} {
 	return map[string]interface{}{}
 }
 
+func (d *deviceStatistics) GetProperties() (*DeviceStatisticsProperties, error) {
+	return d.GetPropertiesContext(context.Background())
+}
+
+func (d *deviceStatistics) GetPropertiesContext(ctx context.Context) (*DeviceStatisticsProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceStatisticsInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceStatisticsProperties
+	return &p, decodeProperties(DeviceStatisticsInterface, props, &p)
+}
+
 func (d *deviceStatistics) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
 
-	m["RefreshRateMs"], _ = d.GetPropertyRefreshRateMs()
-	m["TxBytes"], _ = d.GetPropertyTxBytes()
-	m["RxBytes"], _ = d.GetPropertyRxBytes()
+	p, err := d.GetProperties()
+	if err != nil {
+		p = &DeviceStatisticsProperties{}
+	}
+	m["RefreshRateMs"] = p.RefreshRateMs
+	m["TxBytes"] = p.TxBytes
+	m["RxBytes"] = p.RxBytes
 
 	return json.Marshal(m)
 }
@@ -0,0 +1,144 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceTeamInterface = DeviceInterface + ".Team"
+
+	/* Properties */
+	DeviceTeamPropertyHwAddress = DeviceTeamInterface + ".HwAddress" // readable   s
+	DeviceTeamPropertyCarrier   = DeviceTeamInterface + ".Carrier"   // readable   b
+	DeviceTeamPropertySlaves    = DeviceTeamInterface + ".Slaves"    // readable   ao
+	DeviceTeamPropertyConfig    = DeviceTeamInterface + ".Config"    // readable   s
+)
+
+// DeviceTeamProperties holds the properties of the DeviceTeamInterface of a
+// device, read at once by DeviceTeam.GetTeamProperties.
+type DeviceTeamProperties struct {
+	HwAddress string
+	Carrier   bool
+	Slaves    []dbus.ObjectPath
+	Config    string
+}
+
+type DeviceTeam interface {
+	Device
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Indicates whether the physical carrier is found (e.g. whether a cable is plugged in or not).
+	GetPropertyCarrier() (bool, error)
+	GetPropertyCarrierContext(ctx context.Context) (bool, error)
+
+	// Array of object paths representing devices which are currently enslaved to this device.
+	GetPropertySlaves() ([]Device, error)
+	GetPropertySlavesContext(ctx context.Context) ([]Device, error)
+
+	// The JSON configuration currently applied on the device.
+	GetPropertyConfig() (string, error)
+	GetPropertyConfigContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the DeviceTeamInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetTeamProperties() (*DeviceTeamProperties, error)
+	GetTeamPropertiesContext(ctx context.Context) (*DeviceTeamProperties, error)
+}
+
+func NewDeviceTeam(objectPath dbus.ObjectPath) (DeviceTeam, error) {
+	var d deviceTeam
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceTeamWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceTeam, error) {
+	var d deviceTeam
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceTeam struct {
+	device
+}
+
+func (d *deviceTeam) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceTeam) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceTeam) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceTeamPropertyHwAddress)
+}
+
+func (d *deviceTeam) GetPropertyCarrier() (bool, error) {
+	return d.GetPropertyCarrierContext(context.Background())
+}
+
+func (d *deviceTeam) GetPropertyCarrierContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceTeamPropertyCarrier)
+}
+
+func (d *deviceTeam) GetPropertySlaves() ([]Device, error) {
+	return d.GetPropertySlavesContext(context.Background())
+}
+
+func (d *deviceTeam) GetPropertySlavesContext(ctx context.Context) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(ctx, DeviceTeamPropertySlaves)
+	if err != nil {
+		return nil, err
+	}
+	return devicesWithConn(ctx, d.conn, paths)
+}
+
+func (d *deviceTeam) GetPropertyConfig() (string, error) {
+	return d.GetPropertyConfigContext(context.Background())
+}
+
+func (d *deviceTeam) GetPropertyConfigContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceTeamPropertyConfig)
+}
+
+func (d *deviceTeam) GetTeamProperties() (*DeviceTeamProperties, error) {
+	return d.GetTeamPropertiesContext(context.Background())
+}
+
+func (d *deviceTeam) GetTeamPropertiesContext(ctx context.Context) (*DeviceTeamProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceTeamInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceTeamProperties
+	return &p, decodeProperties(DeviceTeamInterface, props, &p)
+}
+
+func (d *deviceTeam) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetTeamProperties()
+	if err != nil {
+		p = &DeviceTeamProperties{}
+	}
+
+	Slaves := make([]Device, len(p.Slaves))
+	for i, path := range p.Slaves {
+		Slaves[i], _ = NewDeviceWithConn(d.conn, path)
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["Carrier"] = p.Carrier
+	m["Slaves"] = Slaves
+	m["Config"] = p.Config
+	return json.Marshal(m)
+}
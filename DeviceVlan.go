@@ -0,0 +1,145 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceVlanInterface = DeviceInterface + ".Vlan"
+
+	/* Properties */
+	DeviceVlanPropertyHwAddress = DeviceVlanInterface + ".HwAddress" // readable   s
+	DeviceVlanPropertyCarrier   = DeviceVlanInterface + ".Carrier"   // readable   b
+	DeviceVlanPropertyParent    = DeviceVlanInterface + ".Parent"    // readable   o
+	DeviceVlanPropertyVlanId    = DeviceVlanInterface + ".VlanId"    // readable   u
+)
+
+// DeviceVlanProperties holds the properties of the DeviceVlanInterface of a
+// device, read at once by DeviceVlan.GetVlanProperties.
+type DeviceVlanProperties struct {
+	HwAddress string
+	Carrier   bool
+	Parent    dbus.ObjectPath
+	VlanId    uint32
+}
+
+type DeviceVlan interface {
+	Device
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// Indicates whether the physical carrier is found (e.g. whether a cable is plugged in or not).
+	GetPropertyCarrier() (bool, error)
+	GetPropertyCarrierContext(ctx context.Context) (bool, error)
+
+	// The object path of the parent device.
+	GetPropertyParent() (Device, error)
+	GetPropertyParentContext(ctx context.Context) (Device, error)
+
+	// The VLAN ID of this VLAN interface.
+	GetPropertyVlanId() (uint32, error)
+	GetPropertyVlanIdContext(ctx context.Context) (uint32, error)
+
+	// Read all the properties of the DeviceVlanInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetVlanProperties() (*DeviceVlanProperties, error)
+	GetVlanPropertiesContext(ctx context.Context) (*DeviceVlanProperties, error)
+}
+
+func NewDeviceVlan(objectPath dbus.ObjectPath) (DeviceVlan, error) {
+	var d deviceVlan
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceVlanWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceVlan, error) {
+	var d deviceVlan
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceVlan struct {
+	device
+}
+
+func (d *deviceVlan) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceVlan) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceVlan) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceVlanPropertyHwAddress)
+}
+
+func (d *deviceVlan) GetPropertyCarrier() (bool, error) {
+	return d.GetPropertyCarrierContext(context.Background())
+}
+
+func (d *deviceVlan) GetPropertyCarrierContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVlanPropertyCarrier)
+}
+
+func (d *deviceVlan) GetPropertyParent() (Device, error) {
+	return d.GetPropertyParentContext(context.Background())
+}
+
+func (d *deviceVlan) GetPropertyParentContext(ctx context.Context) (Device, error) {
+	path, err := d.getObjectProperty(ctx, DeviceVlanPropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+
+	return DeviceFactoryWithConnContext(ctx, d.conn, path)
+}
+
+func (d *deviceVlan) GetPropertyVlanId() (uint32, error) {
+	return d.GetPropertyVlanIdContext(context.Background())
+}
+
+func (d *deviceVlan) GetPropertyVlanIdContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceVlanPropertyVlanId)
+}
+
+func (d *deviceVlan) GetVlanProperties() (*DeviceVlanProperties, error) {
+	return d.GetVlanPropertiesContext(context.Background())
+}
+
+func (d *deviceVlan) GetVlanPropertiesContext(ctx context.Context) (*DeviceVlanProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceVlanInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceVlanProperties
+	return &p, decodeProperties(DeviceVlanInterface, props, &p)
+}
+
+func (d *deviceVlan) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetVlanProperties()
+	if err != nil {
+		p = &DeviceVlanProperties{}
+	}
+
+	var Parent Device
+	if p.Parent != "" && p.Parent != "/" {
+		Parent, _ = DeviceFactoryWithConn(d.conn, p.Parent)
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["Carrier"] = p.Carrier
+	m["Parent"] = Parent
+	m["VlanId"] = p.VlanId
+	return json.Marshal(m)
+}
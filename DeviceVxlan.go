@@ -0,0 +1,340 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceVxlanInterface = DeviceInterface + ".Vxlan"
+
+	/* Properties */
+	DeviceVxlanPropertyParent     = DeviceVxlanInterface + ".Parent"     // readable   o
+	DeviceVxlanPropertyHwAddress  = DeviceVxlanInterface + ".HwAddress"  // readable   s
+	DeviceVxlanPropertyId         = DeviceVxlanInterface + ".Id"         // readable   u
+	DeviceVxlanPropertyGroup      = DeviceVxlanInterface + ".Group"      // readable   s
+	DeviceVxlanPropertyLocal      = DeviceVxlanInterface + ".Local"      // readable   s
+	DeviceVxlanPropertyTos        = DeviceVxlanInterface + ".Tos"        // readable   y
+	DeviceVxlanPropertyTtl        = DeviceVxlanInterface + ".Ttl"        // readable   y
+	DeviceVxlanPropertyLearning   = DeviceVxlanInterface + ".Learning"   // readable   b
+	DeviceVxlanPropertyAgeing     = DeviceVxlanInterface + ".Ageing"     // readable   u
+	DeviceVxlanPropertyLimit      = DeviceVxlanInterface + ".Limit"      // readable   u
+	DeviceVxlanPropertyDstPort    = DeviceVxlanInterface + ".DstPort"    // readable   q
+	DeviceVxlanPropertySrcPortMin = DeviceVxlanInterface + ".SrcPortMin" // readable   q
+	DeviceVxlanPropertySrcPortMax = DeviceVxlanInterface + ".SrcPortMax" // readable   q
+	DeviceVxlanPropertyProxy      = DeviceVxlanInterface + ".Proxy"      // readable   b
+	DeviceVxlanPropertyRsc        = DeviceVxlanInterface + ".Rsc"        // readable   b
+	DeviceVxlanPropertyL2miss     = DeviceVxlanInterface + ".L2miss"     // readable   b
+	DeviceVxlanPropertyL3miss     = DeviceVxlanInterface + ".L3miss"     // readable   b
+)
+
+// DeviceVxlanProperties holds the properties of the DeviceVxlanInterface of a
+// device, read at once by DeviceVxlan.GetVxlanProperties.
+type DeviceVxlanProperties struct {
+	Parent     dbus.ObjectPath
+	HwAddress  string
+	Id         uint32
+	Group      string
+	Local      string
+	Tos        uint8
+	Ttl        uint8
+	Learning   bool
+	Ageing     uint32
+	Limit      uint32
+	DstPort    uint16
+	SrcPortMin uint16
+	SrcPortMax uint16
+	Proxy      bool
+	Rsc        bool
+	L2miss     bool
+	L3miss     bool
+}
+
+type DeviceVxlan interface {
+	Device
+
+	// The object path of the parent device (if the VXLAN is not an L3 tunnel).
+	GetPropertyParent() (Device, error)
+	GetPropertyParentContext(ctx context.Context) (Device, error)
+
+	// Hardware address of the device.
+	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
+
+	// The VXLAN Network Identifier (VNI).
+	GetPropertyId() (uint32, error)
+	GetPropertyIdContext(ctx context.Context) (uint32, error)
+
+	// The IP (v4 or v6) multicast group used to communicate with other physical hosts on this VXLAN.
+	GetPropertyGroup() (string, error)
+	GetPropertyGroupContext(ctx context.Context) (string, error)
+
+	// The local IPv4 or IPv6 address to use when sending VXLAN packets to other physical hosts.
+	GetPropertyLocal() (string, error)
+	GetPropertyLocalContext(ctx context.Context) (string, error)
+
+	// The value to use in the IP ToS field for VXLAN packets sent to other physical hosts.
+	GetPropertyTos() (uint8, error)
+	GetPropertyTosContext(ctx context.Context) (uint8, error)
+
+	// The value to use in the IP TTL field for VXLAN packets sent to other physical hosts.
+	GetPropertyTtl() (uint8, error)
+	GetPropertyTtlContext(ctx context.Context) (uint8, error)
+
+	// True if the VXLAN dynamically learns remote IP addresses.
+	GetPropertyLearning() (bool, error)
+	GetPropertyLearningContext(ctx context.Context) (bool, error)
+
+	// The lifetime in seconds of FDB entries learned by the kernel.
+	GetPropertyAgeing() (uint32, error)
+	GetPropertyAgeingContext(ctx context.Context) (uint32, error)
+
+	// The maximum number of entries that can be added to the forwarding table.
+	GetPropertyLimit() (uint32, error)
+	GetPropertyLimitContext(ctx context.Context) (uint32, error)
+
+	// The destination port for outgoing VXLAN packets.
+	GetPropertyDstPort() (uint16, error)
+	GetPropertyDstPortContext(ctx context.Context) (uint16, error)
+
+	// The lowest source port number to use for outgoing VXLAN packets.
+	GetPropertySrcPortMin() (uint16, error)
+	GetPropertySrcPortMinContext(ctx context.Context) (uint16, error)
+
+	// The highest source port number to use for outgoing VXLAN packets.
+	GetPropertySrcPortMax() (uint16, error)
+	GetPropertySrcPortMaxContext(ctx context.Context) (uint16, error)
+
+	// True if the VXLAN is implementing DOVE ARP proxying for remote clients.
+	GetPropertyProxy() (bool, error)
+	GetPropertyProxyContext(ctx context.Context) (bool, error)
+
+	// True if the VXLAN is implementing DOVE route short-circuiting of known remote IP addresses.
+	GetPropertyRsc() (bool, error)
+	GetPropertyRscContext(ctx context.Context) (bool, error)
+
+	// True if the VXLAN will emit netlink notifications of L2 switch misses.
+	GetPropertyL2miss() (bool, error)
+	GetPropertyL2missContext(ctx context.Context) (bool, error)
+
+	// True if the VXLAN will emit netlink notifications of L3 switch misses.
+	GetPropertyL3miss() (bool, error)
+	GetPropertyL3missContext(ctx context.Context) (bool, error)
+
+	// Read all the properties of the DeviceVxlanInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetVxlanProperties() (*DeviceVxlanProperties, error)
+	GetVxlanPropertiesContext(ctx context.Context) (*DeviceVxlanProperties, error)
+}
+
+func NewDeviceVxlan(objectPath dbus.ObjectPath) (DeviceVxlan, error) {
+	var d deviceVxlan
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceVxlanWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceVxlan, error) {
+	var d deviceVxlan
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceVxlan struct {
+	device
+}
+
+func (d *deviceVxlan) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceVxlan) GetPropertyParent() (Device, error) {
+	return d.GetPropertyParentContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyParentContext(ctx context.Context) (Device, error) {
+	path, err := d.getObjectProperty(ctx, DeviceVxlanPropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+
+	return DeviceFactoryWithConnContext(ctx, d.conn, path)
+}
+
+func (d *deviceVxlan) GetPropertyHwAddress() (string, error) {
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceVxlanPropertyHwAddress)
+}
+
+func (d *deviceVxlan) GetPropertyId() (uint32, error) {
+	return d.GetPropertyIdContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyIdContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceVxlanPropertyId)
+}
+
+func (d *deviceVxlan) GetPropertyGroup() (string, error) {
+	return d.GetPropertyGroupContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyGroupContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceVxlanPropertyGroup)
+}
+
+func (d *deviceVxlan) GetPropertyLocal() (string, error) {
+	return d.GetPropertyLocalContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyLocalContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceVxlanPropertyLocal)
+}
+
+func (d *deviceVxlan) GetPropertyTos() (uint8, error) {
+	return d.GetPropertyTosContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyTosContext(ctx context.Context) (uint8, error) {
+	return d.getUint8Property(ctx, DeviceVxlanPropertyTos)
+}
+
+func (d *deviceVxlan) GetPropertyTtl() (uint8, error) {
+	return d.GetPropertyTtlContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyTtlContext(ctx context.Context) (uint8, error) {
+	return d.getUint8Property(ctx, DeviceVxlanPropertyTtl)
+}
+
+func (d *deviceVxlan) GetPropertyLearning() (bool, error) {
+	return d.GetPropertyLearningContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyLearningContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVxlanPropertyLearning)
+}
+
+func (d *deviceVxlan) GetPropertyAgeing() (uint32, error) {
+	return d.GetPropertyAgeingContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyAgeingContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceVxlanPropertyAgeing)
+}
+
+func (d *deviceVxlan) GetPropertyLimit() (uint32, error) {
+	return d.GetPropertyLimitContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyLimitContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceVxlanPropertyLimit)
+}
+
+func (d *deviceVxlan) GetPropertyDstPort() (uint16, error) {
+	return d.GetPropertyDstPortContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyDstPortContext(ctx context.Context) (uint16, error) {
+	return d.getUint16Property(ctx, DeviceVxlanPropertyDstPort)
+}
+
+func (d *deviceVxlan) GetPropertySrcPortMin() (uint16, error) {
+	return d.GetPropertySrcPortMinContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertySrcPortMinContext(ctx context.Context) (uint16, error) {
+	return d.getUint16Property(ctx, DeviceVxlanPropertySrcPortMin)
+}
+
+func (d *deviceVxlan) GetPropertySrcPortMax() (uint16, error) {
+	return d.GetPropertySrcPortMaxContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertySrcPortMaxContext(ctx context.Context) (uint16, error) {
+	return d.getUint16Property(ctx, DeviceVxlanPropertySrcPortMax)
+}
+
+func (d *deviceVxlan) GetPropertyProxy() (bool, error) {
+	return d.GetPropertyProxyContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyProxyContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVxlanPropertyProxy)
+}
+
+func (d *deviceVxlan) GetPropertyRsc() (bool, error) {
+	return d.GetPropertyRscContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyRscContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVxlanPropertyRsc)
+}
+
+func (d *deviceVxlan) GetPropertyL2miss() (bool, error) {
+	return d.GetPropertyL2missContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyL2missContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVxlanPropertyL2miss)
+}
+
+func (d *deviceVxlan) GetPropertyL3miss() (bool, error) {
+	return d.GetPropertyL3missContext(context.Background())
+}
+
+func (d *deviceVxlan) GetPropertyL3missContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceVxlanPropertyL3miss)
+}
+
+func (d *deviceVxlan) GetVxlanProperties() (*DeviceVxlanProperties, error) {
+	return d.GetVxlanPropertiesContext(context.Background())
+}
+
+func (d *deviceVxlan) GetVxlanPropertiesContext(ctx context.Context) (*DeviceVxlanProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceVxlanInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceVxlanProperties
+	return &p, decodeProperties(DeviceVxlanInterface, props, &p)
+}
+
+func (d *deviceVxlan) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetVxlanProperties()
+	if err != nil {
+		p = &DeviceVxlanProperties{}
+	}
+
+	var Parent Device
+	if p.Parent != "" && p.Parent != "/" {
+		Parent, _ = DeviceFactoryWithConn(d.conn, p.Parent)
+	}
+
+	m["Parent"] = Parent
+	m["HwAddress"] = p.HwAddress
+	m["Id"] = p.Id
+	m["Group"] = p.Group
+	m["Local"] = p.Local
+	m["Tos"] = p.Tos
+	m["Ttl"] = p.Ttl
+	m["Learning"] = p.Learning
+	m["Ageing"] = p.Ageing
+	m["Limit"] = p.Limit
+	m["DstPort"] = p.DstPort
+	m["SrcPortMin"] = p.SrcPortMin
+	m["SrcPortMax"] = p.SrcPortMax
+	m["Proxy"] = p.Proxy
+	m["Rsc"] = p.Rsc
+	m["L2miss"] = p.L2miss
+	m["L3miss"] = p.L3miss
+	return json.Marshal(m)
+}
@@ -0,0 +1,236 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	DeviceWireGuardInterface = DeviceInterface + ".WireGuard"
+
+	/* Properties */
+	DeviceWireGuardPropertyPublicKey  = DeviceWireGuardInterface + ".PublicKey"  // readable   ay
+	DeviceWireGuardPropertyListenPort = DeviceWireGuardInterface + ".ListenPort" // readable   q
+	DeviceWireGuardPropertyFwMark     = DeviceWireGuardInterface + ".FwMark"     // readable   u
+)
+
+// ErrDeviceNotActive is returned by DeviceWireGuard.AddPeer and RemovePeer
+// when the device has no active connection.
+var ErrDeviceNotActive = errors.New("the device has no active connection")
+
+// DeviceWireGuardProperties holds the properties of the DeviceWireGuardInterface of a
+// device, read at once by DeviceWireGuard.GetWireGuardProperties.
+type DeviceWireGuardProperties struct {
+	PublicKey  []byte
+	ListenPort uint16
+	FwMark     uint32
+}
+
+type DeviceWireGuard interface {
+	Device
+
+	// The public key of the interface, base64 encoded.
+	GetPropertyPublicKey() (string, error)
+	GetPropertyPublicKeyContext(ctx context.Context) (string, error)
+
+	// Local UDP port for WireGuard traffic.
+	GetPropertyListenPort() (uint16, error)
+	GetPropertyListenPortContext(ctx context.Context) (uint16, error)
+
+	// Optional 32-bit mark for the outgoing packets, 0 when unset.
+	GetPropertyFwMark() (uint32, error)
+	GetPropertyFwMarkContext(ctx context.Context) (uint32, error)
+
+	// Read all the properties of the DeviceWireGuardInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetWireGuardProperties() (*DeviceWireGuardProperties, error)
+	GetWireGuardPropertiesContext(ctx context.Context) (*DeviceWireGuardProperties, error)
+
+	// Add a peer to the connection active on the device, replacing the peer with the same public key if any. Both the connection profile, with Update2, and the applied connection, with Reapply, are changed, so that the tunnel keeps running. Preshared keys left empty keep their current value. ErrDeviceNotActive is returned when the device has no active connection.
+	AddPeer(ctx context.Context, peer WireGuardPeer) error
+
+	// Remove the peer with this public key from the connection active on the device, like AddPeer. Removing a peer the connection does not have is not an error.
+	RemovePeer(ctx context.Context, publicKey string) error
+}
+
+func NewDeviceWireGuard(objectPath dbus.ObjectPath) (DeviceWireGuard, error) {
+	var d deviceWireGuard
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+func NewDeviceWireGuardWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceWireGuard, error) {
+	var d deviceWireGuard
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
+type deviceWireGuard struct {
+	device
+}
+
+func (d *deviceWireGuard) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
+func (d *deviceWireGuard) GetPropertyPublicKey() (string, error) {
+	return d.GetPropertyPublicKeyContext(context.Background())
+}
+
+func (d *deviceWireGuard) GetPropertyPublicKeyContext(ctx context.Context) (string, error) {
+	r, err := d.getSliceByteProperty(ctx, DeviceWireGuardPropertyPublicKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(r), nil
+}
+
+func (d *deviceWireGuard) GetPropertyListenPort() (uint16, error) {
+	return d.GetPropertyListenPortContext(context.Background())
+}
+
+func (d *deviceWireGuard) GetPropertyListenPortContext(ctx context.Context) (uint16, error) {
+	return d.getUint16Property(ctx, DeviceWireGuardPropertyListenPort)
+}
+
+func (d *deviceWireGuard) GetPropertyFwMark() (uint32, error) {
+	return d.GetPropertyFwMarkContext(context.Background())
+}
+
+func (d *deviceWireGuard) GetPropertyFwMarkContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceWireGuardPropertyFwMark)
+}
+
+func (d *deviceWireGuard) GetWireGuardProperties() (*DeviceWireGuardProperties, error) {
+	return d.GetWireGuardPropertiesContext(context.Background())
+}
+
+func (d *deviceWireGuard) GetWireGuardPropertiesContext(ctx context.Context) (*DeviceWireGuardProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceWireGuardInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceWireGuardProperties
+	return &p, decodeProperties(DeviceWireGuardInterface, props, &p)
+}
+
+func (d *deviceWireGuard) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := d.GetWireGuardProperties()
+	if err != nil {
+		p = &DeviceWireGuardProperties{}
+	}
+
+	m["PublicKey"] = base64.StdEncoding.EncodeToString(p.PublicKey)
+	m["ListenPort"] = p.ListenPort
+	m["FwMark"] = p.FwMark
+	return json.Marshal(m)
+}
+
+func (d *deviceWireGuard) AddPeer(ctx context.Context, peer WireGuardPeer) error {
+	return d.updatePeers(ctx, func(peers []WireGuardPeer) []WireGuardPeer {
+		for i := range peers {
+			if peers[i].PublicKey == peer.PublicKey {
+				peers[i] = peer
+				return peers
+			}
+		}
+		return append(peers, peer)
+	})
+}
+
+func (d *deviceWireGuard) RemovePeer(ctx context.Context, publicKey string) error {
+	return d.updatePeers(ctx, func(peers []WireGuardPeer) []WireGuardPeer {
+		rv := peers[:0]
+		for _, peer := range peers {
+			if peer.PublicKey != publicKey {
+				rv = append(rv, peer)
+			}
+		}
+		return rv
+	})
+}
+
+// updatePeers changes the peers of the profile and of the applied connection
+// of the device.
+func (d *deviceWireGuard) updatePeers(ctx context.Context, update func([]WireGuardPeer) []WireGuardPeer) error {
+	ac, err := d.WithoutCache().GetPropertyActiveConnectionContext(ctx)
+	if err != nil {
+		return err
+	}
+	if ac == nil {
+		return ErrDeviceNotActive
+	}
+	c, err := ac.GetPropertyConnectionContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Both GetSettings and GetAppliedConnection leave the secrets out, which
+	// are sent back along with the changed settings so that none is lost.
+	secrets, err := c.GetSecretsContext(ctx, SettingWireGuardSettingName)
+	if err != nil {
+		return err
+	}
+
+	settings, err := c.GetSettingsContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err = setWireGuardPeers(settings, secrets, update); err != nil {
+		return err
+	}
+	if _, err = c.Update2Context(ctx, settings, NmSettingsUpdate2FlagsNone, nil); err != nil {
+		return err
+	}
+
+	applied, versionId, err := d.GetAppliedConnectionContext(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if err = setWireGuardPeers(applied, secrets, update); err != nil {
+		return err
+	}
+	return d.ReapplyContext(ctx, applied, versionId, 0)
+}
+
+// setWireGuardPeers replaces the peers of a connection without secrets with
+// the ones returned by update, and restores the secrets.
+func setWireGuardPeers(settings ConnectionSettings, secrets ConnectionSettings, update func([]WireGuardPeer) []WireGuardPeer) error {
+	var wireGuard, wireGuardSecrets SettingWireGuard
+	if err := settings.GetSetting(&wireGuard); err != nil {
+		return err
+	}
+	if err := secrets.GetSetting(&wireGuardSecrets); err != nil && err != ErrSettingNotFound {
+		return err
+	}
+
+	presharedKeys := make(map[string]string)
+	for _, peer := range wireGuardSecrets.Peers {
+		presharedKeys[peer.PublicKey] = peer.PresharedKey
+	}
+
+	peers := update(wireGuard.Peers)
+	for i := range peers {
+		if peers[i].PresharedKey == "" {
+			peers[i].PresharedKey = presharedKeys[peers[i].PublicKey]
+		}
+	}
+
+	// Only the changed keys are set, so that the keys SettingWireGuard does
+	// not know are kept.
+	values := settings[SettingWireGuardSettingName]
+	values["peers"] = wireGuardPeersToVariants(peers)
+	if wireGuardSecrets.PrivateKey != "" {
+		values["private-key"] = wireGuardSecrets.PrivateKey
+	}
+	return nil
+}
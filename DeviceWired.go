@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -17,23 +18,42 @@ const (
 	DeviceWiredPropertyCarrier         = DeviceWiredInterface + ".Carrier"         // readable   b
 )
 
+// DeviceWiredProperties holds the properties of the DeviceWiredInterface of a
+// device, read at once by DeviceWired.GetWiredProperties.
+type DeviceWiredProperties struct {
+	HwAddress       string
+	PermHwAddress   string
+	Speed           uint32
+	S390Subchannels []string
+	Carrier         bool
+}
+
 type DeviceWired interface {
 	Device
 
 	// Active hardware address of the device.
 	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
 
 	// Permanent hardware address of the device.
 	GetPropertyPermHwAddress() (string, error)
+	GetPropertyPermHwAddressContext(ctx context.Context) (string, error)
 
 	// Design speed of the device, in megabits/second (Mb/s).
 	GetPropertySpeed() (uint32, error)
+	GetPropertySpeedContext(ctx context.Context) (uint32, error)
 
 	// Array of S/390 subchannels for S/390 or z/Architecture devices.
 	GetPropertyS390Subchannels() ([]string, error)
+	GetPropertyS390SubchannelsContext(ctx context.Context) ([]string, error)
 
 	// Indicates whether the physical carrier is found (e.g. whether a cable is plugged in or not).
 	GetPropertyCarrier() (bool, error)
+	GetPropertyCarrierContext(ctx context.Context) (bool, error)
+
+	// Read all the properties of the DeviceWiredInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetWiredProperties() (*DeviceWiredProperties, error)
+	GetWiredPropertiesContext(ctx context.Context) (*DeviceWiredProperties, error)
 }
 
 func NewDeviceWired(objectPath dbus.ObjectPath) (DeviceWired, error) {
@@ -41,28 +61,73 @@ func NewDeviceWired(objectPath dbus.ObjectPath) (DeviceWired, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceWiredWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceWired, error) {
+	var d deviceWired
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceWired struct {
 	device
 }
 
+func (d *deviceWired) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceWired) GetPropertyHwAddress() (string, error) {
-	return d.getStringProperty(DeviceWiredPropertyHwAddress)
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceWired) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceWiredPropertyHwAddress)
 }
 
 func (d *deviceWired) GetPropertyPermHwAddress() (string, error) {
-	return d.getStringProperty(DeviceWiredPropertyPermHwAddress)
+	return d.GetPropertyPermHwAddressContext(context.Background())
+}
+
+func (d *deviceWired) GetPropertyPermHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceWiredPropertyPermHwAddress)
 }
 
 func (d *deviceWired) GetPropertySpeed() (uint32, error) {
-	return d.getUint32Property(DeviceWiredPropertySpeed)
+	return d.GetPropertySpeedContext(context.Background())
+}
+
+func (d *deviceWired) GetPropertySpeedContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceWiredPropertySpeed)
 }
 
 func (d *deviceWired) GetPropertyS390Subchannels() ([]string, error) {
-	return d.getSliceStringProperty(DeviceWiredPropertyS390Subchannels)
+	return d.GetPropertyS390SubchannelsContext(context.Background())
+}
+
+func (d *deviceWired) GetPropertyS390SubchannelsContext(ctx context.Context) ([]string, error) {
+	return d.getSliceStringProperty(ctx, DeviceWiredPropertyS390Subchannels)
 }
 
 func (d *deviceWired) GetPropertyCarrier() (bool, error) {
-	return d.getBoolProperty(DeviceWiredPropertyCarrier)
+	return d.GetPropertyCarrierContext(context.Background())
+}
+
+func (d *deviceWired) GetPropertyCarrierContext(ctx context.Context) (bool, error) {
+	return d.getBoolProperty(ctx, DeviceWiredPropertyCarrier)
+}
+
+func (d *deviceWired) GetWiredProperties() (*DeviceWiredProperties, error) {
+	return d.GetWiredPropertiesContext(context.Background())
+}
+
+func (d *deviceWired) GetWiredPropertiesContext(ctx context.Context) (*DeviceWiredProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceWiredInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceWiredProperties
+	return &p, decodeProperties(DeviceWiredInterface, props, &p)
 }
 
 func (d *deviceWired) MarshalJSON() ([]byte, error) {
@@ -71,10 +136,15 @@ func (d *deviceWired) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	m["HwAddress"], _ = d.GetPropertyHwAddress()
-	m["PermHwAddress"], _ = d.GetPropertyPermHwAddress()
-	m["Speed"], _ = d.GetPropertySpeed()
-	m["S390Subchannels"], _ = d.GetPropertyS390Subchannels()
-	m["Carrier"], _ = d.GetPropertyCarrier()
+	p, err := d.GetWiredProperties()
+	if err != nil {
+		p = &DeviceWiredProperties{}
+	}
+
+	m["HwAddress"] = p.HwAddress
+	m["PermHwAddress"] = p.PermHwAddress
+	m["Speed"] = p.Speed
+	m["S390Subchannels"] = p.S390Subchannels
+	m["Carrier"] = p.Carrier
 	return json.Marshal(m)
 }
@@ -1,7 +1,9 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/godbus/dbus/v5"
 )
@@ -25,6 +27,25 @@ const (
 	DeviceWirelessPropertyLastScan             = DeviceWirelessInterface + ".LastScan"             // readable   x
 )
 
+// DeviceWirelessProperties holds the properties of the DeviceWirelessInterface of a
+// device, read at once by DeviceWireless.GetWirelessProperties.
+type DeviceWirelessProperties struct {
+	HwAddress            string
+	PermHwAddress        string
+	Mode                 Nm80211Mode
+	Bitrate              uint32
+	AccessPoints         []dbus.ObjectPath
+	ActiveAccessPoint    dbus.ObjectPath
+	WirelessCapabilities NmDeviceWifiCapabilities
+	LastScan             int64
+}
+
+// ScanOptions are the options of DeviceWireless.ScanAndWait.
+type ScanOptions struct {
+	// SSIDs to probe for, to find the access points of hidden networks.
+	Ssids []string
+}
+
 type DeviceWireless interface {
 	Device
 
@@ -33,41 +54,63 @@ type DeviceWireless interface {
 	// To retrieve a list of all access points (including hidden ones) use the
 	// GetAllAccessPoints() method.
 	GetAccessPoints() ([]AccessPoint, error)
+	GetAccessPointsContext(ctx context.Context) ([]AccessPoint, error)
 
 	// GetAllAccessPoints gets the list of all access points visible to this
 	// device, including hidden ones for which the SSID is not yet known.
 	GetAllAccessPoints() ([]AccessPoint, error)
+	GetAllAccessPointsContext(ctx context.Context) ([]AccessPoint, error)
 
 	// Request the device to scan. To know when the scan is finished, use the
 	// "PropertiesChanged" signal from "org.freedesktop.DBus.Properties" to listen
 	// to changes to the "LastScan" property.
 	RequestScan() error
+	RequestScanContext(ctx context.Context) error
+
+	// Request a scan and wait for it to finish, i.e. for LastScan to advance, then return the access points visible to the device. The SSIDs of the options are probed for, which finds the access points of hidden networks.
+	ScanAndWait(ctx context.Context, opts ScanOptions) ([]AccessPoint, error)
+
+	// Subscribe to the access points appearing and disappearing, delivered as *AccessPointAddedEvent and *AccessPointRemovedEvent, e.g. to keep a list of networks up to date while scanning.
+	SubscribeAccessPoints() (EventSubscription, error)
+	SubscribeAccessPointsContext(ctx context.Context) (EventSubscription, error)
 
 	// The active hardware address of the device.
 	GetPropertyHwAddress() (string, error)
+	GetPropertyHwAddressContext(ctx context.Context) (string, error)
 
 	// The permanent hardware address of the device.
 	GetPropertyPermHwAddress() (string, error)
+	GetPropertyPermHwAddressContext(ctx context.Context) (string, error)
 
 	// The operating mode of the wireless device.
 	GetPropertyMode() (Nm80211Mode, error)
+	GetPropertyModeContext(ctx context.Context) (Nm80211Mode, error)
 
 	// The bit rate currently used by the wireless device, in kilobits/second (Kb/s).
 	GetPropertyBitrate() (uint32, error)
+	GetPropertyBitrateContext(ctx context.Context) (uint32, error)
 
 	// List of object paths of access point visible to this wireless device.
 	GetPropertyAccessPoints() ([]AccessPoint, error)
+	GetPropertyAccessPointsContext(ctx context.Context) ([]AccessPoint, error)
 
 	// Object path of the access point currently used by the wireless device.
 	GetPropertyActiveAccessPoint() (AccessPoint, error)
+	GetPropertyActiveAccessPointContext(ctx context.Context) (AccessPoint, error)
 
 	// The capabilities of the wireless device.
-	GetPropertyWirelessCapabilities() (uint32, error)
+	GetPropertyWirelessCapabilities() (NmDeviceWifiCapabilities, error)
+	GetPropertyWirelessCapabilitiesContext(ctx context.Context) (NmDeviceWifiCapabilities, error)
 
 	// The timestamp (in CLOCK_BOOTTIME milliseconds) for the last finished
 	// network scan. A value of -1 means the device never scanned for access
 	// points.
 	GetPropertyLastScan() (int64, error)
+	GetPropertyLastScanContext(ctx context.Context) (int64, error)
+
+	// Read all the properties of the DeviceWirelessInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetWirelessProperties() (*DeviceWirelessProperties, error)
+	GetWirelessPropertiesContext(ctx context.Context) (*DeviceWirelessProperties, error)
 }
 
 func NewDeviceWireless(objectPath dbus.ObjectPath) (DeviceWireless, error) {
@@ -75,13 +118,28 @@ func NewDeviceWireless(objectPath dbus.ObjectPath) (DeviceWireless, error) {
 	return &d, d.init(NetworkManagerInterface, objectPath)
 }
 
+func NewDeviceWirelessWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (DeviceWireless, error) {
+	var d deviceWireless
+	return &d, d.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type deviceWireless struct {
 	device
 }
 
+func (d *deviceWireless) WithoutCache() Device {
+	copied := *d
+	copied.uncached = true
+	return &copied
+}
+
 func (d *deviceWireless) GetAccessPoints() ([]AccessPoint, error) {
+	return d.GetAccessPointsContext(context.Background())
+}
+
+func (d *deviceWireless) GetAccessPointsContext(ctx context.Context) ([]AccessPoint, error) {
 	var apPaths []dbus.ObjectPath
-	err := d.callWithReturn(&apPaths, DeviceWirelessGetAccessPoints)
+	err := d.callWithReturn(ctx, &apPaths, DeviceWirelessGetAccessPoints)
 
 	if err != nil {
 		return nil, err
@@ -90,7 +148,7 @@ func (d *deviceWireless) GetAccessPoints() ([]AccessPoint, error) {
 	aps := make([]AccessPoint, len(apPaths))
 
 	for i, path := range apPaths {
-		aps[i], err = NewAccessPoint(path)
+		aps[i], err = NewAccessPointWithConn(d.conn, path)
 		if err != nil {
 			return aps, err
 		}
@@ -100,8 +158,12 @@ func (d *deviceWireless) GetAccessPoints() ([]AccessPoint, error) {
 }
 
 func (d *deviceWireless) GetAllAccessPoints() ([]AccessPoint, error) {
+	return d.GetAllAccessPointsContext(context.Background())
+}
+
+func (d *deviceWireless) GetAllAccessPointsContext(ctx context.Context) ([]AccessPoint, error) {
 	var apPaths []dbus.ObjectPath
-	err := d.callWithReturn(&apPaths, DeviceWirelessGetAllAccessPoints)
+	err := d.callWithReturn(ctx, &apPaths, DeviceWirelessGetAllAccessPoints)
 
 	if err != nil {
 		return nil, err
@@ -110,7 +172,7 @@ func (d *deviceWireless) GetAllAccessPoints() ([]AccessPoint, error) {
 	aps := make([]AccessPoint, len(apPaths))
 
 	for i, path := range apPaths {
-		aps[i], err = NewAccessPoint(path)
+		aps[i], err = NewAccessPointWithConn(d.conn, path)
 		if err != nil {
 			return aps, err
 		}
@@ -120,36 +182,114 @@ func (d *deviceWireless) GetAllAccessPoints() ([]AccessPoint, error) {
 }
 
 func (d *deviceWireless) RequestScan() error {
+	return d.RequestScanContext(context.Background())
+}
+
+func (d *deviceWireless) RequestScanContext(ctx context.Context) error {
 	var options map[string]interface{}
-	return d.obj.Call(DeviceWirelessRequestScan, 0, options).Store()
+	return d.call(ctx, DeviceWirelessRequestScan, options)
+}
+
+func (d *deviceWireless) ScanAndWait(ctx context.Context, opts ScanOptions) ([]AccessPoint, error) {
+	sub, err := newEventSubscription(ctx, d.dbusBase, []dbus.ObjectPath{d.GetPath()})
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	// The cache may not have seen the previous scan yet.
+	lastScan, err := d.WithoutCache().(DeviceWireless).GetPropertyLastScanContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[string]interface{})
+	if len(opts.Ssids) > 0 {
+		ssids := make([][]byte, len(opts.Ssids))
+		for i, ssid := range opts.Ssids {
+			ssids[i] = []byte(ssid)
+		}
+		options["ssids"] = ssids
+	}
+	if err := d.call(ctx, DeviceWirelessRequestScan, options); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil, fmt.Errorf("event subscription closed while waiting for the scan of %s", d.GetPath())
+			}
+
+			e, ok := event.(*PropertiesChangedEvent)
+			if !ok || e.Interface != DeviceWirelessInterface {
+				continue
+			}
+			if v, ok := e.Changed["LastScan"].Value().(int64); ok && v > lastScan {
+				return d.GetAccessPointsContext(ctx)
+			}
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (d *deviceWireless) SubscribeAccessPoints() (EventSubscription, error) {
+	return d.SubscribeAccessPointsContext(context.Background())
+}
+
+func (d *deviceWireless) SubscribeAccessPointsContext(ctx context.Context) (EventSubscription, error) {
+	return newFilteredEventSubscription(ctx, d.dbusBase, []dbus.ObjectPath{d.GetPath()}, []string{deviceWirelessSignalAccessPointAdded, deviceWirelessSignalAccessPointRemoved})
 }
 
 func (d *deviceWireless) GetPropertyHwAddress() (string, error) {
-	return d.getStringProperty(DeviceWirelessPropertyHwAddress)
+	return d.GetPropertyHwAddressContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceWirelessPropertyHwAddress)
 }
 
 func (d *deviceWireless) GetPropertyPermHwAddress() (string, error) {
-	return d.getStringProperty(DeviceWirelessPropertyPermHwAddress)
+	return d.GetPropertyPermHwAddressContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyPermHwAddressContext(ctx context.Context) (string, error) {
+	return d.getStringProperty(ctx, DeviceWirelessPropertyPermHwAddress)
 }
 
 func (d *deviceWireless) GetPropertyMode() (Nm80211Mode, error) {
-	v, err := d.getUint32Property(DeviceWirelessPropertyMode)
+	return d.GetPropertyModeContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyModeContext(ctx context.Context) (Nm80211Mode, error) {
+	v, err := d.getUint32Property(ctx, DeviceWirelessPropertyMode)
 	return Nm80211Mode(v), err
 }
 
 func (d *deviceWireless) GetPropertyBitrate() (uint32, error) {
-	return d.getUint32Property(DeviceWirelessPropertyBitrate)
+	return d.GetPropertyBitrateContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyBitrateContext(ctx context.Context) (uint32, error) {
+	return d.getUint32Property(ctx, DeviceWirelessPropertyBitrate)
 }
 
 func (d *deviceWireless) GetPropertyAccessPoints() ([]AccessPoint, error) {
-	apPaths, err := d.getSliceObjectProperty(DeviceWirelessPropertyAccessPoints)
+	return d.GetPropertyAccessPointsContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyAccessPointsContext(ctx context.Context) ([]AccessPoint, error) {
+	apPaths, err := d.getSliceObjectProperty(ctx, DeviceWirelessPropertyAccessPoints)
 	if err != nil {
 		return nil, err
 	}
 
 	ap := make([]AccessPoint, len(apPaths))
 	for i, path := range apPaths {
-		ap[i], err = NewAccessPoint(path)
+		ap[i], err = NewAccessPointWithConn(d.conn, path)
 		if err != nil {
 			return ap, err
 		}
@@ -159,20 +299,47 @@ func (d *deviceWireless) GetPropertyAccessPoints() ([]AccessPoint, error) {
 }
 
 func (d *deviceWireless) GetPropertyActiveAccessPoint() (AccessPoint, error) {
-	path, err := d.getObjectProperty(DeviceWirelessPropertyActiveAccessPoint)
+	return d.GetPropertyActiveAccessPointContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyActiveAccessPointContext(ctx context.Context) (AccessPoint, error) {
+	path, err := d.getObjectProperty(ctx, DeviceWirelessPropertyActiveAccessPoint)
 	if err != nil || path == "/" {
 		return nil, err
 	}
 
-	return NewAccessPoint(path)
+	return NewAccessPointWithConn(d.conn, path)
+}
+
+func (d *deviceWireless) GetPropertyWirelessCapabilities() (NmDeviceWifiCapabilities, error) {
+	return d.GetPropertyWirelessCapabilitiesContext(context.Background())
 }
 
-func (d *deviceWireless) GetPropertyWirelessCapabilities() (uint32, error) {
-	return d.getUint32Property(DeviceWirelessPropertyWirelessCapabilities)
+func (d *deviceWireless) GetPropertyWirelessCapabilitiesContext(ctx context.Context) (NmDeviceWifiCapabilities, error) {
+	r, err := d.getUint32Property(ctx, DeviceWirelessPropertyWirelessCapabilities)
+	return NmDeviceWifiCapabilities(r), err
 }
 
 func (d *deviceWireless) GetPropertyLastScan() (int64, error) {
-	return d.getInt64Property(DeviceWirelessPropertyLastScan)
+	return d.GetPropertyLastScanContext(context.Background())
+}
+
+func (d *deviceWireless) GetPropertyLastScanContext(ctx context.Context) (int64, error) {
+	return d.getInt64Property(ctx, DeviceWirelessPropertyLastScan)
+}
+
+func (d *deviceWireless) GetWirelessProperties() (*DeviceWirelessProperties, error) {
+	return d.GetWirelessPropertiesContext(context.Background())
+}
+
+func (d *deviceWireless) GetWirelessPropertiesContext(ctx context.Context) (*DeviceWirelessProperties, error) {
+	props, err := d.getAllProperties(ctx, DeviceWirelessInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p DeviceWirelessProperties
+	return &p, decodeProperties(DeviceWirelessInterface, props, &p)
 }
 
 func (d *deviceWireless) MarshalJSON() ([]byte, error) {
@@ -181,14 +348,28 @@ func (d *deviceWireless) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	m["AccessPoints"], _ = d.GetPropertyAccessPoints()
-	m["HwAddress"], _ = d.GetPropertyHwAddress()
-	m["PermHwAddress"], _ = d.GetPropertyPermHwAddress()
-	m["Mode"], _ = d.GetPropertyMode()
-	m["Bitrate"], _ = d.GetPropertyBitrate()
-	m["AccessPoints"], _ = d.GetPropertyAccessPoints()
-	m["ActiveAccessPoint"], _ = d.GetPropertyActiveAccessPoint()
-	m["WirelessCapabilities"], _ = d.GetPropertyWirelessCapabilities()
-	m["LastScan"], _ = d.GetPropertyLastScan()
+	p, err := d.GetWirelessProperties()
+	if err != nil {
+		p = &DeviceWirelessProperties{}
+	}
+
+	AccessPoints := make([]AccessPoint, len(p.AccessPoints))
+	for i, path := range p.AccessPoints {
+		AccessPoints[i], _ = NewAccessPointWithConn(d.conn, path)
+	}
+
+	var ActiveAccessPoint AccessPoint
+	if p.ActiveAccessPoint != "" && p.ActiveAccessPoint != "/" {
+		ActiveAccessPoint, _ = NewAccessPointWithConn(d.conn, p.ActiveAccessPoint)
+	}
+
+	m["AccessPoints"] = AccessPoints
+	m["HwAddress"] = p.HwAddress
+	m["PermHwAddress"] = p.PermHwAddress
+	m["Mode"] = p.Mode
+	m["Bitrate"] = p.Bitrate
+	m["ActiveAccessPoint"] = ActiveAccessPoint
+	m["WirelessCapabilities"] = p.WirelessCapabilities
+	m["LastScan"] = p.LastScan
 	return json.Marshal(m)
 }
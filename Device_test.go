@@ -0,0 +1,167 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestGetPropertyStateReason(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateFailed, gnm.NmDeviceStateReasonSupplicantTimeout)
+
+	state, reason, err := d.GetPropertyStateReason()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != gnm.NmDeviceStateFailed || reason != gnm.NmDeviceStateReasonSupplicantTimeout {
+		t.Errorf("GetPropertyStateReason() = %v, %v, want %v, %v", state, reason, gnm.NmDeviceStateFailed, gnm.NmDeviceStateReasonSupplicantTimeout)
+	}
+	if s := reason.String(); s != "NmDeviceStateReasonSupplicantTimeout" {
+		t.Errorf("String() = %s, want NmDeviceStateReasonSupplicantTimeout", s)
+	}
+}
+
+func TestGetPropertyStateReasonRecent(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	for _, want := range []gnm.NmDeviceStateReason{gnm.NmDeviceStateReasonDeviceHandlerFailed, gnm.NmDeviceStateReasonUnmanagedUserExplicit, gnm.NmDeviceStateReasonNetworkingOff} {
+		f.srv.SetDeviceState(dobj, gnm.NmDeviceStateDisconnected, gnm.NmDeviceStateReasonNone)
+		f.srv.SetDeviceState(dobj, gnm.NmDeviceStateUnmanaged, want)
+
+		_, reason, err := d.GetPropertyStateReason()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reason != want {
+			t.Errorf("GetPropertyStateReason() reason = %d, want %d", reason, want)
+		}
+	}
+
+	for reason, want := range map[gnm.NmDeviceStateReason]string{
+		68: "NmDeviceStateReasonDeviceHandlerFailed",
+		73: "NmDeviceStateReasonUnmanagedSleeping",
+		78: "NmDeviceStateReasonNetworkingOff",
+	} {
+		if s := reason.String(); s != want {
+			t.Errorf("NmDeviceStateReason(%d).String() = %s, want %s", uint32(reason), s, want)
+		}
+	}
+}
+
+func TestReapplyIPSettings(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, _ := f.addConnection(t, &gnm.SettingIP4Config{
+		Method:      gnm.SettingIP4ConfigMethodManual,
+		AddressData: []gnm.IP4AddressData{{Address: "192.168.1.10", Prefix: 24}},
+		Gateway:     "192.168.1.1",
+		Dns:         []string{"192.168.1.1"},
+	})
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	if _, err := f.nm.ActivateAndWait(context.Background(), c, d); err != nil {
+		t.Fatal(err)
+	}
+
+	_, version := dobj.AppliedConnection()
+
+	// Nothing differs from the applied connection.
+	same, err := gnm.NewConnectionSettings(&gnm.SettingIP4Config{Dns: []string{"192.168.1.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reapplied, err := d.ReapplyIPSettings(same); err != nil || reapplied {
+		t.Fatalf("ReapplyIPSettings() = %v, %v, want false, nil", reapplied, err)
+	}
+	if _, v := dobj.AppliedConnection(); v != version {
+		t.Errorf("version id = %d, want %d", v, version)
+	}
+
+	changed, err := gnm.NewConnectionSettings(&gnm.SettingIP4Config{
+		AddressData: []gnm.IP4AddressData{{Address: "192.168.1.20", Prefix: 24}},
+		Dns:         []string{"1.1.1.1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Other settings are ignored.
+	changed["connection"] = map[string]interface{}{"id": "ignored"}
+
+	if reapplied, err := d.ReapplyIPSettings(changed); err != nil || !reapplied {
+		t.Fatalf("ReapplyIPSettings() = %v, %v, want true, nil", reapplied, err)
+	}
+
+	applied, v := dobj.AppliedConnection()
+	if v == version {
+		t.Error("the version id of the applied connection did not change")
+	}
+	if id := applied["connection"]["id"]; id != "test" {
+		t.Errorf("connection.id = %v, want test", id)
+	}
+
+	var ip4 gnm.SettingIP4Config
+	if err := applied.GetSetting(&ip4); err != nil {
+		t.Fatal(err)
+	}
+	if len(ip4.AddressData) != 1 || ip4.AddressData[0].Address != "192.168.1.20" {
+		t.Errorf("address-data = %v, want 192.168.1.20/24", ip4.AddressData)
+	}
+	if len(ip4.Dns) != 1 || ip4.Dns[0] != "1.1.1.1" {
+		t.Errorf("dns = %v, want 1.1.1.1", ip4.Dns)
+	}
+	if ip4.Method != gnm.SettingIP4ConfigMethodManual || ip4.Gateway != "192.168.1.1" {
+		t.Errorf("method, gateway = %s, %s, want the applied ones", ip4.Method, ip4.Gateway)
+	}
+}
+
+func TestReapplyIPSettingsLegacyKeys(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	cs := gnm.ConnectionSettings{
+		"connection": {"id": "test", "type": gnm.SettingWiredSettingName},
+		"ipv4": {
+			"method":       gnm.SettingIP4ConfigMethodManual,
+			"address-data": []map[string]interface{}{{"address": "10.0.0.1", "prefix": uint32(24)}},
+			"addresses":    [][]uint32{{0x0100000a, 24, 0}},
+			"routes":       [][]uint32{{0x0000000b, 8, 0x0100000a, 0}},
+		},
+	}
+	obj := f.srv.AddConnection(cs)
+	c, err := gnm.NewConnectionWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, dobj := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+
+	if _, err := f.nm.ActivateAndWait(context.Background(), c, d); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := gnm.NewConnectionSettings(&gnm.SettingIP4Config{
+		AddressData: []gnm.IP4AddressData{{Address: "10.0.0.2", Prefix: 24}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.ReapplyIPSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, _ := dobj.AppliedConnection()
+	for _, key := range []string{"addresses", "routes"} {
+		if value, ok := applied["ipv4"][key]; ok {
+			t.Errorf("ipv4.%s = %v, want it dropped", key, value)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusPropertiesChanged = "org.freedesktop.DBus.Properties.PropertiesChanged"
+
+	networkManagerSignalStateChanged       = NetworkManagerInterface + ".StateChanged"
+	networkManagerSignalDeviceAdded        = NetworkManagerInterface + ".DeviceAdded"
+	networkManagerSignalDeviceRemoved      = NetworkManagerInterface + ".DeviceRemoved"
+	deviceSignalStateChanged               = DeviceInterface + ".StateChanged"
+	deviceWirelessSignalAccessPointAdded   = DeviceWirelessInterface + ".AccessPointAdded"
+	deviceWirelessSignalAccessPointRemoved = DeviceWirelessInterface + ".AccessPointRemoved"
+	activeConnectionSignalStateChanged     = ActiveConnectionInterface + ".StateChanged"
+	settingsSignalNewConnection            = SettingsInterface + ".NewConnection"
+	settingsSignalConnectionRemoved        = SettingsInterface + ".ConnectionRemoved"
+	connectionSignalUpdated                = ConnectionInterface + ".Updated"
+
+	dbusErrorNameHasNoOwner     = "org.freedesktop.DBus.Error.NameHasNoOwner"
+	eventSignalRule             = "type='signal',sender='" + NetworkManagerInterface + "',path_namespace='" + NetworkManagerObjectPath + "'"
+	eventSubscriptionBufferSize = 64
+)
+
+// Event is a decoded NetworkManager signal. The concrete types are the
+// *Event structs of this file.
+type Event interface {
+	// The path of the object that emitted the signal.
+	GetPath() dbus.ObjectPath
+}
+
+// PropertiesChangedEvent is emitted when properties of an object change.
+type PropertiesChangedEvent struct {
+	Path        dbus.ObjectPath
+	Interface   string
+	Changed     map[string]dbus.Variant
+	Invalidated []string
+}
+
+func (e *PropertiesChangedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// StateChangedEvent is emitted when the overall NetworkManager state changes.
+type StateChangedEvent struct {
+	Path  dbus.ObjectPath
+	State NmState
+}
+
+func (e *StateChangedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// DeviceAddedEvent is emitted when a new device appears.
+type DeviceAddedEvent struct {
+	Path   dbus.ObjectPath
+	Device Device
+}
+
+func (e *DeviceAddedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// DeviceRemovedEvent is emitted when a device disappears. The device object
+// no longer exists on the bus.
+type DeviceRemovedEvent struct {
+	Path   dbus.ObjectPath
+	Device Device
+}
+
+func (e *DeviceRemovedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// DeviceStateChangedEvent is emitted when a device changes state.
+type DeviceStateChangedEvent struct {
+	Path   dbus.ObjectPath
+	Device Device
+	New    NmDeviceState
+	Old    NmDeviceState
+	Reason NmDeviceStateReason
+}
+
+func (e *DeviceStateChangedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// AccessPointAddedEvent is emitted when a Wi-Fi device sees a new access
+// point.
+type AccessPointAddedEvent struct {
+	Path        dbus.ObjectPath
+	Device      DeviceWireless
+	AccessPoint AccessPoint
+}
+
+func (e *AccessPointAddedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// AccessPointRemovedEvent is emitted when a Wi-Fi device loses an access
+// point. The access point object no longer exists on the bus.
+type AccessPointRemovedEvent struct {
+	Path        dbus.ObjectPath
+	Device      DeviceWireless
+	AccessPoint AccessPoint
+}
+
+func (e *AccessPointRemovedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// ActiveConnectionStateChangedEvent is emitted when an active connection
+// changes state.
+type ActiveConnectionStateChangedEvent struct {
+	Path             dbus.ObjectPath
+	ActiveConnection ActiveConnection
+	State            NmActiveConnectionState
+	Reason           NmActiveConnectionStateReason
+}
+
+func (e *ActiveConnectionStateChangedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// NewConnectionEvent is emitted by the settings object when a connection
+// profile is added.
+type NewConnectionEvent struct {
+	Path       dbus.ObjectPath
+	Connection Connection
+}
+
+func (e *NewConnectionEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// ConnectionRemovedEvent is emitted by the settings object when a connection
+// profile is deleted. The connection object no longer exists on the bus.
+type ConnectionRemovedEvent struct {
+	Path       dbus.ObjectPath
+	Connection Connection
+}
+
+func (e *ConnectionRemovedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// ConnectionUpdatedEvent is emitted when the settings of a connection
+// profile change.
+type ConnectionUpdatedEvent struct {
+	Path       dbus.ObjectPath
+	Connection Connection
+}
+
+func (e *ConnectionUpdatedEvent) GetPath() dbus.ObjectPath { return e.Path }
+
+// EventSubscription delivers the decoded signals of a NetworkManager until
+// it is closed.
+type EventSubscription interface {
+	// The channel the events are delivered on. It is closed by Close or when
+	// the D-Bus connection goes away.
+	Events() <-chan Event
+
+	// Stop the subscription and release the D-Bus match rule.
+	Close() error
+}
+
+type eventSubscription struct {
+	dbusBase
+
+	// owner is the unique bus name of NetworkManager, the only sender whose
+	// signals are delivered. It is only used by run once the subscription
+	// is started.
+	owner  string
+	paths  map[dbus.ObjectPath]bool
+	names  map[string]bool
+	signal chan *dbus.Signal
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newEventSubscription(ctx context.Context, base dbusBase, paths []dbus.ObjectPath) (*eventSubscription, error) {
+	return newFilteredEventSubscription(ctx, base, paths, nil)
+}
+
+// newFilteredEventSubscription is newEventSubscription delivering only the
+// signals with these names, e.g. deviceSignalStateChanged, or all of them
+// when names is empty.
+func newFilteredEventSubscription(ctx context.Context, base dbusBase, paths []dbus.ObjectPath, names []string) (*eventSubscription, error) {
+	s := &eventSubscription{
+		dbusBase: base,
+		signal:   make(chan *dbus.Signal, eventSubscriptionBufferSize),
+		events:   make(chan Event, eventSubscriptionBufferSize),
+		done:     make(chan struct{}),
+	}
+
+	if len(paths) > 0 {
+		s.paths = make(map[dbus.ObjectPath]bool, len(paths))
+		for _, path := range paths {
+			s.paths[path] = true
+		}
+	}
+	if len(names) > 0 {
+		s.names = make(map[string]bool, len(names))
+		for _, name := range names {
+			s.names[name] = true
+		}
+	}
+
+	// The match rules name NetworkManager as the sender, but the signals of
+	// other senders matched by other rules of the connection are delivered
+	// too, so they are also filtered by the unique name of NetworkManager.
+	// Subscribing to NameOwnerChanged first keeps it up to date even when
+	// NetworkManager restarts in between.
+	for _, rule := range []string{eventSignalRule, networkManagerOwnerRule} {
+		if err := s.conn.BusObject().CallWithContext(ctx, dbusMethodAddMatch, 0, rule).Err; err != nil {
+			s.removeMatches()
+			return nil, err
+		}
+	}
+	s.conn.Signal(s.signal)
+
+	err := s.conn.BusObject().CallWithContext(ctx, dbusMethodGetNameOwner, 0, NetworkManagerInterface).Store(&s.owner)
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) && dbusErr.Name == dbusErrorNameHasNoOwner {
+		// NetworkManager is not running; its signals are delivered once it
+		// starts.
+		err = nil
+	}
+	if err != nil {
+		s.conn.RemoveSignal(s.signal)
+		s.removeMatches()
+		return nil, err
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+func (s *eventSubscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *eventSubscription) Close() (err error) {
+	s.once.Do(func() {
+		close(s.done)
+		s.conn.RemoveSignal(s.signal)
+		err = s.removeMatches()
+	})
+	return
+}
+
+func (s *eventSubscription) removeMatches() (err error) {
+	for _, rule := range []string{eventSignalRule, networkManagerOwnerRule} {
+		if e := s.conn.BusObject().Call(dbusMethodRemoveMatch, 0, rule).Err; e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+func (s *eventSubscription) run() {
+	defer close(s.events)
+
+	for {
+		select {
+		case sig, ok := <-s.signal:
+			if !ok {
+				return
+			}
+
+			if sig.Name == dbusSignalNameOwnerChanged {
+				var name, oldOwner, newOwner string
+				if dbus.Store(sig.Body, &name, &oldOwner, &newOwner) == nil && name == NetworkManagerInterface {
+					s.owner = newOwner
+				}
+				continue
+			}
+			if sig.Sender != s.owner {
+				continue
+			}
+			if s.paths != nil && !s.paths[sig.Path] {
+				continue
+			}
+			if s.names != nil && !s.names[sig.Name] {
+				continue
+			}
+
+			event := s.decode(sig)
+			if event == nil {
+				continue
+			}
+
+			select {
+			case s.events <- event:
+			case <-s.done:
+				return
+			}
+
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *eventSubscription) decode(sig *dbus.Signal) Event {
+	if !strings.HasPrefix(string(sig.Path), NetworkManagerObjectPath) {
+		return nil
+	}
+
+	switch sig.Name {
+	case dbusPropertiesChanged:
+		var e PropertiesChangedEvent
+		if dbus.Store(sig.Body, &e.Interface, &e.Changed, &e.Invalidated) != nil {
+			return nil
+		}
+		e.Path = sig.Path
+		return &e
+
+	case networkManagerSignalStateChanged:
+		var state uint32
+		if dbus.Store(sig.Body, &state) != nil {
+			return nil
+		}
+		return &StateChangedEvent{Path: sig.Path, State: NmState(state)}
+
+	case networkManagerSignalDeviceAdded, networkManagerSignalDeviceRemoved:
+		var path dbus.ObjectPath
+		if dbus.Store(sig.Body, &path) != nil {
+			return nil
+		}
+		device, err := NewDeviceWithConn(s.conn, path)
+		if err != nil {
+			return nil
+		}
+		if sig.Name == networkManagerSignalDeviceAdded {
+			return &DeviceAddedEvent{Path: sig.Path, Device: device}
+		}
+		return &DeviceRemovedEvent{Path: sig.Path, Device: device}
+
+	case deviceSignalStateChanged:
+		var newState, oldState, reason uint32
+		if dbus.Store(sig.Body, &newState, &oldState, &reason) != nil {
+			return nil
+		}
+		device, err := NewDeviceWithConn(s.conn, sig.Path)
+		if err != nil {
+			return nil
+		}
+		return &DeviceStateChangedEvent{
+			Path:   sig.Path,
+			Device: device,
+			New:    NmDeviceState(newState),
+			Old:    NmDeviceState(oldState),
+			Reason: NmDeviceStateReason(reason),
+		}
+
+	case deviceWirelessSignalAccessPointAdded, deviceWirelessSignalAccessPointRemoved:
+		var path dbus.ObjectPath
+		if dbus.Store(sig.Body, &path) != nil {
+			return nil
+		}
+		device, err := NewDeviceWirelessWithConn(s.conn, sig.Path)
+		if err != nil {
+			return nil
+		}
+		ap, err := NewAccessPointWithConn(s.conn, path)
+		if err != nil {
+			return nil
+		}
+		if sig.Name == deviceWirelessSignalAccessPointAdded {
+			return &AccessPointAddedEvent{Path: sig.Path, Device: device, AccessPoint: ap}
+		}
+		return &AccessPointRemovedEvent{Path: sig.Path, Device: device, AccessPoint: ap}
+
+	case activeConnectionSignalStateChanged:
+		var state, reason uint32
+		if dbus.Store(sig.Body, &state, &reason) != nil {
+			return nil
+		}
+		ac, err := NewActiveConnectionWithConn(s.conn, sig.Path)
+		if err != nil {
+			return nil
+		}
+		return &ActiveConnectionStateChangedEvent{
+			Path:             sig.Path,
+			ActiveConnection: ac,
+			State:            NmActiveConnectionState(state),
+			Reason:           NmActiveConnectionStateReason(reason),
+		}
+
+	case settingsSignalNewConnection, settingsSignalConnectionRemoved:
+		var path dbus.ObjectPath
+		if dbus.Store(sig.Body, &path) != nil {
+			return nil
+		}
+		connection, err := NewConnectionWithConn(s.conn, path)
+		if err != nil {
+			return nil
+		}
+		if sig.Name == settingsSignalNewConnection {
+			return &NewConnectionEvent{Path: sig.Path, Connection: connection}
+		}
+		return &ConnectionRemovedEvent{Path: sig.Path, Connection: connection}
+
+	case connectionSignalUpdated:
+		connection, err := NewConnectionWithConn(s.conn, sig.Path)
+		if err != nil {
+			return nil
+		}
+		return &ConnectionUpdatedEvent{Path: sig.Path, Connection: connection}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// nextEvent returns the next event of sub for which match returns true,
+// skipping the others.
+func nextEvent(t *testing.T, sub gnm.EventSubscription, match func(gnm.Event) bool) gnm.Event {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				t.Fatal("the subscription was closed")
+			}
+			if match(e) {
+				return e
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for an event")
+		}
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	sub, err := f.nm.SubscribeEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	dobj := f.srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+	added := nextEvent(t, sub, func(e gnm.Event) bool {
+		_, ok := e.(*gnm.DeviceAddedEvent)
+		return ok
+	}).(*gnm.DeviceAddedEvent)
+	if added.Path != gnm.NetworkManagerObjectPath || added.Device.GetPath() != dobj.Path() {
+		t.Errorf("DeviceAddedEvent = %s %s, want %s %s", added.Path, added.Device.GetPath(), gnm.NetworkManagerObjectPath, dobj.Path())
+	}
+
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateUnavailable, gnm.NmDeviceStateReasonNowUnmanaged)
+	changed := nextEvent(t, sub, func(e gnm.Event) bool {
+		_, ok := e.(*gnm.DeviceStateChangedEvent)
+		return ok
+	}).(*gnm.DeviceStateChangedEvent)
+	if changed.Path != dobj.Path() || changed.New != gnm.NmDeviceStateUnavailable || changed.Old != gnm.NmDeviceStateDisconnected || changed.Reason != gnm.NmDeviceStateReasonNowUnmanaged {
+		t.Errorf("DeviceStateChangedEvent = %s %v %v %v, want %s %v %v %v", changed.Path, changed.New, changed.Old, changed.Reason,
+			dobj.Path(), gnm.NmDeviceStateUnavailable, gnm.NmDeviceStateDisconnected, gnm.NmDeviceStateReasonNowUnmanaged)
+	}
+
+	cobj := f.srv.AddConnection(gnm.ConnectionSettings{
+		"connection": {"id": "test", "type": "802-3-ethernet"},
+	})
+	created := nextEvent(t, sub, func(e gnm.Event) bool {
+		_, ok := e.(*gnm.NewConnectionEvent)
+		return ok
+	}).(*gnm.NewConnectionEvent)
+	if created.Path != gnm.SettingsObjectPath || created.Connection.GetPath() != cobj.Path() {
+		t.Errorf("NewConnectionEvent = %s %s, want %s %s", created.Path, created.Connection.GetPath(), gnm.SettingsObjectPath, cobj.Path())
+	}
+
+	acobj := f.srv.Activate(cobj, dobj, "/")
+	activated := nextEvent(t, sub, func(e gnm.Event) bool {
+		e2, ok := e.(*gnm.ActiveConnectionStateChangedEvent)
+		return ok && e2.State == gnm.NmActiveConnectionStateActivated
+	}).(*gnm.ActiveConnectionStateChangedEvent)
+	if activated.ActiveConnection.GetPath() != acobj.Path() {
+		t.Errorf("ActiveConnectionStateChangedEvent of %s, want %s", activated.ActiveConnection.GetPath(), acobj.Path())
+	}
+
+	f.srv.RemoveDevice(dobj)
+	nextEvent(t, sub, func(e gnm.Event) bool {
+		removed, ok := e.(*gnm.DeviceRemovedEvent)
+		return ok && removed.Device.GetPath() == dobj.Path()
+	})
+
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for range sub.Events() {
+	}
+}
+
+func TestSubscribeEventsPaths(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	wlan := f.srv.AddDevice("wlan0", gnm.NmDeviceTypeWifi)
+	eth := f.srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+
+	sub, err := f.nm.SubscribeEvents(wlan.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	// Changes of other objects are not delivered.
+	f.srv.SetDeviceState(eth, gnm.NmDeviceStateUnavailable, gnm.NmDeviceStateReasonNone)
+	f.srv.AddDevice("eth1", gnm.NmDeviceTypeEthernet)
+
+	ap := f.srv.AddAccessPoint(wlan, "home", 2412, 70)
+
+	for {
+		e := nextEvent(t, sub, func(gnm.Event) bool { return true })
+		if e.GetPath() != wlan.Path() {
+			t.Fatalf("%T of %s delivered, want only the events of %s", e, e.GetPath(), wlan.Path())
+		}
+		if added, ok := e.(*gnm.AccessPointAddedEvent); ok {
+			if added.AccessPoint.GetPath() != ap.Path() || added.Device.GetPath() != wlan.Path() {
+				t.Errorf("AccessPointAddedEvent = %s %s, want %s %s", added.Device.GetPath(), added.AccessPoint.GetPath(), wlan.Path(), ap.Path())
+			}
+			break
+		}
+	}
+
+	f.srv.RemoveAccessPoint(wlan, ap)
+	nextEvent(t, sub, func(e gnm.Event) bool {
+		removed, ok := e.(*gnm.AccessPointRemovedEvent)
+		return ok && removed.AccessPoint.GetPath() == ap.Path()
+	})
+
+	wlan.Set(gnm.DevicePropertyMtu, uint32(1400))
+	props := nextEvent(t, sub, func(e gnm.Event) bool {
+		_, ok := e.(*gnm.PropertiesChangedEvent)
+		return ok
+	}).(*gnm.PropertiesChangedEvent)
+	if props.Interface != gnm.DeviceInterface || props.Changed["Mtu"] != dbus.MakeVariant(uint32(1400)) {
+		t.Errorf("PropertiesChangedEvent = %s %v, want Mtu 1400 of %s", props.Interface, props.Changed, gnm.DeviceInterface)
+	}
+}
+
+func TestSubscribeEventsSender(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	dobj := f.srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+
+	sub, err := f.nm.SubscribeEvents(dobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	// Another peer of the bus cannot forge the signals of NetworkManager.
+	other, err := f.srv.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	err = other.Emit(dobj.Path(), gnm.DeviceInterface+".StateChanged",
+		uint32(gnm.NmDeviceStateFailed), uint32(gnm.NmDeviceStateDisconnected), uint32(gnm.NmDeviceStateReasonNone))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateUnavailable, gnm.NmDeviceStateReasonNone)
+	changed := nextEvent(t, sub, func(e gnm.Event) bool {
+		_, ok := e.(*gnm.DeviceStateChangedEvent)
+		return ok
+	}).(*gnm.DeviceStateChangedEvent)
+	if changed.New != gnm.NmDeviceStateUnavailable {
+		t.Errorf("DeviceStateChangedEvent to %v delivered, want only the one of NetworkManager to %v", changed.New, gnm.NmDeviceStateUnavailable)
+	}
+}
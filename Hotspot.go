@@ -0,0 +1,121 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"fmt"
+)
+
+// Hotspot is an access point hosted by a Wi-Fi device, returned by
+// NetworkManager.StartHotspot. Its connection profile is not saved to disk.
+type Hotspot interface {
+	// GetConnection returns the connection profile of the hotspot.
+	GetConnection() Connection
+
+	// GetActiveConnection returns the active connection of the hotspot.
+	GetActiveConnection() ActiveConnection
+
+	// Stop the hotspot by deleting its connection profile, then reactivate the connection that was active on the device when the hotspot was started, if any, and wait for its activation to complete.
+	Stop() error
+	StopContext(ctx context.Context) error
+}
+
+func (nm *networkManager) StartHotspot(ctx context.Context, d DeviceWireless, ssid string, passphrase string, band string, channel uint32) (Hotspot, error) {
+	var previous Connection
+	ac, err := d.WithoutCache().GetPropertyActiveConnectionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ac != nil {
+		if previous, err = ac.GetPropertyConnectionContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	iface, err := d.GetPropertyInterfaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := hotspotSettings(iface, ssid, passphrase, band, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewSettingsWithConn(nm.conn)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := s.AddConnectionUnsavedContext(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &hotspot{nm: nm, device: d, connection: connection, previous: previous}
+	if h.activeConnection, err = nm.ActivateAndWait(ctx, connection, d); err != nil {
+		// Put the device back as it was, even when ctx is done.
+		cleanupCtx, cancel := cleanupContext()
+		defer cancel()
+		if stopErr := h.StopContext(cleanupCtx); stopErr != nil {
+			return nil, fmt.Errorf("%w (cleanup failed: %v)", err, stopErr)
+		}
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// hotspotSettings builds the connection profile of a hotspot, sharing the
+// connectivity of the host over IPv4. The network is open when the
+// passphrase is empty, and WPA2-Personal otherwise.
+func hotspotSettings(iface, ssid, passphrase, band string, channel uint32) (ConnectionSettings, error) {
+	autoconnect := false
+	settings := []Setting{
+		&SettingConnection{Id: "Hotspot " + ssid, Type: SettingWirelessSettingName, InterfaceName: iface, Autoconnect: &autoconnect},
+		&SettingWireless{Ssid: []byte(ssid), Mode: SettingWirelessModeAp, Band: band, Channel: channel},
+		&SettingIP4Config{Method: SettingIP4ConfigMethodShared},
+		&SettingIP6Config{Method: SettingIP6ConfigMethodIgnore},
+	}
+	if passphrase != "" {
+		settings = append(settings, &SettingWirelessSecurity{
+			KeyMgmt:  SettingWirelessSecurityKeyMgmtWpaPsk,
+			Proto:    []string{"rsn"},
+			Pairwise: []string{"ccmp"},
+			Group:    []string{"ccmp"},
+			Psk:      passphrase,
+		})
+	}
+
+	return NewConnectionSettings(settings...)
+}
+
+type hotspot struct {
+	nm               *networkManager
+	device           DeviceWireless
+	connection       Connection
+	activeConnection ActiveConnection
+	previous         Connection
+}
+
+func (h *hotspot) GetConnection() Connection {
+	return h.connection
+}
+
+func (h *hotspot) GetActiveConnection() ActiveConnection {
+	return h.activeConnection
+}
+
+func (h *hotspot) Stop() error {
+	return h.StopContext(context.Background())
+}
+
+func (h *hotspot) StopContext(ctx context.Context) error {
+	// Deleting the profile also deactivates it.
+	if err := h.connection.DeleteContext(ctx); err != nil {
+		return err
+	}
+
+	if h.previous == nil {
+		return nil
+	}
+	_, err := h.nm.ActivateAndWait(ctx, h.previous, h.device)
+	return err
+}
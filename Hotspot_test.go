@@ -0,0 +1,112 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+func TestStartHotspot(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+
+	// The device is connected to a network before hosting the hotspot.
+	f.srv.AddAccessPoint(dobj, "home", 2412, 70)
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "home", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	previous := f.profiles(t)[0]
+
+	h, err := f.nm.StartHotspot(context.Background(), d, "hotspot", "passphrase", gnm.SettingWirelessBandBg, 6)
+	if err != nil {
+		t.Fatalf("StartHotspot() = %v", err)
+	}
+	if state, err := h.GetActiveConnection().GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("GetPropertyState() = %v, %v, want activated", state, err)
+	}
+
+	if unsaved, err := h.GetConnection().GetPropertyUnsaved(); err != nil || !unsaved {
+		t.Errorf("GetPropertyUnsaved() = %v, %v, want true", unsaved, err)
+	}
+	settings := f.srv.Object(h.GetConnection().GetPath()).ConnectionSettings()
+	var wireless gnm.SettingWireless
+	if err := settings.GetSetting(&wireless); err != nil {
+		t.Fatal(err)
+	}
+	if string(wireless.Ssid) != "hotspot" || wireless.Mode != gnm.SettingWirelessModeAp || wireless.Channel != 6 {
+		t.Errorf("ssid, mode, channel = %s, %s, %d, want hotspot, %s, 6", wireless.Ssid, wireless.Mode, wireless.Channel, gnm.SettingWirelessModeAp)
+	}
+	if method := settings["ipv4"]["method"]; method != gnm.SettingIP4ConfigMethodShared {
+		t.Errorf("ipv4.method = %v, want %s", method, gnm.SettingIP4ConfigMethodShared)
+	}
+	if psk := settings[gnm.SettingWirelessSecuritySettingName]["psk"]; psk != "passphrase" {
+		t.Errorf("psk = %v, want passphrase", psk)
+	}
+
+	if err := h.Stop(); err != nil {
+		t.Fatalf("Stop() = %v", err)
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 1 || profiles[0]["connection"]["uuid"] != previous["connection"]["uuid"] {
+		t.Fatalf("profiles after Stop() = %v, want only the previous one", profiles)
+	}
+	ac, err := d.GetPropertyActiveConnection()
+	if err != nil || ac == nil {
+		t.Fatalf("GetPropertyActiveConnection() = %v, %v, want the previous connection", ac, err)
+	}
+	c, err := ac.GetPropertyConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uuid := f.srv.Object(c.GetPath()).ConnectionSettings()["connection"]["uuid"]; uuid != previous["connection"]["uuid"] {
+		t.Errorf("active connection %v after Stop(), want the previous one", uuid)
+	}
+}
+
+func TestStartHotspotFailure(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+
+	f.srv.AddAccessPoint(dobj, "home", 2412, 70)
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "home", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	previous := f.profiles(t)[0]
+
+	// The hotspot never activates, unlike the previous connection.
+	f.srv.SetActivationFunc(func(s *nmfake.Server, ac *nmfake.Object) {
+		c := s.Object(ac.Get(gnm.ActiveConnectionPropertyConnection).Value().(dbus.ObjectPath))
+		if c.ConnectionSettings()["connection"]["uuid"] == previous["connection"]["uuid"] {
+			nmfake.DefaultActivation(s, ac)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := f.nm.StartHotspot(ctx, d, "hotspot", "", "", 0); err != context.DeadlineExceeded {
+		t.Fatalf("StartHotspot() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The hotspot was stopped although ctx had expired.
+	profiles := f.profiles(t)
+	if len(profiles) != 1 || profiles[0]["connection"]["uuid"] != previous["connection"]["uuid"] {
+		t.Fatalf("profiles after a failed StartHotspot() = %v, want only the previous one", profiles)
+	}
+	ac, err := d.GetPropertyActiveConnection()
+	if err != nil || ac == nil {
+		t.Fatalf("GetPropertyActiveConnection() = %v, %v, want the previous connection", ac, err)
+	}
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("state of the previous connection = %v, %v, want activated", state, err)
+	}
+}
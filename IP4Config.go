@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -46,11 +47,14 @@ type IP4Route struct {
 	Metric  uint8
 }
 
+// IP4RouteData is a route of the RouteData property and of the route-data
+// key of SettingIP4Config. Metric is nil for a route without a metric of its
+// own, which then gets the route-metric of the connection.
 type IP4RouteData struct {
 	Destination          string
 	Prefix               uint8
 	NextHop              string
-	Metric               uint8
+	Metric               *uint32
 	AdditionalAttributes map[string]string
 }
 
@@ -58,45 +62,78 @@ type IP4NameserverData struct {
 	Address string
 }
 
+// IP4ConfigProperties holds the properties of an IP4Config, read at once by
+// IP4Config.GetProperties. The deprecated properties are left out.
+type IP4ConfigProperties struct {
+	AddressData    []IP4AddressData `dbus:"-"`
+	Gateway        string
+	RouteData      []IP4RouteData      `dbus:"-"`
+	NameserverData []IP4NameserverData `dbus:"-"`
+	Domains        []string
+	Searches       []string
+	DnsOptions     []string
+	DnsPriority    int32
+	WinsServerData []string
+}
+
 type IP4Config interface {
 	// Array of arrays of IPv4 address/prefix/gateway. All 3 elements of each array are in network byte order. Essentially: [(addr, prefix, gateway), (addr, prefix, gateway), ...]
 	// Deprecated: use AddressData and Gateway
 	GetPropertyAddresses() ([]IP4Address, error)
+	GetPropertyAddressesContext(ctx context.Context) ([]IP4Address, error)
 
 	// Array of IP address data objects. All addresses will include "address" (an IP address string), and "prefix" (a uint). Some addresses may include additional attributes.
 	GetPropertyAddressData() ([]IP4AddressData, error)
+	GetPropertyAddressDataContext(ctx context.Context) ([]IP4AddressData, error)
 
 	// The gateway in use.
 	GetPropertyGateway() (string, error)
+	GetPropertyGatewayContext(ctx context.Context) (string, error)
 
 	// Arrays of IPv4 route/prefix/next-hop/metric. All 4 elements of each tuple are in network byte order. 'route' and 'next hop' are IPv4 addresses, while prefix and metric are simple unsigned integers. Essentially: [(route, prefix, next-hop, metric), (route, prefix, next-hop, metric), ...]
 	// Deprecated: use RouteData
 	GetPropertyRoutes() ([]IP4Route, error)
+	GetPropertyRoutesContext(ctx context.Context) ([]IP4Route, error)
 
 	// Array of IP route data objects. All routes will include "dest" (an IP address string) and "prefix" (a uint). Some routes may include "next-hop" (an IP address string), "metric" (a uint), and additional attributes.
 	GetPropertyRouteData() ([]IP4RouteData, error)
+	GetPropertyRouteDataContext(ctx context.Context) ([]IP4RouteData, error)
 
 	// The nameservers in use.
 	// Deprecated: use NameserverData
 	GetPropertyNameservers() ([]string, error)
+	GetPropertyNameserversContext(ctx context.Context) ([]string, error)
 
 	// The nameservers in use. Currently only the value "address" is recognized (with an IP address string).
 	GetPropertyNameserverData() ([]IP4NameserverData, error)
+	GetPropertyNameserverDataContext(ctx context.Context) ([]IP4NameserverData, error)
 
 	// A list of domains this address belongs to.
 	GetPropertyDomains() ([]string, error)
+	GetPropertyDomainsContext(ctx context.Context) ([]string, error)
 
 	// A list of dns searches.
 	GetPropertySearches() ([]string, error)
+	GetPropertySearchesContext(ctx context.Context) ([]string, error)
 
 	// A list of DNS options that modify the behavior of the DNS resolver. See resolv.conf(5) manual page for the list of supported options.
 	GetPropertyDnsOptions() ([]string, error)
+	GetPropertyDnsOptionsContext(ctx context.Context) ([]string, error)
 
 	// The relative priority of DNS servers.
 	GetPropertyDnsPriority() (uint32, error)
+	GetPropertyDnsPriorityContext(ctx context.Context) (uint32, error)
 
 	// The Windows Internet Name Service servers associated with the connection.
 	GetPropertyWinsServerData() ([]string, error)
+	GetPropertyWinsServerDataContext(ctx context.Context) ([]string, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*IP4ConfigProperties, error)
+	GetPropertiesContext(ctx context.Context) (*IP4ConfigProperties, error)
+
+	// WithoutCache returns a copy of the IPv4 configuration whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() IP4Config
 
 	MarshalJSON() ([]byte, error)
 }
@@ -106,13 +143,28 @@ func NewIP4Config(objectPath dbus.ObjectPath) (IP4Config, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewIP4ConfigWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (IP4Config, error) {
+	var c ip4Config
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type ip4Config struct {
 	dbusBase
 }
 
+func (c *ip4Config) WithoutCache() IP4Config {
+	copied := *c
+	copied.uncached = true
+	return &copied
+}
+
 // Deprecated: use GetPropertyAddressData
 func (c *ip4Config) GetPropertyAddresses() ([]IP4Address, error) {
-	addresses, err := c.getSliceSliceUint32Property(IP4ConfigPropertyAddresses)
+	return c.GetPropertyAddressesContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyAddressesContext(ctx context.Context) ([]IP4Address, error) {
+	addresses, err := c.getSliceSliceUint32Property(ctx, IP4ConfigPropertyAddresses)
 	ret := make([]IP4Address, len(addresses))
 
 	if err != nil {
@@ -131,40 +183,32 @@ func (c *ip4Config) GetPropertyAddresses() ([]IP4Address, error) {
 }
 
 func (c *ip4Config) GetPropertyAddressData() ([]IP4AddressData, error) {
-	addresses, err := c.getSliceMapStringVariantProperty(IP4ConfigPropertyAddressData)
-	ret := make([]IP4AddressData, len(addresses))
+	return c.GetPropertyAddressDataContext(context.Background())
+}
 
+func (c *ip4Config) GetPropertyAddressDataContext(ctx context.Context) ([]IP4AddressData, error) {
+	addresses, err := c.getSliceMapStringVariantProperty(ctx, IP4ConfigPropertyAddressData)
 	if err != nil {
-		return ret, err
+		return []IP4AddressData{}, err
 	}
-
-	for i, address := range addresses {
-		prefix, ok := address["prefix"].Value().(uint32)
-		if !ok {
-			return ret, errors.New("unexpected variant type for address prefix")
-		}
-
-		address, ok := address["address"].Value().(string)
-		if !ok {
-			return ret, errors.New("unexpected variant type for address")
-		}
-
-		ret[i] = IP4AddressData{
-			Address: address,
-			Prefix:  uint8(prefix),
-		}
-	}
-
-	return ret, nil
+	return ip4AddressData(addresses)
 }
 
 func (c *ip4Config) GetPropertyGateway() (string, error) {
-	return c.getStringProperty(IP4ConfigPropertyGateway)
+	return c.GetPropertyGatewayContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyGatewayContext(ctx context.Context) (string, error) {
+	return c.getStringProperty(ctx, IP4ConfigPropertyGateway)
 }
 
 // Deprecated: use GetPropertyRouteData
 func (c *ip4Config) GetPropertyRoutes() ([]IP4Route, error) {
-	routes, err := c.getSliceSliceUint32Property(IP4ConfigPropertyRoutes)
+	return c.GetPropertyRoutesContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyRoutesContext(ctx context.Context) ([]IP4Route, error) {
+	routes, err := c.getSliceSliceUint32Property(ctx, IP4ConfigPropertyRoutes)
 	ret := make([]IP4Route, len(routes))
 
 	if err != nil {
@@ -184,56 +228,24 @@ func (c *ip4Config) GetPropertyRoutes() ([]IP4Route, error) {
 }
 
 func (c *ip4Config) GetPropertyRouteData() ([]IP4RouteData, error) {
-	routesData, err := c.getSliceMapStringVariantProperty(IP4ConfigPropertyRouteData)
-	routes := make([]IP4RouteData, len(routesData))
+	return c.GetPropertyRouteDataContext(context.Background())
+}
 
+func (c *ip4Config) GetPropertyRouteDataContext(ctx context.Context) ([]IP4RouteData, error) {
+	routesData, err := c.getSliceMapStringVariantProperty(ctx, IP4ConfigPropertyRouteData)
 	if err != nil {
-		return routes, err
-	}
-
-	for _, routeData := range routesData {
-
-		route := IP4RouteData{}
-
-		for routeDataAttributeName, routeDataAttribute := range routeData {
-			switch routeDataAttributeName {
-			case "dest":
-				destination, ok := routeDataAttribute.Value().(string)
-				if !ok {
-					return routes, errors.New("unexpected variant type for dest")
-				}
-				route.Destination = destination
-			case "prefix":
-				prefix, ok := routeDataAttribute.Value().(uint32)
-				if !ok {
-					return routes, errors.New("unexpected variant type for prefix")
-				}
-				route.Prefix = uint8(prefix)
-			case "next-hop":
-				nextHop, ok := routeDataAttribute.Value().(string)
-				if !ok {
-					return routes, errors.New("unexpected variant type for next-hop")
-				}
-				route.NextHop = nextHop
-			case "metric":
-				metric, ok := routeDataAttribute.Value().(uint32)
-				if !ok {
-					return routes, errors.New("unexpected variant type for metric")
-				}
-				route.Metric = uint8(metric)
-			default:
-				route.AdditionalAttributes[routeDataAttributeName] = routeDataAttribute.String()
-			}
-		}
-
-		routes = append(routes, route)
+		return []IP4RouteData{}, err
 	}
-	return routes, nil
+	return ip4RouteData(routesData)
 }
 
 // Deprecated: use GetPropertyNameserverData
 func (c *ip4Config) GetPropertyNameservers() ([]string, error) {
-	nameservers, err := c.getSliceUint32Property(IP4ConfigPropertyNameservers)
+	return c.GetPropertyNameserversContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyNameserversContext(ctx context.Context) ([]string, error) {
+	nameservers, err := c.getSliceUint32Property(ctx, IP4ConfigPropertyNameservers)
 	ret := make([]string, len(nameservers))
 
 	if err != nil {
@@ -248,71 +260,182 @@ func (c *ip4Config) GetPropertyNameservers() ([]string, error) {
 }
 
 func (c *ip4Config) GetPropertyNameserverData() ([]IP4NameserverData, error) {
-	nameserversData, err := c.getSliceMapStringVariantProperty(IP4ConfigPropertyNameserverData)
-	nameservers := make([]IP4NameserverData, len(nameserversData))
+	return c.GetPropertyNameserverDataContext(context.Background())
+}
 
+func (c *ip4Config) GetPropertyNameserverDataContext(ctx context.Context) ([]IP4NameserverData, error) {
+	nameserversData, err := c.getSliceMapStringVariantProperty(ctx, IP4ConfigPropertyNameserverData)
 	if err != nil {
-		return nameservers, err
+		return []IP4NameserverData{}, err
 	}
-
-	for _, nameserverData := range nameserversData {
-		address, ok := nameserverData["address"].Value().(string)
-
-		if !ok {
-			return nameservers, errors.New("unexpected variant type for address")
-		}
-
-		nameserver := IP4NameserverData{
-			Address: address,
-		}
-
-		nameservers = append(nameservers, nameserver)
-	}
-	return nameservers, nil
+	return ip4NameserverData(nameserversData)
 }
 
 func (c *ip4Config) GetPropertyDomains() ([]string, error) {
-	return c.getSliceStringProperty(IP4ConfigPropertyDomains)
+	return c.GetPropertyDomainsContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyDomainsContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP4ConfigPropertyDomains)
 }
 
 func (c *ip4Config) GetPropertySearches() ([]string, error) {
-	return c.getSliceStringProperty(IP4ConfigPropertySearches)
+	return c.GetPropertySearchesContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertySearchesContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP4ConfigPropertySearches)
 }
 
 func (c *ip4Config) GetPropertyDnsOptions() ([]string, error) {
-	return c.getSliceStringProperty(IP4ConfigPropertyDnsOptions)
+	return c.GetPropertyDnsOptionsContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyDnsOptionsContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP4ConfigPropertyDnsOptions)
 }
 
 func (c *ip4Config) GetPropertyDnsPriority() (uint32, error) {
-	return c.getUint32Property(IP4ConfigPropertyDnsPriority)
+	return c.GetPropertyDnsPriorityContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertyDnsPriorityContext(ctx context.Context) (uint32, error) {
+	return c.getUint32Property(ctx, IP4ConfigPropertyDnsPriority)
 }
 
 func (c *ip4Config) GetPropertyWinsServerData() ([]string, error) {
-	return c.getSliceStringProperty(IP4ConfigPropertyWinsServerData)
+	return c.GetPropertyWinsServerDataContext(context.Background())
 }
 
-func (c *ip4Config) MarshalJSON() ([]byte, error) {
-	Addresses, err := c.GetPropertyAddressData()
+func (c *ip4Config) GetPropertyWinsServerDataContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP4ConfigPropertyWinsServerData)
+}
+
+func (c *ip4Config) GetProperties() (*IP4ConfigProperties, error) {
+	return c.GetPropertiesContext(context.Background())
+}
+
+func (c *ip4Config) GetPropertiesContext(ctx context.Context) (*IP4ConfigProperties, error) {
+	props, err := c.getAllProperties(ctx, IP4ConfigInterface)
 	if err != nil {
 		return nil, err
 	}
-	Routes, err := c.GetPropertyRouteData()
+
+	var p IP4ConfigProperties
+	if err = decodeProperties(IP4ConfigInterface, props, &p); err != nil {
+		return nil, err
+	}
+
+	addresses, err := sliceMapStringVariantValue(IP4ConfigInterface, props, "AddressData")
+	if err == nil {
+		p.AddressData, err = ip4AddressData(addresses)
+	}
 	if err != nil {
 		return nil, err
 	}
-	Nameservers, err := c.GetPropertyNameserverData()
+
+	routes, err := sliceMapStringVariantValue(IP4ConfigInterface, props, "RouteData")
+	if err == nil {
+		p.RouteData, err = ip4RouteData(routes)
+	}
 	if err != nil {
 		return nil, err
 	}
-	Domains, err := c.GetPropertyDomains()
+
+	nameservers, err := sliceMapStringVariantValue(IP4ConfigInterface, props, "NameserverData")
+	if err == nil {
+		p.NameserverData, err = ip4NameserverData(nameservers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (c *ip4Config) MarshalJSON() ([]byte, error) {
+	p, err := c.GetProperties()
 	if err != nil {
 		return nil, err
 	}
 
 	return json.Marshal(map[string]interface{}{
-		"Addresses":   Addresses,
-		"Routes":      Routes,
-		"Nameservers": Nameservers,
-		"Domains":     Domains,
+		"Addresses":   p.AddressData,
+		"Routes":      p.RouteData,
+		"Nameservers": p.NameserverData,
+		"Domains":     p.Domains,
 	})
 }
+
+func ip4AddressData(addresses []map[string]dbus.Variant) ([]IP4AddressData, error) {
+	ret := make([]IP4AddressData, len(addresses))
+	for i, address := range addresses {
+		prefix, ok := address["prefix"].Value().(uint32)
+		if !ok {
+			return ret, errors.New("unexpected variant type for address prefix")
+		}
+		address, ok := address["address"].Value().(string)
+		if !ok {
+			return ret, errors.New("unexpected variant type for address")
+		}
+		ret[i] = IP4AddressData{
+			Address: address,
+			Prefix:  uint8(prefix),
+		}
+	}
+	return ret, nil
+}
+
+func ip4RouteData(routesData []map[string]dbus.Variant) ([]IP4RouteData, error) {
+	routes := make([]IP4RouteData, 0, len(routesData))
+	for _, routeData := range routesData {
+		route := IP4RouteData{AdditionalAttributes: make(map[string]string)}
+		for routeDataAttributeName, routeDataAttribute := range routeData {
+			switch routeDataAttributeName {
+			case "dest":
+				destination, ok := routeDataAttribute.Value().(string)
+				if !ok {
+					return routes, errors.New("unexpected variant type for dest")
+				}
+				route.Destination = destination
+			case "prefix":
+				prefix, ok := routeDataAttribute.Value().(uint32)
+				if !ok {
+					return routes, errors.New("unexpected variant type for prefix")
+				}
+				route.Prefix = uint8(prefix)
+			case "next-hop":
+				nextHop, ok := routeDataAttribute.Value().(string)
+				if !ok {
+					return routes, errors.New("unexpected variant type for next-hop")
+				}
+				route.NextHop = nextHop
+			case "metric":
+				metric, ok := routeDataAttribute.Value().(uint32)
+				if !ok {
+					return routes, errors.New("unexpected variant type for metric")
+				}
+				route.Metric = &metric
+			default:
+				route.AdditionalAttributes[routeDataAttributeName] = routeDataAttribute.String()
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func ip4NameserverData(nameserversData []map[string]dbus.Variant) ([]IP4NameserverData, error) {
+	nameservers := make([]IP4NameserverData, 0, len(nameserversData))
+	for _, nameserverData := range nameserversData {
+		address, ok := nameserverData["address"].Value().(string)
+		if !ok {
+			return nameservers, errors.New("unexpected variant type for address")
+		}
+		nameserver := IP4NameserverData{
+			Address: address,
+		}
+		nameservers = append(nameservers, nameserver)
+	}
+	return nameservers, nil
+}
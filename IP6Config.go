@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -43,39 +44,70 @@ type IP6Route struct {
 	Metric  uint8
 }
 
+// IP6RouteData is a route of the RouteData property and of the route-data
+// key of SettingIP6Config. Metric is nil for a route without a metric of its
+// own, which then gets the route-metric of the connection.
 type IP6RouteData struct {
 	Destination          string
 	Prefix               uint8
 	NextHop              string
-	Metric               uint8
+	Metric               *uint32
 	AdditionalAttributes map[string]string
 }
 
+// IP6ConfigProperties holds the properties of an IP6Config, read at once by
+// IP6Config.GetProperties. The deprecated properties are left out.
+type IP6ConfigProperties struct {
+	AddressData []IP6AddressData `dbus:"-"`
+	Gateway     string
+	RouteData   []IP6RouteData `dbus:"-"`
+	Nameservers [][]byte
+	Domains     []string
+	Searches    []string
+	DnsOptions  []string
+	DnsPriority int32
+}
+
 type IP6Config interface {
 
 	// Array of IP address data objects. All addresses will include "address" (an IP address string), and "prefix" (a uint). Some addresses may include additional attributes.
 	GetPropertyAddressData() ([]IP6AddressData, error)
+	GetPropertyAddressDataContext(ctx context.Context) ([]IP6AddressData, error)
 
 	// The gateway in use.
 	GetPropertyGateway() (string, error)
+	GetPropertyGatewayContext(ctx context.Context) (string, error)
 
 	// Array of IP route data objects. All routes will include "dest" (an IP address string) and "prefix" (a uint). Some routes may include "next-hop" (an IP address string), "metric" (a uint), and additional attributes.
 	GetPropertyRouteData() ([]IP6RouteData, error)
+	GetPropertyRouteDataContext(ctx context.Context) ([]IP6RouteData, error)
 
 	// GetNameservers gets the nameservers in use.
 	GetPropertyNameservers() ([]string, error)
+	GetPropertyNameserversContext(ctx context.Context) ([]string, error)
 
 	// A list of domains this address belongs to.
 	GetPropertyDomains() ([]string, error)
+	GetPropertyDomainsContext(ctx context.Context) ([]string, error)
 
 	// A list of dns searches.
 	GetPropertySearches() ([]string, error)
+	GetPropertySearchesContext(ctx context.Context) ([]string, error)
 
 	// A list of DNS options that modify the behavior of the DNS resolver. See resolv.conf(5) manual page for the list of supported options.
 	GetPropertyDnsOptions() ([]string, error)
+	GetPropertyDnsOptionsContext(ctx context.Context) ([]string, error)
 
 	// The relative priority of DNS servers.
 	GetPropertyDnsPriority() (uint32, error)
+	GetPropertyDnsPriorityContext(ctx context.Context) (uint32, error)
+
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*IP6ConfigProperties, error)
+	GetPropertiesContext(ctx context.Context) (*IP6ConfigProperties, error)
+
+	// WithoutCache returns a copy of the IPv6 configuration whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() IP6Config
 
 	MarshalJSON() ([]byte, error)
 }
@@ -85,54 +117,170 @@ func NewIP6Config(objectPath dbus.ObjectPath) (IP6Config, error) {
 	return &c, c.init(NetworkManagerInterface, objectPath)
 }
 
+func NewIP6ConfigWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (IP6Config, error) {
+	var c ip6Config
+	return &c, c.initWithConn(conn, NetworkManagerInterface, objectPath)
+}
+
 type ip6Config struct {
 	dbusBase
 }
 
+func (c *ip6Config) WithoutCache() IP6Config {
+	copied := *c
+	copied.uncached = true
+	return &copied
+}
+
 func (c *ip6Config) GetPropertyAddressData() ([]IP6AddressData, error) {
-	addresses, err := c.getSliceMapStringVariantProperty(IP6ConfigPropertyAddressData)
-	ret := make([]IP6AddressData, len(addresses))
+	return c.GetPropertyAddressDataContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyAddressDataContext(ctx context.Context) ([]IP6AddressData, error) {
+	addresses, err := c.getSliceMapStringVariantProperty(ctx, IP6ConfigPropertyAddressData)
+	if err != nil {
+		return []IP6AddressData{}, err
+	}
+	return ip6AddressData(addresses)
+}
+
+func (c *ip6Config) GetPropertyGateway() (string, error) {
+	return c.GetPropertyGatewayContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyGatewayContext(ctx context.Context) (string, error) {
+	return c.getStringProperty(ctx, IP6ConfigPropertyGateway)
+}
+
+func (c *ip6Config) GetPropertyRouteData() ([]IP6RouteData, error) {
+	return c.GetPropertyRouteDataContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyRouteDataContext(ctx context.Context) ([]IP6RouteData, error) {
+	routesData, err := c.getSliceMapStringVariantProperty(ctx, IP6ConfigPropertyRouteData)
+	if err != nil {
+		return []IP6RouteData{}, err
+	}
+	return ip6RouteData(routesData)
+}
+
+func (c *ip6Config) GetPropertyNameservers() ([]string, error) {
+	return c.GetPropertyNameserversContext(context.Background())
+}
 
+func (c *ip6Config) GetPropertyNameserversContext(ctx context.Context) ([]string, error) {
+	nameservers, err := c.getSliceSliceByteProperty(ctx, IP6ConfigPropertyNameservers)
 	if err != nil {
-		return ret, err
+		return []string{}, err
 	}
+	return ip6Nameservers(nameservers), nil
+}
+
+func (c *ip6Config) GetPropertyDomains() ([]string, error) {
+	return c.GetPropertyDomainsContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyDomainsContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP6ConfigPropertyDomains)
+}
+
+func (c *ip6Config) GetPropertySearches() ([]string, error) {
+	return c.GetPropertySearchesContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertySearchesContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP6ConfigPropertySearches)
+}
+
+func (c *ip6Config) GetPropertyDnsOptions() ([]string, error) {
+	return c.GetPropertyDnsOptionsContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyDnsOptionsContext(ctx context.Context) ([]string, error) {
+	return c.getSliceStringProperty(ctx, IP6ConfigPropertyDnsOptions)
+}
+
+func (c *ip6Config) GetPropertyDnsPriority() (uint32, error) {
+	return c.GetPropertyDnsPriorityContext(context.Background())
+}
+
+func (c *ip6Config) GetPropertyDnsPriorityContext(ctx context.Context) (uint32, error) {
+	return c.getUint32Property(ctx, IP6ConfigPropertyDnsPriority)
+}
+
+func (c *ip6Config) GetProperties() (*IP6ConfigProperties, error) {
+	return c.GetPropertiesContext(context.Background())
+}
 
+func (c *ip6Config) GetPropertiesContext(ctx context.Context) (*IP6ConfigProperties, error) {
+	props, err := c.getAllProperties(ctx, IP6ConfigInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p IP6ConfigProperties
+	if err = decodeProperties(IP6ConfigInterface, props, &p); err != nil {
+		return nil, err
+	}
+
+	addresses, err := sliceMapStringVariantValue(IP6ConfigInterface, props, "AddressData")
+	if err == nil {
+		p.AddressData, err = ip6AddressData(addresses)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := sliceMapStringVariantValue(IP6ConfigInterface, props, "RouteData")
+	if err == nil {
+		p.RouteData, err = ip6RouteData(routes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (c *ip6Config) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{})
+
+	p, err := c.GetProperties()
+	if err != nil {
+		p = &IP6ConfigProperties{}
+	}
+
+	m["Addresses"] = p.AddressData
+	m["Routes"] = p.RouteData
+	m["Nameservers"] = ip6Nameservers(p.Nameservers)
+	m["Domains"] = p.Domains
+
+	return json.Marshal(m)
+}
+
+func ip6AddressData(addresses []map[string]dbus.Variant) ([]IP6AddressData, error) {
+	ret := make([]IP6AddressData, len(addresses))
 	for i, address := range addresses {
 		prefix, ok := address["prefix"].Value().(uint32)
 		if !ok {
-			return ret, errors.New("unexpected variant type for prefix")
+			return ret, errors.New("unexpected variant type for address prefix")
 		}
-
 		address, ok := address["address"].Value().(string)
 		if !ok {
 			return ret, errors.New("unexpected variant type for address")
 		}
-
 		ret[i] = IP6AddressData{
 			Address: address,
 			Prefix:  uint8(prefix),
 		}
 	}
-
 	return ret, nil
 }
 
-func (c *ip6Config) GetPropertyGateway() (string, error) {
-	return c.getStringProperty(IP6ConfigPropertyGateway)
-}
-
-func (c *ip6Config) GetPropertyRouteData() ([]IP6RouteData, error) {
-	routesData, err := c.getSliceMapStringVariantProperty(IP6ConfigPropertyRouteData)
-	routes := make([]IP6RouteData, len(routesData))
-
-	if err != nil {
-		return routes, err
-	}
-
+func ip6RouteData(routesData []map[string]dbus.Variant) ([]IP6RouteData, error) {
+	routes := make([]IP6RouteData, 0, len(routesData))
 	for _, routeData := range routesData {
-
-		route := IP6RouteData{}
-
+		route := IP6RouteData{AdditionalAttributes: make(map[string]string)}
 		for routeDataAttributeName, routeDataAttribute := range routeData {
 			switch routeDataAttributeName {
 			case "dest":
@@ -158,55 +306,20 @@ func (c *ip6Config) GetPropertyRouteData() ([]IP6RouteData, error) {
 				if !ok {
 					return routes, errors.New("unexpected variant type for metric")
 				}
-				route.Metric = uint8(metric)
+				route.Metric = &metric
 			default:
 				route.AdditionalAttributes[routeDataAttributeName] = routeDataAttribute.String()
 			}
 		}
-
 		routes = append(routes, route)
 	}
 	return routes, nil
 }
 
-func (c *ip6Config) GetPropertyNameservers() ([]string, error) {
-	nameservers, err := c.getSliceSliceByteProperty(IP6ConfigPropertyNameservers)
+func ip6Nameservers(nameservers [][]byte) []string {
 	ret := make([]string, len(nameservers))
-
-	if err != nil {
-		return ret, err
-	}
-
 	for i, nameserver := range nameservers {
 		ret[i] = string(nameserver)
 	}
-
-	return ret, nil
-}
-
-func (c *ip6Config) GetPropertyDomains() ([]string, error) {
-	return c.getSliceStringProperty(IP6ConfigPropertyDomains)
-}
-
-func (c *ip6Config) GetPropertySearches() ([]string, error) {
-	return c.getSliceStringProperty(IP6ConfigPropertySearches)
-}
-
-func (c *ip6Config) GetPropertyDnsOptions() ([]string, error) {
-	return c.getSliceStringProperty(IP6ConfigPropertyDnsOptions)
-}
-
-func (c *ip6Config) GetPropertyDnsPriority() (uint32, error) {
-	return c.getUint32Property(IP6ConfigPropertyDnsPriority)
-}
-
-func (c *ip6Config) MarshalJSON() ([]byte, error) {
-	m := make(map[string]interface{})
-
-	m["Addresses"], _ = c.GetPropertyAddressData()
-	m["Routes"], _ = c.GetPropertyRouteData()
-	m["Nameservers"], _ = c.GetPropertyNameservers()
-	m["Domains"], _ = c.GetPropertyDomains()
-
-	return json.Marshal(m)
+	return ret
 }
@@ -0,0 +1,110 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"fmt"
+)
+
+func (nm *networkManager) AddAndActivateMaster(ctx context.Context, interfaceName string, master Setting, slaves []DeviceWired, settings ...Setting) (ActiveConnection, error) {
+	slaveType := master.SettingName()
+	switch master.(type) {
+	case *SettingBond, *SettingBridge, *SettingTeam:
+	default:
+		return nil, fmt.Errorf("%s is not a master setting", slaveType)
+	}
+
+	masterSettings, err := NewConnectionSettings(append([]Setting{
+		&SettingConnection{Id: interfaceName, Type: slaveType, InterfaceName: interfaceName},
+		master,
+	}, settings...)...)
+	if err != nil {
+		return nil, err
+	}
+	uuid, _ := masterSettings[SettingConnectionSettingName]["uuid"].(string)
+
+	// NetworkManager creates the master device, which may not exist yet.
+	profiles := []ConnectionSettings{masterSettings}
+	devices := []Device{nil}
+	for _, slave := range slaves {
+		iface, err := slave.GetPropertyInterfaceContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slaveSettings, err := NewConnectionSettings(
+			&SettingConnection{Id: slaveType + "-slave-" + iface, Type: SettingWiredSettingName, InterfaceName: iface, Master: uuid, SlaveType: slaveType},
+			&SettingWired{},
+		)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, slaveSettings)
+		devices = append(devices, slave)
+	}
+
+	acs, err := nm.addAndActivateProfiles(ctx, profiles, devices)
+	if err != nil {
+		return nil, err
+	}
+	return acs[0], nil
+}
+
+// addAndActivateProfiles adds saved connection profiles, then activates each
+// of them on its device, nil to let NetworkManager pick it, and waits for all
+// the activations to complete. When a profile cannot be added or activated,
+// the profiles added so far are deleted, which also deactivates them.
+func (nm *networkManager) addAndActivateProfiles(ctx context.Context, profiles []ConnectionSettings, devices []Device) ([]ActiveConnection, error) {
+	s, err := NewSettingsWithConn(nm.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make([]Connection, 0, len(profiles))
+	for _, profile := range profiles {
+		c, err := s.AddConnectionContext(ctx, profile)
+		if err != nil {
+			return nil, deleteConnections(connections, err)
+		}
+		connections = append(connections, c)
+	}
+
+	sub, err := newEventSubscription(ctx, nm.dbusBase, nil)
+	if err != nil {
+		return nil, deleteConnections(connections, err)
+	}
+	defer sub.Close()
+
+	var acs []ActiveConnection
+	for i, c := range connections {
+		ac, err := nm.ActivateConnectionContext(ctx, c, devices[i])
+		if err != nil {
+			return nil, deleteConnections(connections, err)
+		}
+		acs = append(acs, ac)
+	}
+
+	for _, ac := range acs {
+		if err = nm.waitForActivation(ctx, sub, ac.GetPath()); err != nil {
+			return nil, deleteConnections(connections, err)
+		}
+	}
+	return acs, nil
+}
+
+// deleteConnections deletes the profiles added by an operation that failed
+// with err, even when its context is done, and reports a failure to delete
+// them with err.
+func deleteConnections(connections []Connection, err error) error {
+	cleanupCtx, cancel := cleanupContext()
+	defer cancel()
+
+	var deleteErr error
+	for _, connection := range connections {
+		if e := connection.DeleteContext(cleanupCtx); e != nil && deleteErr == nil {
+			deleteErr = e
+		}
+	}
+	if deleteErr != nil {
+		return fmt.Errorf("%w (cleanup failed: %v)", err, deleteErr)
+	}
+	return err
+}
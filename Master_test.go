@@ -0,0 +1,166 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+func newWiredDevices(t *testing.T, f *fake, ifaces ...string) []gnm.DeviceWired {
+	t.Helper()
+
+	devices := make([]gnm.DeviceWired, len(ifaces))
+	for i, iface := range ifaces {
+		obj := f.srv.AddDevice(iface, gnm.NmDeviceTypeEthernet)
+		d, err := gnm.NewDeviceWiredWithConn(f.conn, obj.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		devices[i] = d
+	}
+	return devices
+}
+
+func TestAddAndActivateMaster(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	slaves := newWiredDevices(t, f, "eth0", "eth1")
+	// NetworkManager would create the device of the bond.
+	bobj := f.srv.AddDevice("bond0", gnm.NmDeviceTypeBond)
+	bond, err := gnm.DeviceFactoryWithConn(f.conn, bobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bond.(gnm.DeviceBond); !ok {
+		t.Fatalf("DeviceFactoryWithConn() of a bond = %T, want a DeviceBond", bond)
+	}
+
+	master := &gnm.SettingBond{Options: map[string]string{"mode": gnm.SettingBondModeActiveBackup}}
+	ac, err := f.nm.AddAndActivateMaster(context.Background(), "bond0", master, slaves,
+		&gnm.SettingIP4Config{Method: gnm.SettingIP4ConfigMethodAuto})
+	if err != nil {
+		t.Fatalf("AddAndActivateMaster() = %v", err)
+	}
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("GetPropertyState() = %v, %v, want activated", state, err)
+	}
+	if devices, err := ac.GetPropertyDevices(); err != nil || len(devices) != 1 || devices[0].GetPath() != bond.GetPath() {
+		t.Errorf("GetPropertyDevices() = %v, %v, want %s", devices, err, bond.GetPath())
+	}
+
+	c, err := ac.GetPropertyConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := f.srv.Object(c.GetPath()).ConnectionSettings()
+	var connection gnm.SettingConnection
+	if err := settings.GetSetting(&connection); err != nil {
+		t.Fatal(err)
+	}
+	if connection.Type != gnm.SettingBondSettingName || connection.InterfaceName != "bond0" {
+		t.Errorf("type, interface-name = %s, %s, want %s, bond0", connection.Type, connection.InterfaceName, gnm.SettingBondSettingName)
+	}
+	var bondSetting gnm.SettingBond
+	if err := settings.GetSetting(&bondSetting); err != nil {
+		t.Fatal(err)
+	}
+	if mode := bondSetting.Options["mode"]; mode != gnm.SettingBondModeActiveBackup {
+		t.Errorf("bond mode = %s, want %s", mode, gnm.SettingBondModeActiveBackup)
+	}
+	if method := settings["ipv4"]["method"]; method != gnm.SettingIP4ConfigMethodAuto {
+		t.Errorf("ipv4.method = %v, want %s", method, gnm.SettingIP4ConfigMethodAuto)
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 3 {
+		t.Fatalf("%d profiles, want the master and 2 slaves", len(profiles))
+	}
+	for _, profile := range profiles[1:] {
+		var slave gnm.SettingConnection
+		if err := profile.GetSetting(&slave); err != nil {
+			t.Fatal(err)
+		}
+		if slave.Master != connection.Uuid || slave.SlaveType != gnm.SettingBondSettingName {
+			t.Errorf("master, slave-type of %s = %s, %s, want %s, %s", slave.InterfaceName, slave.Master, slave.SlaveType,
+				connection.Uuid, gnm.SettingBondSettingName)
+		}
+	}
+
+	for _, slave := range slaves {
+		if state, err := slave.GetPropertyState(); err != nil || state != gnm.NmDeviceStateActivated {
+			t.Errorf("state of %s = %v, %v, want activated", slave.GetPath(), state, err)
+		}
+	}
+}
+
+func TestAddAndActivateMasterFailure(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	slaves := newWiredDevices(t, f, "eth0", "eth1")
+	f.srv.SetActivationFunc(func(s *nmfake.Server, ac *nmfake.Object) {
+		s.SetActiveConnectionState(ac, gnm.NmActiveConnectionStateDeactivated, gnm.NmActiveConnectionStateReasonDeviceDisconnected)
+	})
+
+	ac, err := f.nm.AddAndActivateMaster(context.Background(), "bond0", &gnm.SettingBond{}, slaves)
+	var activationErr *gnm.ActivationError
+	if !errors.As(err, &activationErr) {
+		t.Fatalf("AddAndActivateMaster() = %v, want an *ActivationError", err)
+	}
+	if ac != nil {
+		t.Errorf("AddAndActivateMaster() returned %s, want no active connection", ac.GetPath())
+	}
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Errorf("%d profiles left, want none", len(profiles))
+	}
+}
+
+func TestAddAndActivateMasterNotMaster(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	slaves := newWiredDevices(t, f, "eth0")
+	if _, err := f.nm.AddAndActivateMaster(context.Background(), "bond0", &gnm.SettingWired{}, slaves); err == nil {
+		t.Error("AddAndActivateMaster() with a wired setting succeeded")
+	}
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Errorf("%d profiles added, want none", len(profiles))
+	}
+}
+
+func TestSettingBridgeZeroValues(t *testing.T) {
+	want := &gnm.SettingBridge{Priority: uint32p(0), AgeingTime: uint32p(0), VlanDefaultPvid: uint32p(0)}
+	cs, err := gnm.NewConnectionSettings(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"priority", "ageing-time", "vlan-default-pvid"} {
+		if value, ok := cs[gnm.SettingBridgeSettingName][key]; !ok || value != uint32(0) {
+			t.Errorf("bridge.%s = %#v, want uint32(0)", key, value)
+		}
+	}
+
+	var got gnm.SettingBridge
+	if err := cs.GetSetting(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("GetSetting() = %+v, want %+v", got, want)
+	}
+
+	// Unset keys are left to the defaults of NetworkManager.
+	cs, err = gnm.NewConnectionSettings(&gnm.SettingBridge{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"priority", "ageing-time", "vlan-default-pvid"} {
+		if value, ok := cs[gnm.SettingBridgeSettingName][key]; ok {
+			t.Errorf("bridge.%s = %#v while unset", key, value)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	ModemManagerInterface  = "org.freedesktop.ModemManager1"
+	ModemManagerObjectPath = "/org/freedesktop/ModemManager1"
+
+	ModemManagerModemInterface     = ModemManagerInterface + ".Modem"
+	ModemManagerModem3gppInterface = ModemManagerModemInterface + ".Modem3gpp"
+
+	/* Properties */
+	ModemManagerModemPropertyManufacturer        = ModemManagerModemInterface + ".Manufacturer"        // readable   s
+	ModemManagerModemPropertyModel               = ModemManagerModemInterface + ".Model"               // readable   s
+	ModemManagerModemPropertyRevision            = ModemManagerModemInterface + ".Revision"            // readable   s
+	ModemManagerModemPropertyEquipmentIdentifier = ModemManagerModemInterface + ".EquipmentIdentifier" // readable   s
+	ModemManagerModemPropertyOwnNumbers          = ModemManagerModemInterface + ".OwnNumbers"          // readable   as
+	ModemManagerModemPropertyState               = ModemManagerModemInterface + ".State"               // readable   i
+	ModemManagerModemPropertyAccessTechnologies  = ModemManagerModemInterface + ".AccessTechnologies"  // readable   u
+	ModemManagerModemPropertySignalQuality       = ModemManagerModemInterface + ".SignalQuality"       // readable   (ub)
+
+	ModemManagerModem3gppPropertyImei              = ModemManagerModem3gppInterface + ".Imei"              // readable   s
+	ModemManagerModem3gppPropertyRegistrationState = ModemManagerModem3gppInterface + ".RegistrationState" // readable   u
+	ModemManagerModem3gppPropertyOperatorCode      = ModemManagerModem3gppInterface + ".OperatorCode"      // readable   s
+	ModemManagerModem3gppPropertyOperatorName      = ModemManagerModem3gppInterface + ".OperatorName"      // readable   s
+)
+
+// ModemSignalQuality is the SignalQuality property of a ModemManager modem.
+type ModemSignalQuality struct {
+	Quality uint32 // Signal quality in percent, from 0 to 100.
+	Recent  bool   // Whether the quality was taken recently.
+}
+
+// ModemManagerModemProperties holds the properties of the
+// ModemManagerModemInterface of a modem, read at once by
+// ModemManagerModem.GetProperties.
+type ModemManagerModemProperties struct {
+	Manufacturer        string
+	Model               string
+	Revision            string
+	EquipmentIdentifier string
+	OwnNumbers          []string
+	State               MmModemState
+	AccessTechnologies  MmModemAccessTechnology
+	SignalQuality       ModemSignalQuality
+}
+
+// ModemManagerModem is a modem of the ModemManager service, which
+// NetworkManager relies on for mobile broadband. Use
+// DeviceModem.GetModemManagerModem to get the one behind a NetworkManager
+// device.
+type ModemManagerModem interface {
+	GetPath() dbus.ObjectPath
+
+	// The equipment manufacturer, as reported by the modem.
+	GetPropertyManufacturer() (string, error)
+	GetPropertyManufacturerContext(ctx context.Context) (string, error)
+
+	// The equipment model, as reported by the modem.
+	GetPropertyModel() (string, error)
+	GetPropertyModelContext(ctx context.Context) (string, error)
+
+	// The revision identification of the software, as reported by the modem.
+	GetPropertyRevision() (string, error)
+	GetPropertyRevisionContext(ctx context.Context) (string, error)
+
+	// The identity of the device, e.g. the IMEI of 3GPP modems or the ESN or MEID of CDMA modems.
+	GetPropertyEquipmentIdentifier() (string, error)
+	GetPropertyEquipmentIdentifierContext(ctx context.Context) (string, error)
+
+	// The phone numbers of the SIM, as reported by the modem.
+	GetPropertyOwnNumbers() ([]string, error)
+	GetPropertyOwnNumbersContext(ctx context.Context) ([]string, error)
+
+	// Overall state of the modem.
+	GetPropertyState() (MmModemState, error)
+	GetPropertyStateContext(ctx context.Context) (MmModemState, error)
+
+	// The current network access technologies used by the modem to communicate with the network. If the modem is not connected to the network, the value is MmModemAccessTechnologyUnknown.
+	GetPropertyAccessTechnologies() (MmModemAccessTechnology, error)
+	GetPropertyAccessTechnologiesContext(ctx context.Context) (MmModemAccessTechnology, error)
+
+	// Signal quality in percent (0 - 100) of the dominant access technology the device is using to communicate with the network, and whether it was taken recently.
+	GetPropertySignalQuality() (ModemSignalQuality, error)
+	GetPropertySignalQualityContext(ctx context.Context) (ModemSignalQuality, error)
+
+	// The IMEI of the device. Only 3GPP modems, e.g. GSM, UMTS or LTE ones, have it.
+	GetPropertyImei() (string, error)
+	GetPropertyImeiContext(ctx context.Context) (string, error)
+
+	// The registration status of the modem on a 3GPP network.
+	GetPropertyRegistrationState() (MmModem3gppRegistrationState, error)
+	GetPropertyRegistrationStateContext(ctx context.Context) (MmModem3gppRegistrationState, error)
+
+	// Code of the 3GPP network operator the modem is registered with, its MCC and MNC, e.g. "310260". Empty if the modem is not registered.
+	GetPropertyOperatorCode() (string, error)
+	GetPropertyOperatorCodeContext(ctx context.Context) (string, error)
+
+	// Name of the 3GPP network operator the modem is registered with. Empty if the modem is not registered.
+	GetPropertyOperatorName() (string, error)
+	GetPropertyOperatorNameContext(ctx context.Context) (string, error)
+
+	// Read all the properties of the ModemManagerModemInterface at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*ModemManagerModemProperties, error)
+	GetPropertiesContext(ctx context.Context) (*ModemManagerModemProperties, error)
+
+	MarshalJSON() ([]byte, error)
+}
+
+func NewModemManagerModem(objectPath dbus.ObjectPath) (ModemManagerModem, error) {
+	var m modemManagerModem
+	return &m, m.init(ModemManagerInterface, objectPath)
+}
+
+func NewModemManagerModemWithConn(conn *dbus.Conn, objectPath dbus.ObjectPath) (ModemManagerModem, error) {
+	var m modemManagerModem
+	return &m, m.initWithConn(conn, ModemManagerInterface, objectPath)
+}
+
+type modemManagerModem struct {
+	dbusBase
+}
+
+func (m *modemManagerModem) GetPath() dbus.ObjectPath {
+	return m.obj.Path()
+}
+
+func (m *modemManagerModem) GetPropertyManufacturer() (string, error) {
+	return m.GetPropertyManufacturerContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyManufacturerContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModemPropertyManufacturer)
+}
+
+func (m *modemManagerModem) GetPropertyModel() (string, error) {
+	return m.GetPropertyModelContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyModelContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModemPropertyModel)
+}
+
+func (m *modemManagerModem) GetPropertyRevision() (string, error) {
+	return m.GetPropertyRevisionContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyRevisionContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModemPropertyRevision)
+}
+
+func (m *modemManagerModem) GetPropertyEquipmentIdentifier() (string, error) {
+	return m.GetPropertyEquipmentIdentifierContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyEquipmentIdentifierContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModemPropertyEquipmentIdentifier)
+}
+
+func (m *modemManagerModem) GetPropertyOwnNumbers() ([]string, error) {
+	return m.GetPropertyOwnNumbersContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyOwnNumbersContext(ctx context.Context) ([]string, error) {
+	return m.getSliceStringProperty(ctx, ModemManagerModemPropertyOwnNumbers)
+}
+
+func (m *modemManagerModem) GetPropertyState() (MmModemState, error) {
+	return m.GetPropertyStateContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyStateContext(ctx context.Context) (MmModemState, error) {
+	v, err := m.getInt32Property(ctx, ModemManagerModemPropertyState)
+	return MmModemState(v), err
+}
+
+func (m *modemManagerModem) GetPropertyAccessTechnologies() (MmModemAccessTechnology, error) {
+	return m.GetPropertyAccessTechnologiesContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyAccessTechnologiesContext(ctx context.Context) (MmModemAccessTechnology, error) {
+	v, err := m.getUint32Property(ctx, ModemManagerModemPropertyAccessTechnologies)
+	return MmModemAccessTechnology(v), err
+}
+
+func (m *modemManagerModem) GetPropertySignalQuality() (ModemSignalQuality, error) {
+	return m.GetPropertySignalQualityContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertySignalQualityContext(ctx context.Context) (q ModemSignalQuality, err error) {
+	prop, err := m.getProperty(ctx, ModemManagerModemPropertySignalQuality)
+	if err != nil {
+		return
+	}
+	if err = dbus.Store([]interface{}{prop}, &q); err != nil {
+		err = makeErrVariantType(ModemManagerModemPropertySignalQuality)
+	}
+	return
+}
+
+func (m *modemManagerModem) GetPropertyImei() (string, error) {
+	return m.GetPropertyImeiContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyImeiContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModem3gppPropertyImei)
+}
+
+func (m *modemManagerModem) GetPropertyRegistrationState() (MmModem3gppRegistrationState, error) {
+	return m.GetPropertyRegistrationStateContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyRegistrationStateContext(ctx context.Context) (MmModem3gppRegistrationState, error) {
+	v, err := m.getUint32Property(ctx, ModemManagerModem3gppPropertyRegistrationState)
+	return MmModem3gppRegistrationState(v), err
+}
+
+func (m *modemManagerModem) GetPropertyOperatorCode() (string, error) {
+	return m.GetPropertyOperatorCodeContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyOperatorCodeContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModem3gppPropertyOperatorCode)
+}
+
+func (m *modemManagerModem) GetPropertyOperatorName() (string, error) {
+	return m.GetPropertyOperatorNameContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertyOperatorNameContext(ctx context.Context) (string, error) {
+	return m.getStringProperty(ctx, ModemManagerModem3gppPropertyOperatorName)
+}
+
+func (m *modemManagerModem) GetProperties() (*ModemManagerModemProperties, error) {
+	return m.GetPropertiesContext(context.Background())
+}
+
+func (m *modemManagerModem) GetPropertiesContext(ctx context.Context) (*ModemManagerModemProperties, error) {
+	props, err := m.getAllProperties(ctx, ModemManagerModemInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	var p ModemManagerModemProperties
+	return &p, decodeProperties(ModemManagerModemInterface, props, &p)
+}
+
+func (m *modemManagerModem) MarshalJSON() ([]byte, error) {
+	p, err := m.GetProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	r := map[string]interface{}{
+		"Manufacturer":        p.Manufacturer,
+		"Model":               p.Model,
+		"Revision":            p.Revision,
+		"EquipmentIdentifier": p.EquipmentIdentifier,
+		"OwnNumbers":          p.OwnNumbers,
+		"State":               p.State.String(),
+		"AccessTechnologies":  p.AccessTechnologies,
+		"SignalQuality":       p.SignalQuality.Quality,
+	}
+
+	// CDMA modems do not implement the 3GPP interface.
+	if props, err := m.getAllProperties(context.Background(), ModemManagerModem3gppInterface); err == nil {
+		var p3gpp struct {
+			Imei              string
+			RegistrationState MmModem3gppRegistrationState
+			OperatorCode      string
+			OperatorName      string
+		}
+		if decodeProperties(ModemManagerModem3gppInterface, props, &p3gpp) == nil {
+			r["Imei"] = p3gpp.Imei
+			r["RegistrationState"] = p3gpp.RegistrationState.String()
+			r["OperatorCode"] = p3gpp.OperatorCode
+			r["OperatorName"] = p3gpp.OperatorName
+		}
+	}
+
+	return json.Marshal(r)
+}
@@ -0,0 +1,76 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestGetModemManagerModem(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	dobj := f.srv.AddDevice("cdc-wdm0", gnm.NmDeviceTypeModem)
+	d, err := gnm.DeviceFactoryWithConn(f.conn, dobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	modem, ok := d.(gnm.DeviceModem)
+	if !ok {
+		t.Fatalf("DeviceFactoryWithConn() of a modem = %T, want a DeviceModem", d)
+	}
+	if caps, err := modem.GetPropertyCurrentCapabilities(); err != nil || caps&gnm.NmDeviceModemCapabilitiesLte == 0 {
+		t.Errorf("GetPropertyCurrentCapabilities() = %v, %v, want LTE", caps, err)
+	}
+
+	// The Udi of the device is not a ModemManager modem yet.
+	if _, err := modem.GetModemManagerModem(); err != gnm.ErrNotModemManagerModem {
+		t.Errorf("GetModemManagerModem() = %v, want %v", err, gnm.ErrNotModemManagerModem)
+	}
+
+	mobj := f.srv.AddModemManagerModem(dobj)
+	mm, err := modem.GetModemManagerModem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mm.GetPath() != mobj.Path() {
+		t.Errorf("GetModemManagerModem() = %s, want %s", mm.GetPath(), mobj.Path())
+	}
+
+	props, err := mm.GetProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.State != gnm.MmModemStateRegistered || props.AccessTechnologies != gnm.MmModemAccessTechnologyLte || props.SignalQuality.Quality != 75 || !props.SignalQuality.Recent {
+		t.Errorf("GetProperties() = %+v, want a registered LTE modem at 75%%", props)
+	}
+	if imei, err := mm.GetPropertyImei(); err != nil || imei != props.EquipmentIdentifier {
+		t.Errorf("GetPropertyImei() = %q, %v, want %q", imei, err, props.EquipmentIdentifier)
+	}
+	if state, err := mm.GetPropertyRegistrationState(); err != nil || state != gnm.MmModem3gppRegistrationStateHome {
+		t.Errorf("GetPropertyRegistrationState() = %v, %v, want home", state, err)
+	}
+
+	mobj.Set(gnm.ModemManagerModemPropertySignalQuality, struct {
+		Quality uint32
+		Recent  bool
+	}{20, false})
+	if q, err := mm.GetPropertySignalQuality(); err != nil || q.Quality != 20 || q.Recent {
+		t.Errorf("GetPropertySignalQuality() = %+v, %v, want 20 not recent", q, err)
+	}
+}
+
+func TestSetPropertyWwanEnabled(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	if err := f.nm.SetPropertyWwanEnabled(false); err != nil {
+		t.Fatal(err)
+	}
+	if enabled, err := f.nm.GetPropertyWwanEnabled(); err != nil || enabled {
+		t.Errorf("GetPropertyWwanEnabled() = %v, %v, want false", enabled, err)
+	}
+	if enabled := f.srv.NetworkManager().Get(gnm.NetworkManagerPropertyWwanEnabled).Value(); enabled != false {
+		t.Errorf("WwanEnabled of the fake = %v, want false", enabled)
+	}
+}
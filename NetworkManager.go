@@ -1,6 +1,7 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/godbus/dbus/v5"
@@ -57,6 +58,34 @@ const (
 	NetworkManagerPropertyGlobalDnsConfiguration     = NetworkManagerInterface + ".GlobalDnsConfiguration"     // readwrite  a{sv}
 )
 
+// NetworkManagerProperties holds the properties of the NetworkManager
+// object, read at once by NetworkManager.GetProperties.
+type NetworkManagerProperties struct {
+	Devices                    []dbus.ObjectPath
+	AllDevices                 []dbus.ObjectPath
+	Checkpoints                []dbus.ObjectPath
+	NetworkingEnabled          bool
+	WirelessEnabled            bool
+	WirelessHardwareEnabled    bool
+	WwanEnabled                bool
+	WwanHardwareEnabled        bool
+	WimaxEnabled               bool
+	WimaxHardwareEnabled       bool
+	ActiveConnections          []dbus.ObjectPath
+	PrimaryConnection          dbus.ObjectPath
+	PrimaryConnectionType      string
+	Metered                    NmMetered
+	ActivatingConnection       dbus.ObjectPath
+	Startup                    bool
+	Version                    string
+	Capabilities               []NmCapability
+	State                      NmState
+	Connectivity               NmConnectivity
+	ConnectivityCheckAvailable bool
+	ConnectivityCheckEnabled   bool
+	GlobalDnsConfiguration     map[string]dbus.Variant
+}
+
 type NetworkManager interface {
 	/* METHODS */
 
@@ -66,24 +95,31 @@ type NetworkManager interface {
 	// (0x02) means to update DNS configuration, which usually involves writing /etc/resolv.conf anew.
 	// (0x04) means to restart the DNS plugin. This is for example useful when using dnsmasq plugin, which uses additional configuration in /etc/NetworkManager/dnsmasq.d. If you edit those files, you can restart the DNS plugin. This action shortly interrupts name resolution. Note that flags may affect each other. For example, restarting the DNS plugin (0x04) implicitly updates DNS too (0x02). Or when reloading the configuration (0x01), changes to DNS setting also cause a DNS update (0x02). However, (0x01) does not involve restarting the DNS plugin (0x04) or update resolv.conf (0x02), unless the DNS related configuration changes in NetworkManager.conf.
 	Reload(flags uint32) error
+	ReloadContext(ctx context.Context, flags uint32) error
 
 	// Get the list of realized network devices.
 	GetDevices() ([]Device, error)
+	GetDevicesContext(ctx context.Context) ([]Device, error)
 
 	// Get the list of all network devices.
 	GetAllDevices() ([]Device, error)
+	GetAllDevicesContext(ctx context.Context) ([]Device, error)
 
 	// Return the object path of the network device referenced by its IP interface name. Note that some devices (usually modems) only have an IP interface name when they are connected.
 	GetDeviceByIpIface(interfaceId string) (Device, error)
+	GetDeviceByIpIfaceContext(ctx context.Context, interfaceId string) (Device, error)
 
-	// Activate a connection using the supplied device.
+	// Activate a connection using the supplied device. The device may be nil to let NetworkManager pick it, which is how software devices such as bonds are created.
 	ActivateConnection(connection Connection, device Device) (ActiveConnection, error)
+	ActivateConnectionContext(ctx context.Context, connection Connection, device Device) (ActiveConnection, error)
 
 	// Adds a new connection using the given details (if any) as a template (automatically filling in missing settings with the capabilities of the given device), then activate the new connection. Cannot be used for VPN connections at this time.
 	AddAndActivateConnection(connection map[string]map[string]interface{}, device Device) (ActiveConnection, error)
+	AddAndActivateConnectionContext(ctx context.Context, connection map[string]map[string]interface{}, device Device) (ActiveConnection, error)
 
 	// ActivateWirelessConnection requests activating access point to network device
 	ActivateWirelessConnection(connection Connection, device Device, accessPoint AccessPoint) (ActiveConnection, error)
+	ActivateWirelessConnectionContext(ctx context.Context, connection Connection, device Device, accessPoint AccessPoint) (ActiveConnection, error)
 
 	// AddAndActivateWirelessConnection adds a new connection profile to the network device it has been
 	// passed. It then activates the connection to the passed access point. The first parameter contains
@@ -96,23 +132,53 @@ type NetworkManager interface {
 	// connection["802-11-wireless-security"]["key-mgmt"] = "wpa-psk"
 	// connection["802-11-wireless-security"]["psk"] = password
 	AddAndActivateWirelessConnection(connection map[string]map[string]interface{}, device Device, accessPoint AccessPoint) (ActiveConnection, error)
+	AddAndActivateWirelessConnectionContext(ctx context.Context, connection map[string]map[string]interface{}, device Device, accessPoint AccessPoint) (ActiveConnection, error)
+
+	// Block until the active connection is activated, following its StateChanged signal. A *ActivationError carrying the NetworkManager reasons is returned when the connection gets deactivated instead, and the context error when ctx is done first.
+	WaitForActivation(ctx context.Context, ac ActiveConnection) error
+
+	// Activate a connection and wait for the activation to complete, as ActivateConnection followed by WaitForActivation. The active connection is returned even when the activation fails.
+	ActivateAndWait(ctx context.Context, connection Connection, device Device) (ActiveConnection, error)
+
+	// Connect the device to the Wi-Fi network with this SSID and wait for the activation to complete. The access point with the strongest signal is used, after a scan probing for the SSID when none is visible, and the key management (open, OWE, WEP, WPA-PSK, SAE or 802.1X) is derived from its flags. A saved connection profile of the SSID is reused, with its secret replaced by the passphrase when one is given; otherwise a new profile is added. ErrAccessPointNotFound is returned when the device sees no access point of the SSID. opts may be nil.
+	ConnectWifi(ctx context.Context, device DeviceWireless, ssid string, passphrase string, opts *ConnectWifiOptions) (ActiveConnection, error)
+
+	// Host a Wi-Fi network with this SSID on the device, sharing the connectivity of the host over IPv4, and wait for its activation to complete. The network is protected with WPA2-Personal when a passphrase is given, and open otherwise. band is one of the SettingWirelessBand constants and channel a channel of that band, or "" and 0 to let NetworkManager pick them. The connection profile of the hotspot is not saved to disk; stopping the hotspot deletes it and reactivates the connection that was active on the device before. When the activation fails the hotspot is stopped, without using ctx, and a failure to stop it is reported with the activation error.
+	StartHotspot(ctx context.Context, device DeviceWireless, ssid string, passphrase string, band string, channel uint32) (Hotspot, error)
+
+	// Add the connection profile of a bond, bridge or team master interface, given its *SettingBond, *SettingBridge or *SettingTeam setting and optional further settings such as SettingIP4Config, add a profile enslaving each wired device to it, then activate them all and wait for the activations to complete. The active connection of the master is returned. When a profile cannot be added or activated, the profiles are deleted, without using ctx, and a failure to delete them is reported with the error.
+	AddAndActivateMaster(ctx context.Context, interfaceName string, master Setting, slaves []DeviceWired, settings ...Setting) (ActiveConnection, error)
+
+	// Add the connection profile of a VLAN on top of the parent device, named like parent.id, then activate it and wait for the activation to complete. The settings are added to the profile, replacing the default ones of the same name, e.g. a SettingConnection to choose another interface name. When the activation fails, the profile is deleted, without using ctx, and a failure to delete it is reported with the error.
+	AddAndActivateVlan(ctx context.Context, parent Device, id uint32, settings ...Setting) (ActiveConnection, error)
+
+	// Add the connection profile of a VXLAN with this VNI, named vxlan<id> and sending to the remote unicast or multicast IP address through the parent device, then activate it and wait for the activation to complete. parent may be nil to let the routing pick the outgoing device. The settings are added to the profile, replacing the default ones of the same name. When the activation fails, the profile is deleted, without using ctx, and a failure to delete it is reported with the error.
+	AddAndActivateVxlan(ctx context.Context, parent Device, id uint32, remote string, settings ...Setting) (ActiveConnection, error)
+
+	// Add the three connection profiles of an Open vSwitch bridge, a port named port-<interfaceName> and an internal interface, bound together by their connection.master, then activate them and wait for the activations to complete. A SettingOvsBridge or SettingOvsPort in settings replaces the default one of the bridge or port; the other settings, e.g. SettingIP4Config, are added to the profile of the interface. The active connection of the interface is returned. When a profile cannot be added or activated, the three profiles are deleted, without using ctx, and a failure to delete them is reported with the error.
+	AddAndActivateOvsBridge(ctx context.Context, bridgeName string, interfaceName string, settings ...Setting) (ActiveConnection, error)
 
 	// Deactivate an active connection.
 	DeactivateConnection(connection ActiveConnection) error
+	DeactivateConnectionContext(ctx context.Context, connection ActiveConnection) error
 
 	// Control the NetworkManager daemon's sleep state. When asleep, all interfaces that it manages are deactivated. When awake, devices are available to be activated. This command should not be called directly by users or clients; it is intended for system suspend/resume tracking.
 	// sleepnWake: Indicates whether the NetworkManager daemon should sleep or wake.
 	Sleep(sleepNWake bool) error
+	SleepContext(ctx context.Context, sleepNWake bool) error
 
 	// Control whether overall networking is enabled or disabled. When disabled, all interfaces that NM manages are deactivated. When enabled, all managed interfaces are re-enabled and available to be activated. This command should be used by clients that provide to users the ability to enable/disable all networking.
 	// enableNDisable: If FALSE, indicates that all networking should be disabled. If TRUE, indicates that NetworkManager should begin managing network devices.
 	Enable(enableNDisable bool) error
+	EnableContext(ctx context.Context, enableNDisable bool) error
 
 	// Re-check the network connectivity state.
 	CheckConnectivity() error
+	CheckConnectivityContext(ctx context.Context) error
 
 	// The overall networking state as determined by the NetworkManager daemon, based on the state of network devices under its management.
 	State() (NmState, error)
+	StateContext(ctx context.Context) (NmState, error)
 
 	// Create a checkpoint of the current networking configuration for given interfaces. If rollback_timeout is not zero, a rollback is automatically performed after the given timeout.
 	// devices: A list of device paths for which a checkpoint should be created. An empty list means all devices.
@@ -120,95 +186,149 @@ type NetworkManager interface {
 	// flags: Flags for the creation.
 	// returns: On success, the new checkpoint.
 	CheckpointCreate(devices []Device, rollbackTimeout uint32, flags []NmCheckpointCreateFlags) (Checkpoint, error)
+	CheckpointCreateContext(ctx context.Context, devices []Device, rollbackTimeout uint32, flags []NmCheckpointCreateFlags) (Checkpoint, error)
 
 	// Destroy a previously created checkpoint.
 	// checkpoint: The checkpoint to be destroyed. Set to empty to cancel all pending checkpoints.
 	CheckpointDestroy(checkpoint Checkpoint) error
+	CheckpointDestroyContext(ctx context.Context, checkpoint Checkpoint) error
+
+	// Rollback a checkpoint before the timeout is reached.
+	// checkpoint: The checkpoint to be rolled back.
+	// returns: On return, a dictionary of devices and results. Devices are represented by their original D-Bus path; each result is a NmRollbackResult.
+	CheckpointRollback(checkpoint Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error)
+	CheckpointRollbackContext(ctx context.Context, checkpoint Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error)
 
 	// Reset the timeout for rollback for the checkpoint.
 	// Since: 1.12
 	// addTimeout: number of seconds from ~now~ in which the timeout will expire. Set to 0 to disable the timeout. Note that the added seconds start counting from now, not "Created" timestamp or the previous expiration time. Note that the "Created" property of the checkpoint will stay unchanged by this call. However, the "RollbackTimeout" will be recalculated to give the approximate new expiration time. The new "RollbackTimeout" property will be approximate up to one second precision, which is the accuracy of the property.
 	CheckpointAdjustRollbackTimeout(checkpoint Checkpoint, addTimeout uint32) error
+	CheckpointAdjustRollbackTimeoutContext(ctx context.Context, checkpoint Checkpoint, addTimeout uint32) error
+
+	// Run fn inside a checkpoint of the given devices, all of them when empty. The checkpoint is rolled back when fn returns an error or panics, and destroyed when it succeeds; a panic of fn is recovered and returned as an error, along with the rollback failures. The rollback and the destruction do not use ctx, so that they still happen when ctx is done. While fn runs, the rollback timeout (in seconds, zero for infinite) is extended periodically, so that NetworkManager only rolls back on its own when this process stops, e.g. when fn cut the network it was reached through. When ctx is done the timeout is no longer extended.
+	// The checkpoint is created with NmCheckpointCreateFlagsDeleteNewConnections and NmCheckpointCreateFlagsDisconnectNewDevices so that the rollback also undoes the connections and devices fn added.
+	WithCheckpoint(ctx context.Context, devices []Device, rollbackTimeout uint32, fn func() error) error
 
 	/* PROPERTIES */
 
 	// The list of realized network devices. Realized devices are those which have backing resources (eg from the kernel or a management daemon like ModemManager, teamd, etc).
 	GetPropertyDevices() ([]Device, error)
+	GetPropertyDevicesContext(ctx context.Context) ([]Device, error)
 
 	// The list of both realized and un-realized network devices. Un-realized devices are software devices which do not yet have backing resources, but for which backing resources can be created if the device is activated.
 	GetPropertyAllDevices() ([]Device, error)
+	GetPropertyAllDevicesContext(ctx context.Context) ([]Device, error)
 
 	// The list of active checkpoints.
 	GetPropertyCheckpoints() ([]Checkpoint, error)
+	GetPropertyCheckpointsContext(ctx context.Context) ([]Checkpoint, error)
 
 	// Indicates if overall networking is currently enabled or not. See the Enable() method.
 	GetPropertyNetworkingEnabled() (bool, error)
+	GetPropertyNetworkingEnabledContext(ctx context.Context) (bool, error)
 
 	// Indicates if wireless is currently enabled or not.
 	GetPropertyWirelessEnabled() (bool, error)
+	GetPropertyWirelessEnabledContext(ctx context.Context) (bool, error)
 
 	// Indicates if the wireless hardware is currently enabled, i.e. the state of the RF kill switch.
 	GetPropertyWirelessHardwareEnabled() (bool, error)
+	GetPropertyWirelessHardwareEnabledContext(ctx context.Context) (bool, error)
 
 	// Indicates if mobile broadband devices are currently enabled or not.
 	GetPropertyWwanEnabled() (bool, error)
+	GetPropertyWwanEnabledContext(ctx context.Context) (bool, error)
+
+	// Enable or disable mobile broadband devices.
+	SetPropertyWwanEnabled(enabled bool) error
+	SetPropertyWwanEnabledContext(ctx context.Context, enabled bool) error
 
 	// Indicates if the mobile broadband hardware is currently enabled, i.e. the state of the RF kill switch.
 	GetPropertyWwanHardwareEnabled() (bool, error)
+	GetPropertyWwanHardwareEnabledContext(ctx context.Context) (bool, error)
 
 	// Indicates if WiMAX devices are currently enabled or not.
 	GetPropertyWimaxEnabled() (bool, error)
+	GetPropertyWimaxEnabledContext(ctx context.Context) (bool, error)
 
 	// Indicates if the WiMAX hardware is currently enabled, i.e. the state of the RF kill switch.
 	GetPropertyWimaxHardwareEnabled() (bool, error)
+	GetPropertyWimaxHardwareEnabledContext(ctx context.Context) (bool, error)
 
 	// List of active connection object paths.
 	GetPropertyActiveConnections() ([]ActiveConnection, error)
+	GetPropertyActiveConnectionsContext(ctx context.Context) ([]ActiveConnection, error)
 
 	// The object path of the "primary" active connection being used to access the network. In particular, if there is no VPN active, or the VPN does not have the default route, then this indicates the connection that has the default route. If there is a VPN active with the default route, then this indicates the connection that contains the route to the VPN endpoint.
 	GetPropertyPrimaryConnection() (Connection, error)
+	GetPropertyPrimaryConnectionContext(ctx context.Context) (Connection, error)
 
 	// The connection type of the "primary" active connection being used to access the network. This is the same as the Type property on the object indicated by PrimaryConnection.
 	GetPropertyPrimaryConnectionType() (string, error)
+	GetPropertyPrimaryConnectionTypeContext(ctx context.Context) (string, error)
 
 	// Indicates whether the connectivity is metered. This is equivalent to the metered property of the device associated with the primary connection.
 	GetPropertyMetered() (NmMetered, error)
+	GetPropertyMeteredContext(ctx context.Context) (NmMetered, error)
 
 	// The object path of an active connection that is currently being activated and which is expected to become the new PrimaryConnection when it finishes activating.
 	GetPropertyActivatingConnection() (ActiveConnection, error)
+	GetPropertyActivatingConnectionContext(ctx context.Context) (ActiveConnection, error)
 
 	// Indicates whether NM is still starting up; this becomes FALSE when NM has finished attempting to activate every connection that it might be able to activate at startup.
 	GetPropertyStartup() (bool, error)
+	GetPropertyStartupContext(ctx context.Context) (bool, error)
 
 	// NetworkManager version.
 	GetPropertyVersion() (string, error)
+	GetPropertyVersionContext(ctx context.Context) (string, error)
 
 	// The current set of capabilities. See NMCapability for currently defined capability numbers. The array is guaranteed to be sorted in ascending order without duplicates.
 	GetPropertyCapabilities() ([]NmCapability, error)
+	GetPropertyCapabilitiesContext(ctx context.Context) ([]NmCapability, error)
 
 	// The overall state of the NetworkManager daemon.
 	// This takes state of all active connections and the connectivity state into account to produce a single indicator of the network accessibility status.
 	// The graphical shells may use this property to provide network connection status indication and applications may use this to check if Internet connection is accessible. Shell that is able to cope with captive portals should use the "Connectivity" property to decide whether to present a captive portal authentication dialog.
 	GetPropertyState() (NmState, error)
+	GetPropertyStateContext(ctx context.Context) (NmState, error)
 
 	// The result of the last connectivity check. The connectivity check is triggered automatically when a default connection becomes available, periodically and by calling a CheckConnectivity() method.
 	// This property is in general useful for the graphical shell to determine whether the Internet access is being hijacked by an authentication gateway (a "captive portal"). In such case it would typically present a web browser window to give the user a chance to authenticate and call CheckConnectivity() when the user submits a form or dismisses the window.
 	// To determine the whether the user is able to access the Internet without dealing with captive portals (e.g. to provide a network connection indicator or disable controls that require Internet access), the "State" property is more suitable.
 	GetPropertyConnectivity() (NmConnectivity, error)
+	GetPropertyConnectivityContext(ctx context.Context) (NmConnectivity, error)
 
 	// Indicates whether connectivity checking service has been configured. This may return true even if the service is not currently enabled.
 	// This is primarily intended for use in a privacy control panel, as a way to determine whether to show an option to enable/disable the feature.
 	GetPropertyConnectivityCheckAvailable() (bool, error)
+	GetPropertyConnectivityCheckAvailableContext(ctx context.Context) (bool, error)
 
 	// Indicates whether connectivity checking is enabled. This property can also be written to to disable connectivity checking (as a privacy control panel might want to do).
 	GetPropertyConnectivityCheckEnabled() (bool, error)
+	GetPropertyConnectivityCheckEnabledContext(ctx context.Context) (bool, error)
 
 	// Dictionary of global DNS settings where the key is one of "searches", "options" and "domains". The values for the "searches" and "options" keys are string arrays describing the list of search domains and resolver options, respectively. The value of the "domains" key is a second-level dictionary, where each key is a domain name, and each key's value is a third-level dictionary with the keys "servers" and "options". "servers" is a string array of DNS servers, "options" is a string array of domain-specific options.
 	//GetPropertyGlobalDnsConfiguration() []interface{}
 
+	// Read all the properties at once with org.freedesktop.DBus.Properties.GetAll.
+	GetProperties() (*NetworkManagerProperties, error)
+	GetPropertiesContext(ctx context.Context) (*NetworkManagerProperties, error)
+
 	Subscribe() <-chan *dbus.Signal
 	Unsubscribe()
 
+	// Subscribe to the signals of the NetworkManager object tree and receive them decoded as typed events. When paths are given, only the signals emitted by these objects are delivered.
+	SubscribeEvents(paths ...dbus.ObjectPath) (EventSubscription, error)
+	SubscribeEventsContext(ctx context.Context, paths ...dbus.ObjectPath) (EventSubscription, error)
+
+	// Load the properties of every NetworkManager object with org.freedesktop.DBus.ObjectManager.GetManagedObjects and keep them up to date from the InterfacesAdded, InterfacesRemoved and PropertiesChanged signals. Until the returned cache is closed, the GetProperty* methods of every object using the same D-Bus connection are served from memory. Only one cache can be enabled per D-Bus connection, ErrCacheEnabled is returned otherwise.
+	EnableCache() (Cache, error)
+	EnableCacheContext(ctx context.Context) (Cache, error)
+
+	// WithoutCache returns a copy of the NetworkManager object whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() NetworkManager
+
 	MarshalJSON() ([]byte, error)
 }
 
@@ -217,19 +337,41 @@ func NewNetworkManager() (NetworkManager, error) {
 	return &nm, nm.init(NetworkManagerInterface, NetworkManagerObjectPath)
 }
 
+// NewNetworkManagerWithConn creates a NetworkManager using the given D-Bus
+// connection instead of the system bus. Every object obtained through it
+// (devices, connections, active connections, ...) shares that connection.
+func NewNetworkManagerWithConn(conn *dbus.Conn) (NetworkManager, error) {
+	var nm networkManager
+	return &nm, nm.initWithConn(conn, NetworkManagerInterface, NetworkManagerObjectPath)
+}
+
 type networkManager struct {
 	dbusBase
 
 	sigChan chan *dbus.Signal
 }
 
+func (nm *networkManager) WithoutCache() NetworkManager {
+	copied := *nm
+	copied.uncached = true
+	return &copied
+}
+
 func (nm *networkManager) Reload(flags uint32) error {
-	return nm.call(NetworkManagerReload)
+	return nm.ReloadContext(context.Background(), flags)
+}
+
+func (nm *networkManager) ReloadContext(ctx context.Context, flags uint32) error {
+	return nm.call(ctx, NetworkManagerReload, flags)
 }
 
 func (nm *networkManager) GetDevices() (devices []Device, err error) {
+	return nm.GetDevicesContext(context.Background())
+}
+
+func (nm *networkManager) GetDevicesContext(ctx context.Context) (devices []Device, err error) {
 	var devicePaths []dbus.ObjectPath
-	err = nm.callWithReturn(&devicePaths, NetworkManagerGetDevices)
+	err = nm.callWithReturn(ctx, &devicePaths, NetworkManagerGetDevices)
 	if err != nil {
 		return
 	}
@@ -237,7 +379,7 @@ func (nm *networkManager) GetDevices() (devices []Device, err error) {
 	devices = make([]Device, len(devicePaths))
 
 	for i, path := range devicePaths {
-		devices[i], err = DeviceFactory(path)
+		devices[i], err = DeviceFactoryWithConnContext(ctx, nm.conn, path)
 		if err != nil {
 			return
 		}
@@ -247,9 +389,13 @@ func (nm *networkManager) GetDevices() (devices []Device, err error) {
 }
 
 func (nm *networkManager) GetAllDevices() (devices []Device, err error) {
+	return nm.GetAllDevicesContext(context.Background())
+}
+
+func (nm *networkManager) GetAllDevicesContext(ctx context.Context) (devices []Device, err error) {
 	var devicePaths []dbus.ObjectPath
 
-	err = nm.callWithReturn(&devicePaths, NetworkManagerGetAllDevices)
+	err = nm.callWithReturn(ctx, &devicePaths, NetworkManagerGetAllDevices)
 	if err != nil {
 		return
 	}
@@ -257,7 +403,7 @@ func (nm *networkManager) GetAllDevices() (devices []Device, err error) {
 	devices = make([]Device, len(devicePaths))
 
 	for i, path := range devicePaths {
-		devices[i], err = DeviceFactory(path)
+		devices[i], err = DeviceFactoryWithConnContext(ctx, nm.conn, path)
 		if err != nil {
 			return
 		}
@@ -267,14 +413,18 @@ func (nm *networkManager) GetAllDevices() (devices []Device, err error) {
 }
 
 func (nm *networkManager) GetDeviceByIpIface(interfaceId string) (device Device, err error) {
+	return nm.GetDeviceByIpIfaceContext(context.Background(), interfaceId)
+}
+
+func (nm *networkManager) GetDeviceByIpIfaceContext(ctx context.Context, interfaceId string) (device Device, err error) {
 	var devicePath dbus.ObjectPath
 
-	err = nm.callWithReturn(&devicePath, NetworkManagerGetDeviceByIpIface, interfaceId)
+	err = nm.callWithReturn(ctx, &devicePath, NetworkManagerGetDeviceByIpIface, interfaceId)
 	if err != nil {
 		return
 	}
 
-	device, err = DeviceFactory(devicePath)
+	device, err = DeviceFactoryWithConnContext(ctx, nm.conn, devicePath)
 	if err != nil {
 		return
 	}
@@ -283,13 +433,22 @@ func (nm *networkManager) GetDeviceByIpIface(interfaceId string) (device Device,
 }
 
 func (nm *networkManager) ActivateConnection(c Connection, d Device) (ac ActiveConnection, err error) {
+	return nm.ActivateConnectionContext(context.Background(), c, d)
+}
+
+func (nm *networkManager) ActivateConnectionContext(ctx context.Context, c Connection, d Device) (ac ActiveConnection, err error) {
 	var opath dbus.ObjectPath
-	err = nm.callWithReturn(&opath, NetworkManagerActivateConnection, c.GetPath(), d.GetPath(), dbus.ObjectPath("/"))
+	devicePath := dbus.ObjectPath("/")
+	if d != nil {
+		devicePath = d.GetPath()
+	}
+
+	err = nm.callWithReturn(ctx, &opath, NetworkManagerActivateConnection, c.GetPath(), devicePath, dbus.ObjectPath("/"))
 	if err != nil {
 		return
 	}
 
-	ac, err = NewActiveConnection(opath)
+	ac, err = NewActiveConnectionWithConn(nm.conn, opath)
 	if err != nil {
 		return
 	}
@@ -298,15 +457,19 @@ func (nm *networkManager) ActivateConnection(c Connection, d Device) (ac ActiveC
 }
 
 func (nm *networkManager) AddAndActivateConnection(connection map[string]map[string]interface{}, d Device) (ac ActiveConnection, err error) {
+	return nm.AddAndActivateConnectionContext(context.Background(), connection, d)
+}
+
+func (nm *networkManager) AddAndActivateConnectionContext(ctx context.Context, connection map[string]map[string]interface{}, d Device) (ac ActiveConnection, err error) {
 	var opath1 dbus.ObjectPath
 	var opath2 dbus.ObjectPath
 
-	err = nm.callWithReturn2(&opath1, &opath2, NetworkManagerAddAndActivateConnection, connection, d.GetPath())
+	err = nm.callWithReturn2(ctx, &opath1, &opath2, NetworkManagerAddAndActivateConnection, connection, d.GetPath(), dbus.ObjectPath("/"))
 	if err != nil {
 		return
 	}
 
-	ac, err = NewActiveConnection(opath2)
+	ac, err = NewActiveConnectionWithConn(nm.conn, opath2)
 	if err != nil {
 		return
 	}
@@ -315,13 +478,17 @@ func (nm *networkManager) AddAndActivateConnection(connection map[string]map[str
 }
 
 func (nm *networkManager) ActivateWirelessConnection(c Connection, d Device, ap AccessPoint) (ac ActiveConnection, err error) {
+	return nm.ActivateWirelessConnectionContext(context.Background(), c, d, ap)
+}
+
+func (nm *networkManager) ActivateWirelessConnectionContext(ctx context.Context, c Connection, d Device, ap AccessPoint) (ac ActiveConnection, err error) {
 	var opath dbus.ObjectPath
-	err = nm.callWithReturn(&opath, NetworkManagerActivateConnection, c.GetPath(), d.GetPath(), ap.GetPath())
+	err = nm.callWithReturn(ctx, &opath, NetworkManagerActivateConnection, c.GetPath(), d.GetPath(), ap.GetPath())
 	if err != nil {
 		return nil, err
 	}
 
-	ac, err = NewActiveConnection(opath)
+	ac, err = NewActiveConnectionWithConn(nm.conn, opath)
 	if err != nil {
 		return nil, err
 	}
@@ -330,15 +497,19 @@ func (nm *networkManager) ActivateWirelessConnection(c Connection, d Device, ap
 }
 
 func (nm *networkManager) AddAndActivateWirelessConnection(connection map[string]map[string]interface{}, d Device, ap AccessPoint) (ac ActiveConnection, err error) {
+	return nm.AddAndActivateWirelessConnectionContext(context.Background(), connection, d, ap)
+}
+
+func (nm *networkManager) AddAndActivateWirelessConnectionContext(ctx context.Context, connection map[string]map[string]interface{}, d Device, ap AccessPoint) (ac ActiveConnection, err error) {
 	var opath1 dbus.ObjectPath
 	var opath2 dbus.ObjectPath
 
-	err = nm.callWithReturn2(&opath1, &opath2, NetworkManagerAddAndActivateConnection, connection, d.GetPath(), ap.GetPath())
+	err = nm.callWithReturn2(ctx, &opath1, &opath2, NetworkManagerAddAndActivateConnection, connection, d.GetPath(), ap.GetPath())
 	if err != nil {
 		return
 	}
 
-	ac, err = NewActiveConnection(opath2)
+	ac, err = NewActiveConnectionWithConn(nm.conn, opath2)
 	if err != nil {
 		return
 	}
@@ -346,59 +517,124 @@ func (nm *networkManager) AddAndActivateWirelessConnection(connection map[string
 }
 
 func (nm *networkManager) DeactivateConnection(c ActiveConnection) error {
-	return nm.call(NetworkManagerDeactivateConnection, c.GetPath())
+	return nm.DeactivateConnectionContext(context.Background(), c)
+}
+
+func (nm *networkManager) DeactivateConnectionContext(ctx context.Context, c ActiveConnection) error {
+	return nm.call(ctx, NetworkManagerDeactivateConnection, c.GetPath())
 }
 
 func (nm *networkManager) Sleep(sleepNWake bool) error {
-	return nm.call(NetworkManagerSleep, sleepNWake)
+	return nm.SleepContext(context.Background(), sleepNWake)
+}
+
+func (nm *networkManager) SleepContext(ctx context.Context, sleepNWake bool) error {
+	return nm.call(ctx, NetworkManagerSleep, sleepNWake)
 }
 
 func (nm *networkManager) Enable(enableNDisable bool) error {
-	return nm.call(NetworkManagerEnable, enableNDisable)
+	return nm.EnableContext(context.Background(), enableNDisable)
+}
+
+func (nm *networkManager) EnableContext(ctx context.Context, enableNDisable bool) error {
+	return nm.call(ctx, NetworkManagerEnable, enableNDisable)
 }
 
 func (nm *networkManager) CheckConnectivity() error {
-	return nm.call(NetworkManagerCheckConnectivity)
+	return nm.CheckConnectivityContext(context.Background())
+}
+
+func (nm *networkManager) CheckConnectivityContext(ctx context.Context) error {
+	return nm.call(ctx, NetworkManagerCheckConnectivity)
 }
 
 func (nm *networkManager) State() (state NmState, err error) {
-	err = nm.callWithReturn(&state, NetworkManagerState)
+	return nm.StateContext(context.Background())
+}
+
+func (nm *networkManager) StateContext(ctx context.Context) (state NmState, err error) {
+	err = nm.callWithReturn(ctx, &state, NetworkManagerState)
 	return
 }
 
 func (nm *networkManager) CheckpointCreate(devices []Device, rollbackTimeout uint32, flags []NmCheckpointCreateFlags) (cp Checkpoint, err error) {
-	intFlags := 0
+	return nm.CheckpointCreateContext(context.Background(), devices, rollbackTimeout, flags)
+}
+
+func (nm *networkManager) CheckpointCreateContext(ctx context.Context, devices []Device, rollbackTimeout uint32, flags []NmCheckpointCreateFlags) (cp Checkpoint, err error) {
+	devicePaths := make([]dbus.ObjectPath, len(devices))
+	for i, device := range devices {
+		devicePaths[i] = device.GetPath()
+	}
+
+	var intFlags uint32
 	for _, flag := range flags {
-		intFlags |= int(flag)
+		intFlags |= uint32(flag)
 	}
 
-	err = nm.callWithReturn(&cp, NetworkManagerCheckpointCreate, rollbackTimeout, intFlags)
-	return
+	var checkpointPath dbus.ObjectPath
+	err = nm.callWithReturn(ctx, &checkpointPath, NetworkManagerCheckpointCreate, devicePaths, rollbackTimeout, intFlags)
+	if err != nil {
+		return
+	}
+
+	return NewCheckpointWithConn(nm.conn, checkpointPath)
 }
 
 func (nm *networkManager) CheckpointDestroy(checkpoint Checkpoint) error {
+	return nm.CheckpointDestroyContext(context.Background(), checkpoint)
+}
+
+func (nm *networkManager) CheckpointDestroyContext(ctx context.Context, checkpoint Checkpoint) error {
 	if checkpoint == nil {
-		return nm.call(NetworkManagerCheckpointDestroy)
+		return nm.call(ctx, NetworkManagerCheckpointDestroy, dbus.ObjectPath("/"))
 	} else {
-		return nm.call(NetworkManagerCheckpointDestroy, checkpoint.GetPath())
+		return nm.call(ctx, NetworkManagerCheckpointDestroy, checkpoint.GetPath())
 	}
 }
 
+func (nm *networkManager) CheckpointRollback(checkpoint Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error) {
+	return nm.CheckpointRollbackContext(context.Background(), checkpoint)
+}
+
+func (nm *networkManager) CheckpointRollbackContext(ctx context.Context, checkpoint Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error) {
+	var results map[string]uint32
+	err := nm.callWithReturn(ctx, &results, NetworkManagerCheckpointRollback, checkpoint.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	rv := make(map[dbus.ObjectPath]NmRollbackResult, len(results))
+	for path, result := range results {
+		rv[dbus.ObjectPath(path)] = NmRollbackResult(result)
+	}
+
+	return rv, nil
+}
+
 func (nm *networkManager) CheckpointAdjustRollbackTimeout(checkpoint Checkpoint, addTimeout uint32) error {
-	return nm.call(NetworkManagerCheckpointAdjustRollbackTimeout, checkpoint, addTimeout)
+	return nm.CheckpointAdjustRollbackTimeoutContext(context.Background(), checkpoint, addTimeout)
+}
+
+func (nm *networkManager) CheckpointAdjustRollbackTimeoutContext(ctx context.Context, checkpoint Checkpoint, addTimeout uint32) error {
+	return nm.call(ctx, NetworkManagerCheckpointAdjustRollbackTimeout, checkpoint.GetPath(), addTimeout)
 }
 
 /* PROPERTIES */
 
 func (nm *networkManager) GetPropertyDevices() ([]Device, error) {
-	devicesPaths, err := nm.getSliceObjectProperty(NetworkManagerPropertyDevices)
+	return nm.GetPropertyDevicesContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyDevicesContext(ctx context.Context) ([]Device, error) {
+	devicesPaths, err := nm.getSliceObjectProperty(ctx, NetworkManagerPropertyDevices)
 	if err != nil {
 		return nil, err
 	}
 
 	devices := make([]Device, len(devicesPaths))
 	for i, path := range devicesPaths {
-		devices[i], err = NewDevice(path)
+		devices[i], err = NewDeviceWithConn(nm.conn, path)
 		if err != nil {
 			return devices, err
 		}
@@ -408,14 +644,18 @@ func (nm *networkManager) GetPropertyDevices() ([]Device, error) {
 }
 
 func (nm *networkManager) GetPropertyAllDevices() ([]Device, error) {
-	devicesPaths, err := nm.getSliceObjectProperty(NetworkManagerPropertyAllDevices)
+	return nm.GetPropertyAllDevicesContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyAllDevicesContext(ctx context.Context) ([]Device, error) {
+	devicesPaths, err := nm.getSliceObjectProperty(ctx, NetworkManagerPropertyAllDevices)
 	if err != nil {
 		return nil, err
 	}
 
 	devices := make([]Device, len(devicesPaths))
 	for i, path := range devicesPaths {
-		devices[i], err = NewDevice(path)
+		devices[i], err = NewDeviceWithConn(nm.conn, path)
 		if err != nil {
 			return devices, err
 		}
@@ -425,14 +665,18 @@ func (nm *networkManager) GetPropertyAllDevices() ([]Device, error) {
 }
 
 func (nm *networkManager) GetPropertyCheckpoints() ([]Checkpoint, error) {
-	checkpointsPaths, err := nm.getSliceObjectProperty(NetworkManagerPropertyAllDevices)
+	return nm.GetPropertyCheckpointsContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyCheckpointsContext(ctx context.Context) ([]Checkpoint, error) {
+	checkpointsPaths, err := nm.getSliceObjectProperty(ctx, NetworkManagerPropertyCheckpoints)
 	if err != nil {
 		return nil, err
 	}
 
 	checkpoints := make([]Checkpoint, len(checkpointsPaths))
 	for i, path := range checkpointsPaths {
-		checkpoints[i], err = NewCheckpoint(path)
+		checkpoints[i], err = NewCheckpointWithConn(nm.conn, path)
 		if err != nil {
 			return checkpoints, err
 		}
@@ -442,42 +686,82 @@ func (nm *networkManager) GetPropertyCheckpoints() ([]Checkpoint, error) {
 }
 
 func (nm *networkManager) GetPropertyNetworkingEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyNetworkingEnabled)
+	return nm.GetPropertyNetworkingEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyNetworkingEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyNetworkingEnabled)
 }
 
 func (nm *networkManager) GetPropertyWirelessEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWirelessEnabled)
+	return nm.GetPropertyWirelessEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWirelessEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWirelessEnabled)
 }
 
 func (nm *networkManager) GetPropertyWirelessHardwareEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWirelessHardwareEnabled)
+	return nm.GetPropertyWirelessHardwareEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWirelessHardwareEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWirelessHardwareEnabled)
 }
 
 func (nm *networkManager) GetPropertyWwanEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWwanEnabled)
+	return nm.GetPropertyWwanEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWwanEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWwanEnabled)
+}
+
+func (nm *networkManager) SetPropertyWwanEnabled(enabled bool) error {
+	return nm.SetPropertyWwanEnabledContext(context.Background(), enabled)
+}
+
+func (nm *networkManager) SetPropertyWwanEnabledContext(ctx context.Context, enabled bool) error {
+	return nm.setProperty(ctx, NetworkManagerPropertyWwanEnabled, enabled)
 }
 
 func (nm *networkManager) GetPropertyWwanHardwareEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWwanHardwareEnabled)
+	return nm.GetPropertyWwanHardwareEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWwanHardwareEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWwanHardwareEnabled)
 }
 
 func (nm *networkManager) GetPropertyWimaxEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWimaxEnabled)
+	return nm.GetPropertyWimaxEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWimaxEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWimaxEnabled)
 }
 
 func (nm *networkManager) GetPropertyWimaxHardwareEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyWimaxHardwareEnabled)
+	return nm.GetPropertyWimaxHardwareEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyWimaxHardwareEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyWimaxHardwareEnabled)
 }
 
 func (nm *networkManager) GetPropertyActiveConnections() ([]ActiveConnection, error) {
-	acPaths, err := nm.getSliceObjectProperty(NetworkManagerPropertyActiveConnections)
+	return nm.GetPropertyActiveConnectionsContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyActiveConnectionsContext(ctx context.Context) ([]ActiveConnection, error) {
+	acPaths, err := nm.getSliceObjectProperty(ctx, NetworkManagerPropertyActiveConnections)
 	if err != nil {
 		return nil, err
 	}
 
 	ac := make([]ActiveConnection, len(acPaths))
 	for i, path := range acPaths {
-		ac[i], err = NewActiveConnection(path)
+		ac[i], err = NewActiveConnectionWithConn(nm.conn, path)
 		if err != nil {
 			return ac, err
 		}
@@ -487,56 +771,115 @@ func (nm *networkManager) GetPropertyActiveConnections() ([]ActiveConnection, er
 }
 
 func (nm *networkManager) GetPropertyPrimaryConnection() (Connection, error) {
-	connectionPath, err := nm.getObjectProperty(NetworkManagerPropertyPrimaryConnection)
+	return nm.GetPropertyPrimaryConnectionContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyPrimaryConnectionContext(ctx context.Context) (Connection, error) {
+	connectionPath, err := nm.getObjectProperty(ctx, NetworkManagerPropertyPrimaryConnection)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return NewConnection(connectionPath)
+	return NewConnectionWithConn(nm.conn, connectionPath)
 }
 
 func (nm *networkManager) GetPropertyPrimaryConnectionType() (string, error) {
-	return nm.getStringProperty(NetworkManagerPropertyPrimaryConnectionType)
+	return nm.GetPropertyPrimaryConnectionTypeContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyPrimaryConnectionTypeContext(ctx context.Context) (string, error) {
+	return nm.getStringProperty(ctx, NetworkManagerPropertyPrimaryConnectionType)
 }
 
 func (nm *networkManager) GetPropertyMetered() (NmMetered, error) {
-	v, err := nm.getUint32Property(NetworkManagerPropertyMetered)
+	return nm.GetPropertyMeteredContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyMeteredContext(ctx context.Context) (NmMetered, error) {
+	v, err := nm.getUint32Property(ctx, NetworkManagerPropertyMetered)
 	return NmMetered(v), err
 }
 
 func (nm *networkManager) GetPropertyActivatingConnection() (ActiveConnection, error) {
-	panic("implement me")
+	return nm.GetPropertyActivatingConnectionContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyActivatingConnectionContext(ctx context.Context) (ActiveConnection, error) {
+	path, err := nm.getObjectProperty(ctx, NetworkManagerPropertyActivatingConnection)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+
+	return NewActiveConnectionWithConn(nm.conn, path)
 }
 
 func (nm *networkManager) GetPropertyStartup() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyStartup)
+	return nm.GetPropertyStartupContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyStartupContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyStartup)
 }
 
 func (nm *networkManager) GetPropertyVersion() (string, error) {
-	return nm.getStringProperty(NetworkManagerPropertyVersion)
+	return nm.GetPropertyVersionContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyVersionContext(ctx context.Context) (string, error) {
+	return nm.getStringProperty(ctx, NetworkManagerPropertyVersion)
 }
 
 func (nm *networkManager) GetPropertyCapabilities() ([]NmCapability, error) {
-	panic("implement me")
+	return nm.GetPropertyCapabilitiesContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyCapabilitiesContext(ctx context.Context) ([]NmCapability, error) {
+	v, err := nm.getSliceUint32Property(ctx, NetworkManagerPropertyCapabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := make([]NmCapability, len(v))
+	for i, c := range v {
+		capabilities[i] = NmCapability(c)
+	}
+
+	return capabilities, nil
 }
 
 func (nm *networkManager) GetPropertyState() (NmState, error) {
-	v, err := nm.getUint32Property(NetworkManagerPropertyState)
+	return nm.GetPropertyStateContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyStateContext(ctx context.Context) (NmState, error) {
+	v, err := nm.getUint32Property(ctx, NetworkManagerPropertyState)
 	return NmState(v), err
 }
 
 func (nm *networkManager) GetPropertyConnectivity() (NmConnectivity, error) {
-	v, err := nm.getUint32Property(NetworkManagerPropertyConnectivity)
+	return nm.GetPropertyConnectivityContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyConnectivityContext(ctx context.Context) (NmConnectivity, error) {
+	v, err := nm.getUint32Property(ctx, NetworkManagerPropertyConnectivity)
 	return NmConnectivity(v), err
 }
 
 func (nm *networkManager) GetPropertyConnectivityCheckAvailable() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyConnectivityCheckAvailable)
+	return nm.GetPropertyConnectivityCheckAvailableContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyConnectivityCheckAvailableContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyConnectivityCheckAvailable)
 }
 
 func (nm *networkManager) GetPropertyConnectivityCheckEnabled() (bool, error) {
-	return nm.getBoolProperty(NetworkManagerPropertyConnectivityCheckEnabled)
+	return nm.GetPropertyConnectivityCheckEnabledContext(context.Background())
+}
+
+func (nm *networkManager) GetPropertyConnectivityCheckEnabledContext(ctx context.Context) (bool, error) {
+	return nm.getBoolProperty(ctx, NetworkManagerPropertyConnectivityCheckEnabled)
 }
 
 func (nm *networkManager) Subscribe() <-chan *dbus.Signal {
@@ -556,140 +899,91 @@ func (nm *networkManager) Unsubscribe() {
 	nm.sigChan = nil
 }
 
-func (nm *networkManager) MarshalJSON() ([]byte, error) {
-
-	Devices, err := nm.GetPropertyDevices()
-	if err != nil {
-		return nil, err
-	}
-
-	AllDevices, err := nm.GetPropertyAllDevices()
-	if err != nil {
-		return nil, err
-	}
-
-	Checkpoints, err := nm.GetPropertyCheckpoints()
-	if err != nil {
-		return nil, err
-	}
-
-	NetworkingEnabled, err := nm.GetPropertyNetworkingEnabled()
-	if err != nil {
-		return nil, err
-	}
-
-	WirelessEnabled, err := nm.GetPropertyWirelessEnabled()
-	if err != nil {
-		return nil, err
-	}
-
-	WirelessHardwareEnabled, err := nm.GetPropertyWirelessHardwareEnabled()
-	if err != nil {
-		return nil, err
-	}
-
-	WwanEnabled, err := nm.GetPropertyWwanEnabled()
-	if err != nil {
-		return nil, err
-	}
-
-	WwanHardwareEnabled, err := nm.GetPropertyWwanHardwareEnabled()
-	if err != nil {
-		return nil, err
-	}
-
-	WimaxEnabled, err := nm.GetPropertyWimaxEnabled()
-	if err != nil {
-		return nil, err
-	}
+func (nm *networkManager) SubscribeEvents(paths ...dbus.ObjectPath) (EventSubscription, error) {
+	return nm.SubscribeEventsContext(context.Background(), paths...)
+}
 
-	WimaxHardwareEnabled, err := nm.GetPropertyWimaxHardwareEnabled()
-	if err != nil {
-		return nil, err
-	}
+func (nm *networkManager) SubscribeEventsContext(ctx context.Context, paths ...dbus.ObjectPath) (EventSubscription, error) {
+	return newEventSubscription(ctx, nm.dbusBase, paths)
+}
 
-	ActiveConnections, err := nm.GetPropertyActiveConnections()
-	if err != nil {
-		return nil, err
-	}
+func (nm *networkManager) EnableCache() (Cache, error) {
+	return nm.EnableCacheContext(context.Background())
+}
 
-	PrimaryConnection, err := nm.GetPropertyPrimaryConnection()
-	if err != nil {
-		return nil, err
-	}
+func (nm *networkManager) EnableCacheContext(ctx context.Context) (Cache, error) {
+	return newCache(ctx, nm.conn)
+}
 
-	PrimaryConnectionType, err := nm.GetPropertyPrimaryConnectionType()
-	if err != nil {
-		return nil, err
-	}
+func (nm *networkManager) GetProperties() (*NetworkManagerProperties, error) {
+	return nm.GetPropertiesContext(context.Background())
+}
 
-	Metered, err := nm.GetPropertyMetered()
+func (nm *networkManager) GetPropertiesContext(ctx context.Context) (*NetworkManagerProperties, error) {
+	props, err := nm.getAllProperties(ctx, NetworkManagerInterface)
 	if err != nil {
 		return nil, err
 	}
 
-	ActivatingConnection, err := nm.GetPropertyActivatingConnection()
-	if err != nil {
-		return nil, err
-	}
+	var p NetworkManagerProperties
+	return &p, decodeProperties(NetworkManagerInterface, props, &p)
+}
 
-	Startup, err := nm.GetPropertyStartup()
+func (nm *networkManager) MarshalJSON() ([]byte, error) {
+	p, err := nm.GetProperties()
 	if err != nil {
 		return nil, err
 	}
 
-	Version, err := nm.GetPropertyVersion()
-	if err != nil {
-		return nil, err
+	Devices := make([]Device, len(p.Devices))
+	for i, path := range p.Devices {
+		Devices[i], _ = NewDeviceWithConn(nm.conn, path)
 	}
 
-	Capabilities, err := nm.GetPropertyCapabilities()
-	if err != nil {
-		return nil, err
+	AllDevices := make([]Device, len(p.AllDevices))
+	for i, path := range p.AllDevices {
+		AllDevices[i], _ = NewDeviceWithConn(nm.conn, path)
 	}
 
-	State, err := nm.GetPropertyState()
-	if err != nil {
-		return nil, err
+	Checkpoints := make([]Checkpoint, len(p.Checkpoints))
+	for i, path := range p.Checkpoints {
+		Checkpoints[i], _ = NewCheckpointWithConn(nm.conn, path)
 	}
 
-	Connectivity, err := nm.GetPropertyConnectivity()
-	if err != nil {
-		return nil, err
+	ActiveConnections := make([]ActiveConnection, len(p.ActiveConnections))
+	for i, path := range p.ActiveConnections {
+		ActiveConnections[i], _ = NewActiveConnectionWithConn(nm.conn, path)
 	}
 
-	ConnectivityCheckAvailable, err := nm.GetPropertyConnectivityCheckAvailable()
-	if err != nil {
-		return nil, err
-	}
+	PrimaryConnection, _ := NewConnectionWithConn(nm.conn, p.PrimaryConnection)
 
-	ConnectivityCheckEnabled, err := nm.GetPropertyConnectivityCheckEnabled()
-	if err != nil {
-		return nil, err
+	var ActivatingConnection ActiveConnection
+	if p.ActivatingConnection != "/" {
+		ActivatingConnection, _ = NewActiveConnectionWithConn(nm.conn, p.ActivatingConnection)
 	}
 
 	return json.Marshal(map[string]interface{}{
 		"Devices":                    Devices,
 		"AllDevices":                 AllDevices,
 		"Checkpoints":                Checkpoints,
-		"NetworkingEnabled":          NetworkingEnabled,
-		"WirelessEnabled":            WirelessEnabled,
-		"WirelessHardwareEnabled":    WirelessHardwareEnabled,
-		"WwanEnabled":                WwanEnabled,
-		"WwanHardwareEnabled":        WwanHardwareEnabled,
-		"WimaxEnabled":               WimaxEnabled,
-		"WimaxHardwareEnabled":       WimaxHardwareEnabled,
+		"NetworkingEnabled":          p.NetworkingEnabled,
+		"WirelessEnabled":            p.WirelessEnabled,
+		"WirelessHardwareEnabled":    p.WirelessHardwareEnabled,
+		"WwanEnabled":                p.WwanEnabled,
+		"WwanHardwareEnabled":        p.WwanHardwareEnabled,
+		"WimaxEnabled":               p.WimaxEnabled,
+		"WimaxHardwareEnabled":       p.WimaxHardwareEnabled,
 		"ActiveConnections":          ActiveConnections,
 		"PrimaryConnection":          PrimaryConnection,
-		"PrimaryConnectionType":      PrimaryConnectionType,
-		"Metered":                    Metered,
+		"PrimaryConnectionType":      p.PrimaryConnectionType,
+		"Metered":                    p.Metered,
 		"ActivatingConnection":       ActivatingConnection,
-		"Startup":                    Startup,
-		"Version":                    Version,
-		"Capabilities":               Capabilities,
-		"State":                      State,
-		"Connectivity":               Connectivity,
-		"ConnectivityCheckAvailable": ConnectivityCheckAvailable,
-		"ConnectivityCheckEnabled":   ConnectivityCheckEnabled,
+		"Startup":                    p.Startup,
+		"Version":                    p.Version,
+		"Capabilities":               p.Capabilities,
+		"State":                      p.State,
+		"Connectivity":               p.Connectivity,
+		"ConnectivityCheckAvailable": p.ConnectivityCheckAvailable,
+		"ConnectivityCheckEnabled":   p.ConnectivityCheckEnabled,
 	})
 }
@@ -0,0 +1,24 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestReload(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	if err := f.nm.Reload(0x01); err != nil {
+		t.Errorf("Reload(0x01) = %v", err)
+	}
+
+	// The fake rejects unknown flags like NetworkManager, which tells that
+	// they reached it.
+	err := f.nm.ReloadContext(context.Background(), 0x08)
+	if dbusErr, ok := err.(dbus.Error); !ok || dbusErr.Name != "org.freedesktop.NetworkManager.InvalidArguments" {
+		t.Errorf("ReloadContext(0x08) = %v, want InvalidArguments", err)
+	}
+}
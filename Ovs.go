@@ -0,0 +1,60 @@
+package gonetworkmanager
+
+import (
+	"context"
+)
+
+func (nm *networkManager) AddAndActivateOvsBridge(ctx context.Context, bridgeName string, interfaceName string, settings ...Setting) (ActiveConnection, error) {
+	portName := "port-" + interfaceName
+
+	bridge := []Setting{
+		&SettingConnection{Id: bridgeName, Type: SettingOvsBridgeSettingName, InterfaceName: bridgeName},
+		&SettingOvsBridge{},
+	}
+	port := []Setting{
+		&SettingConnection{Id: portName, Type: SettingOvsPortSettingName, InterfaceName: portName, SlaveType: SettingOvsBridgeSettingName},
+		&SettingOvsPort{},
+	}
+	iface := []Setting{
+		&SettingConnection{Id: interfaceName, Type: SettingOvsInterfaceSettingName, InterfaceName: interfaceName, SlaveType: SettingOvsPortSettingName},
+		&SettingOvsInterface{Type: SettingOvsInterfaceTypeInternal},
+	}
+
+	// The given settings come last to replace the default ones.
+	for _, setting := range settings {
+		switch setting.(type) {
+		case *SettingOvsBridge:
+			bridge = append(bridge, setting)
+		case *SettingOvsPort:
+			port = append(port, setting)
+		default:
+			iface = append(iface, setting)
+		}
+	}
+
+	bridgeSettings, err := NewConnectionSettings(bridge...)
+	if err != nil {
+		return nil, err
+	}
+	portSettings, err := NewConnectionSettings(port...)
+	if err != nil {
+		return nil, err
+	}
+	interfaceSettings, err := NewConnectionSettings(iface...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The levels are bound by the UUIDs of their masters, which the profiles
+	// of the same name cannot be confused with.
+	portSettings[SettingConnectionSettingName]["master"] = bridgeSettings[SettingConnectionSettingName]["uuid"]
+	interfaceSettings[SettingConnectionSettingName]["master"] = portSettings[SettingConnectionSettingName]["uuid"]
+
+	// The bridge and port devices only exist in Open vSwitch; NetworkManager
+	// creates the three of them.
+	acs, err := nm.addAndActivateProfiles(ctx, []ConnectionSettings{bridgeSettings, portSettings, interfaceSettings}, []Device{nil, nil, nil})
+	if err != nil {
+		return nil, err
+	}
+	return acs[2], nil
+}
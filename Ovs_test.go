@@ -0,0 +1,99 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+func TestAddAndActivateOvsBridge(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	// NetworkManager would create the devices of the three levels.
+	f.srv.AddDevice("ovsbr0", gnm.NmDeviceTypeOvsBridge)
+	f.srv.AddDevice("port-ovs0", gnm.NmDeviceTypeOvsPort)
+	iobj := f.srv.AddDevice("ovs0", gnm.NmDeviceTypeOvsInterface)
+
+	ac, err := f.nm.AddAndActivateOvsBridge(context.Background(), "ovsbr0", "ovs0",
+		&gnm.SettingOvsBridge{StpEnable: true},
+		&gnm.SettingOvsPort{VlanMode: "access", Tag: 10},
+		&gnm.SettingIP4Config{Method: gnm.SettingIP4ConfigMethodAuto})
+	if err != nil {
+		t.Fatalf("AddAndActivateOvsBridge() = %v", err)
+	}
+	if devices, err := ac.GetPropertyDevices(); err != nil || len(devices) != 1 || devices[0].GetPath() != iobj.Path() {
+		t.Errorf("GetPropertyDevices() = %v, %v, want %s", devices, err, iobj.Path())
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 3 {
+		t.Fatalf("%d profiles, want the bridge, the port and the interface", len(profiles))
+	}
+	want := []struct {
+		id, connectionType, slaveType string
+	}{
+		{"ovsbr0", gnm.SettingOvsBridgeSettingName, ""},
+		{"port-ovs0", gnm.SettingOvsPortSettingName, gnm.SettingOvsBridgeSettingName},
+		{"ovs0", gnm.SettingOvsInterfaceSettingName, gnm.SettingOvsPortSettingName},
+	}
+	connections := make([]gnm.SettingConnection, len(profiles))
+	for i, profile := range profiles {
+		if err := profile.GetSetting(&connections[i]); err != nil {
+			t.Fatal(err)
+		}
+		c := connections[i]
+		if c.Id != want[i].id || c.InterfaceName != want[i].id || c.Type != want[i].connectionType || c.SlaveType != want[i].slaveType {
+			t.Errorf("profile %d = %s %s %s %s, want %s %s %s %s", i, c.Id, c.InterfaceName, c.Type, c.SlaveType,
+				want[i].id, want[i].id, want[i].connectionType, want[i].slaveType)
+		}
+		if i > 0 && c.Master != connections[i-1].Uuid {
+			t.Errorf("master of %s = %s, want %s", c.Id, c.Master, connections[i-1].Uuid)
+		}
+	}
+
+	var bridge gnm.SettingOvsBridge
+	if err := profiles[0].GetSetting(&bridge); err != nil || !bridge.StpEnable {
+		t.Errorf("ovs-bridge = %+v, %v, want stp-enable", bridge, err)
+	}
+	var port gnm.SettingOvsPort
+	if err := profiles[1].GetSetting(&port); err != nil || port.VlanMode != "access" || port.Tag != 10 {
+		t.Errorf("ovs-port = %+v, %v, want access 10", port, err)
+	}
+	var iface gnm.SettingOvsInterface
+	if err := profiles[2].GetSetting(&iface); err != nil || iface.Type != gnm.SettingOvsInterfaceTypeInternal {
+		t.Errorf("ovs-interface = %+v, %v, want %s", iface, err, gnm.SettingOvsInterfaceTypeInternal)
+	}
+	if method := profiles[2]["ipv4"]["method"]; method != gnm.SettingIP4ConfigMethodAuto {
+		t.Errorf("ipv4.method of the interface = %v, want %s", method, gnm.SettingIP4ConfigMethodAuto)
+	}
+}
+
+func TestAddAndActivateOvsBridgeFailure(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	// The bridge and the port come up, the interface does not.
+	f.srv.SetActivationFunc(func(s *nmfake.Server, ac *nmfake.Object) {
+		if ac.Get(gnm.ActiveConnectionPropertyType).Value() == gnm.SettingOvsInterfaceSettingName {
+			s.SetActiveConnectionState(ac, gnm.NmActiveConnectionStateDeactivated, gnm.NmActiveConnectionStateReasonDeviceDisconnected)
+			return
+		}
+		nmfake.DefaultActivation(s, ac)
+	})
+
+	ac, err := f.nm.AddAndActivateOvsBridge(context.Background(), "ovsbr0", "ovs0")
+	var activationErr *gnm.ActivationError
+	if !errors.As(err, &activationErr) {
+		t.Fatalf("AddAndActivateOvsBridge() = %v, want an *ActivationError", err)
+	}
+	if ac != nil {
+		t.Errorf("AddAndActivateOvsBridge() returned %s, want no active connection", ac.GetPath())
+	}
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Errorf("%d profiles left, want none", len(profiles))
+	}
+}
@@ -0,0 +1,97 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestGetProperties(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, dobj := f.addDevice(t, "wlan0", gnm.NmDeviceTypeWifi)
+	ap := f.srv.AddAccessPoint(dobj, "home", 5180, 80)
+	ip4 := f.srv.AddIP4Config(dobj, []gnm.IP4AddressData{{Address: "192.168.1.10", Prefix: 24}}, "192.168.1.1")
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateFailed, gnm.NmDeviceStateReasonNoSecrets)
+
+	// The properties are read with GetAll alone.
+	if err := f.srv.RemoveMethod("org.freedesktop.DBus.Properties.Get"); err != nil {
+		t.Fatal(err)
+	}
+
+	nmProps, err := f.nm.GetProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nmProps.Devices) != 1 || nmProps.Devices[0] != dobj.Path() {
+		t.Errorf("Devices = %v, want %s", nmProps.Devices, dobj.Path())
+	}
+
+	d, err := gnm.NewDeviceWirelessWithConn(f.conn, dobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	props, err := d.GetProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.Interface != "wlan0" || props.DeviceType != gnm.NmDeviceTypeWifi || props.State != gnm.NmDeviceStateFailed {
+		t.Errorf("Interface, DeviceType, State = %s, %v, %v, want wlan0, %v, %v", props.Interface, props.DeviceType, props.State,
+			gnm.NmDeviceTypeWifi, gnm.NmDeviceStateFailed)
+	}
+	want := gnm.DeviceStateReason{State: gnm.NmDeviceStateFailed, Reason: gnm.NmDeviceStateReasonNoSecrets}
+	if props.StateReason != want {
+		t.Errorf("StateReason = %+v, want %+v", props.StateReason, want)
+	}
+	if props.Ip4Config != ip4.Path() {
+		t.Errorf("Ip4Config = %s, want %s", props.Ip4Config, ip4.Path())
+	}
+
+	wireless, err := d.GetWirelessProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wireless.AccessPoints) != 1 || wireless.AccessPoints[0] != ap.Path() {
+		t.Errorf("AccessPoints = %v, want %s", wireless.AccessPoints, ap.Path())
+	}
+
+	a, err := gnm.NewAccessPointWithConn(f.conn, ap.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	apProps, err := a.GetProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(apProps.Ssid) != "home" || apProps.Frequency != 5180 || apProps.Strength != 80 {
+		t.Errorf("Ssid, Frequency, Strength = %s, %d, %d, want home, 5180, 80", apProps.Ssid, apProps.Frequency, apProps.Strength)
+	}
+
+	c, err := gnm.NewIP4ConfigWithConn(f.conn, ip4.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip4Props, err := c.GetProperties()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ip4Props.AddressData) != 1 || ip4Props.AddressData[0].Address != "192.168.1.10" || ip4Props.Gateway != "192.168.1.1" {
+		t.Errorf("AddressData, Gateway = %v, %s, want 192.168.1.10/24, 192.168.1.1", ip4Props.AddressData, ip4Props.Gateway)
+	}
+}
+
+func TestGetPropertiesUnknownObject(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	a, err := gnm.NewAccessPointWithConn(f.conn, dbus.ObjectPath(gnm.NetworkManagerObjectPath+"/AccessPoint/42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.GetProperties(); err == nil {
+		t.Error("GetProperties() of a missing access point succeeded")
+	}
+}
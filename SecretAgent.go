@@ -0,0 +1,205 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	SecretAgentInterface  = NetworkManagerInterface + ".SecretAgent"
+	SecretAgentObjectPath = NetworkManagerObjectPath + "/SecretAgent"
+
+	/* Methods */
+	SecretAgentGetSecrets       = SecretAgentInterface + ".GetSecrets"
+	SecretAgentCancelGetSecrets = SecretAgentInterface + ".CancelGetSecrets"
+	SecretAgentSaveSecrets      = SecretAgentInterface + ".SaveSecrets"
+	SecretAgentDeleteSecrets    = SecretAgentInterface + ".DeleteSecrets"
+
+	/* Errors */
+	SecretAgentErrorFailed            = SecretAgentInterface + ".Failed"
+	SecretAgentErrorPermissionDenied  = SecretAgentInterface + ".PermissionDenied"
+	SecretAgentErrorInvalidConnection = SecretAgentInterface + ".InvalidConnection"
+	SecretAgentErrorUserCanceled      = SecretAgentInterface + ".UserCanceled"
+	SecretAgentErrorAgentCanceled     = SecretAgentInterface + ".AgentCanceled"
+	SecretAgentErrorNoSecrets         = SecretAgentInterface + ".NoSecrets"
+
+	dbusMethodGetNameOwner = "org.freedesktop.DBus.GetNameOwner"
+)
+
+var (
+	// ErrSecretAgentUserCanceled is returned by a SecretAgent when the user
+	// canceled the secrets request.
+	ErrSecretAgentUserCanceled = errors.New("user canceled the secrets request")
+
+	// ErrSecretAgentNoSecrets is returned by a SecretAgent when it has no
+	// secrets for the connection.
+	ErrSecretAgentNoSecrets = errors.New("no secrets available")
+)
+
+// SecretAgent provides the secrets of connections to NetworkManager, e.g. by
+// prompting the user or reading them from a keyring. It is registered with
+// AgentManager.Register.
+//
+// The methods are called concurrently, each from its own goroutine. An error
+// is returned to NetworkManager as a SecretAgentErrorFailed D-Bus error, except
+// for ErrSecretAgentUserCanceled, ErrSecretAgentNoSecrets and dbus.Error
+// values, which are returned as is.
+type SecretAgent interface {
+	// GetSecrets returns the secrets of the settingName setting of a connection, e.g. {"802-11-wireless-security": {"psk": "..."}}. The hints name the secrets NetworkManager needs, and flags tell whether the agent may interact with the user. The context is canceled when NetworkManager cancels the request.
+	GetSecrets(ctx context.Context, connection ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags NmSecretAgentGetSecretsFlags) (ConnectionSettings, error)
+
+	// SaveSecrets saves the secrets of the connection, which are marked as owned by agents, to the storage of the agent.
+	SaveSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error
+
+	// DeleteSecrets deletes the secrets of the connection from the storage of the agent.
+	DeleteSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error
+}
+
+// secretAgentExport is the object exported on D-Bus for a SecretAgent. It
+// only answers NetworkManager, since other peers of the bus would otherwise be
+// able to read secrets, and turns CancelGetSecrets into the cancellation of the
+// context given to GetSecrets.
+type secretAgentExport struct {
+	conn  *dbus.Conn
+	agent SecretAgent
+
+	lock    sync.Mutex
+	pending map[secretAgentRequest][]*context.CancelFunc
+}
+
+type secretAgentRequest struct {
+	connectionPath dbus.ObjectPath
+	settingName    string
+}
+
+func newSecretAgentExport(conn *dbus.Conn, agent SecretAgent) *secretAgentExport {
+	return &secretAgentExport{
+		conn:    conn,
+		agent:   agent,
+		pending: make(map[secretAgentRequest][]*context.CancelFunc),
+	}
+}
+
+func (e *secretAgentExport) GetSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath, settingName string, hints []string, flags uint32) (ConnectionSettings, *dbus.Error) {
+	if err := e.checkSender(sender); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := secretAgentRequest{connectionPath, settingName}
+	e.lock.Lock()
+	e.pending[request] = append(e.pending[request], &cancel)
+	e.lock.Unlock()
+
+	secrets, err := e.agent.GetSecrets(ctx, settingsFromVariants(connection), connectionPath, settingName, hints, NmSecretAgentGetSecretsFlags(flags))
+	canceled := ctx.Err() != nil
+	e.removePending(request, &cancel)
+
+	if canceled {
+		return nil, dbus.NewError(SecretAgentErrorAgentCanceled, []interface{}{"request canceled"})
+	}
+	if err != nil {
+		return nil, secretAgentError(err)
+	}
+	if secrets == nil {
+		secrets = make(ConnectionSettings)
+	}
+	return secrets, nil
+}
+
+func (e *secretAgentExport) CancelGetSecrets(sender dbus.Sender, connectionPath dbus.ObjectPath, settingName string) *dbus.Error {
+	if err := e.checkSender(sender); err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	request := secretAgentRequest{connectionPath, settingName}
+	cancels := e.pending[request]
+	delete(e.pending, request)
+	e.lock.Unlock()
+
+	for _, cancel := range cancels {
+		(*cancel)()
+	}
+	return nil
+}
+
+func (e *secretAgentExport) SaveSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	if err := e.checkSender(sender); err != nil {
+		return err
+	}
+
+	return secretAgentError(e.agent.SaveSecrets(settingsFromVariants(connection), connectionPath))
+}
+
+func (e *secretAgentExport) DeleteSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	if err := e.checkSender(sender); err != nil {
+		return err
+	}
+
+	return secretAgentError(e.agent.DeleteSecrets(settingsFromVariants(connection), connectionPath))
+}
+
+// cancelAll cancels the pending GetSecrets calls, once the agent is no longer
+// registered.
+func (e *secretAgentExport) cancelAll() {
+	e.lock.Lock()
+	pending := e.pending
+	e.pending = make(map[secretAgentRequest][]*context.CancelFunc)
+	e.lock.Unlock()
+
+	for _, cancels := range pending {
+		for _, cancel := range cancels {
+			(*cancel)()
+		}
+	}
+}
+
+func (e *secretAgentExport) removePending(request secretAgentRequest, cancel *context.CancelFunc) {
+	(*cancel)()
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	cancels := e.pending[request]
+	for i, c := range cancels {
+		if c == cancel {
+			cancels = append(cancels[:i], cancels[i+1:]...)
+			break
+		}
+	}
+	if len(cancels) == 0 {
+		delete(e.pending, request)
+	} else {
+		e.pending[request] = cancels
+	}
+}
+
+func (e *secretAgentExport) checkSender(sender dbus.Sender) *dbus.Error {
+	var owner string
+	err := e.conn.BusObject().Call(dbusMethodGetNameOwner, 0, NetworkManagerInterface).Store(&owner)
+	if err != nil || owner != string(sender) {
+		return dbus.NewError(SecretAgentErrorPermissionDenied, []interface{}{"request not sent by NetworkManager"})
+	}
+	return nil
+}
+
+func secretAgentError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+
+	var dbusErr dbus.Error
+	switch {
+	case errors.As(err, &dbusErr):
+		return &dbusErr
+	case errors.Is(err, ErrSecretAgentUserCanceled):
+		return dbus.NewError(SecretAgentErrorUserCanceled, []interface{}{err.Error()})
+	case errors.Is(err, ErrSecretAgentNoSecrets):
+		return dbus.NewError(SecretAgentErrorNoSecrets, []interface{}{err.Error()})
+	}
+	return dbus.NewError(SecretAgentErrorFailed, []interface{}{err.Error()})
+}
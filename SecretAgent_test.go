@@ -0,0 +1,185 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// testAgent answers with a fixed passphrase, or blocks until the request is
+// canceled when block is set.
+type testAgent struct {
+	block    bool
+	canceled chan struct{}
+	saved    chan dbus.ObjectPath
+	deleted  chan dbus.ObjectPath
+	id       chan interface{}
+}
+
+func newTestAgent() *testAgent {
+	return &testAgent{
+		canceled: make(chan struct{}, 1),
+		saved:    make(chan dbus.ObjectPath, 1),
+		deleted:  make(chan dbus.ObjectPath, 1),
+		id:       make(chan interface{}, 1),
+	}
+}
+
+func (a *testAgent) GetSecrets(ctx context.Context, connection gnm.ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags gnm.NmSecretAgentGetSecretsFlags) (gnm.ConnectionSettings, error) {
+	a.id <- connection["connection"]["id"]
+	if a.block {
+		<-ctx.Done()
+		a.canceled <- struct{}{}
+		return nil, ctx.Err()
+	}
+	if settingName != "802-11-wireless-security" {
+		return nil, gnm.ErrSecretAgentNoSecrets
+	}
+	return gnm.ConnectionSettings{settingName: {"psk": "passphrase"}}, nil
+}
+
+func (a *testAgent) SaveSecrets(connection gnm.ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	a.saved <- connectionPath
+	return nil
+}
+
+func (a *testAgent) DeleteSecrets(connection gnm.ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	a.deleted <- connectionPath
+	return errors.New("keyring locked")
+}
+
+func TestSecretAgent(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, cobj := f.addConnection(t)
+
+	am, err := gnm.NewAgentManagerWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := newTestAgent()
+	if err := am.Register(agent, "org.example.test"); err != nil {
+		t.Fatal(err)
+	}
+	if agents := f.srv.Agents(); len(agents) != 1 || agents[0] != "org.example.test" {
+		t.Fatalf("Agents() = %v, want org.example.test", agents)
+	}
+
+	secrets, err := f.srv.GetAgentSecrets(context.Background(), cobj, "802-11-wireless-security", nil, gnm.NmSecretAgentGetSecretsFlagsAllowInteraction)
+	if err != nil {
+		t.Fatalf("GetSecrets() = %v", err)
+	}
+	if psk := secrets["802-11-wireless-security"]["psk"]; psk != "passphrase" {
+		t.Errorf("psk = %v, want passphrase", psk)
+	}
+	if id := <-agent.id; id != "test" {
+		t.Errorf("the agent got the connection %v, want test", id)
+	}
+
+	_, err = f.srv.GetAgentSecrets(context.Background(), cobj, "802-1x", nil, 0)
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) || dbusErr.Name != gnm.SecretAgentErrorNoSecrets {
+		t.Errorf("GetSecrets() without secrets = %v, want %s", err, gnm.SecretAgentErrorNoSecrets)
+	}
+	<-agent.id
+
+	if err := f.srv.SaveAgentSecrets(cobj); err != nil {
+		t.Errorf("SaveSecrets() = %v", err)
+	}
+	if path := <-agent.saved; path != cobj.Path() {
+		t.Errorf("SaveSecrets() of %s, want %s", path, cobj.Path())
+	}
+	err = f.srv.DeleteAgentSecrets(cobj)
+	if !errors.As(err, &dbusErr) || dbusErr.Name != gnm.SecretAgentErrorFailed {
+		t.Errorf("DeleteSecrets() = %v, want %s", err, gnm.SecretAgentErrorFailed)
+	}
+	<-agent.deleted
+
+	if err := am.Unregister(); err != nil {
+		t.Fatal(err)
+	}
+	if agents := f.srv.Agents(); len(agents) != 0 {
+		t.Errorf("Agents() after Unregister() = %v, want none", agents)
+	}
+}
+
+func TestSecretAgentCancel(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, cobj := f.addConnection(t)
+
+	am, err := gnm.NewAgentManagerWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := newTestAgent()
+	agent.block = true
+	if err := am.Register(agent, "org.example.test"); err != nil {
+		t.Fatal(err)
+	}
+	defer am.Unregister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := f.srv.GetAgentSecrets(ctx, cobj, "802-11-wireless-security", nil, 0)
+		done <- err
+	}()
+
+	<-agent.id
+	cancel()
+
+	select {
+	case <-agent.canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("CancelGetSecrets() did not cancel the request")
+	}
+	var dbusErr dbus.Error
+	if err := <-done; !errors.As(err, &dbusErr) || dbusErr.Name != gnm.SecretAgentErrorAgentCanceled {
+		t.Errorf("GetSecrets() = %v, want %s", err, gnm.SecretAgentErrorAgentCanceled)
+	}
+}
+
+func TestSecretAgentSender(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, cobj := f.addConnection(t)
+
+	am, err := gnm.NewAgentManagerWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := newTestAgent()
+	if err := am.Register(agent, "org.example.test"); err != nil {
+		t.Fatal(err)
+	}
+	defer am.Unregister()
+
+	// Another peer of the bus cannot ask for the secrets.
+	other, err := f.srv.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	settings := map[string]map[string]dbus.Variant{"connection": {"id": dbus.MakeVariant("test")}}
+	call := other.Object(f.conn.Names()[0], gnm.SecretAgentObjectPath).Call(gnm.SecretAgentGetSecrets, 0,
+		settings, cobj.Path(), "802-11-wireless-security", []string{}, uint32(0))
+	var dbusErr dbus.Error
+	if !errors.As(call.Err, &dbusErr) || dbusErr.Name != gnm.SecretAgentErrorPermissionDenied {
+		t.Errorf("GetSecrets() from another peer = %v, want %s", call.Err, gnm.SecretAgentErrorPermissionDenied)
+	}
+	select {
+	case <-agent.id:
+		t.Error("the agent was called for another peer")
+	default:
+	}
+}
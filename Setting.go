@@ -0,0 +1,608 @@
+package gonetworkmanager
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ErrSettingNotFound is returned by ConnectionSettings.GetSetting when the
+// connection profile has no such setting.
+var ErrSettingNotFound = errors.New("setting not found")
+
+// Setting is the typed form of one setting of a connection profile, e.g.
+// SettingWireless for the "802-11-wireless" setting.
+//
+// The fields are mapped to the setting keys by their `nm` struct tag. Zero
+// values are left out of the profile so that NetworkManager applies its own
+// defaults; the keys whose zero value is meaningful use pointer fields.
+type Setting interface {
+	// The name of the setting in the connection profile.
+	SettingName() string
+}
+
+// NewConnectionSettings builds a connection profile from typed settings. A
+// random connection.uuid is generated when the SettingConnection does not
+// carry one.
+func NewConnectionSettings(settings ...Setting) (ConnectionSettings, error) {
+	rv := make(ConnectionSettings)
+	for _, setting := range settings {
+		if c, ok := setting.(*SettingConnection); ok && c.Uuid == "" {
+			uuid, err := newUUID()
+			if err != nil {
+				return nil, err
+			}
+			copied := *c
+			copied.Uuid = uuid
+			setting = &copied
+		}
+
+		if err := rv.SetSetting(setting); err != nil {
+			return nil, err
+		}
+	}
+	return rv, nil
+}
+
+// SetSetting replaces a setting of the profile with the D-Bus representation
+// of a typed setting.
+func (s ConnectionSettings) SetSetting(setting Setting) error {
+	values, err := marshalSetting(setting)
+	if err != nil {
+		return err
+	}
+
+	s[setting.SettingName()] = values
+	return nil
+}
+
+// GetSetting decodes a setting of the profile into a typed setting, which
+// must be a pointer, e.g. GetSetting(&SettingWireless{}). Keys unknown to the
+// typed setting are ignored. ErrSettingNotFound is returned when the profile
+// does not have the setting.
+func (s ConnectionSettings) GetSetting(setting Setting) error {
+	values, ok := s[setting.SettingName()]
+	if !ok {
+		return ErrSettingNotFound
+	}
+
+	return unmarshalSetting(values, setting)
+}
+
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+var (
+	typeBool           = reflect.TypeOf(false)
+	typeBoolPointer    = reflect.TypeOf((*bool)(nil))
+	typeBytes          = reflect.TypeOf([]byte(nil))
+	typeHardwareAddr   = reflect.TypeOf(net.HardwareAddr(nil))
+	typeStrings        = reflect.TypeOf([]string(nil))
+	typeStringMap      = reflect.TypeOf(map[string]string(nil))
+	typeIP4AddressData = reflect.TypeOf([]IP4AddressData(nil))
+	typeIP6AddressData = reflect.TypeOf([]IP6AddressData(nil))
+	typeIP4RouteData   = reflect.TypeOf([]IP4RouteData(nil))
+	typeIP6RouteData   = reflect.TypeOf([]IP6RouteData(nil))
+	typeWireGuardPeers = reflect.TypeOf([]WireGuardPeer(nil))
+)
+
+// settingField describes one tagged field of a typed setting. The only tag
+// option is the address family of the "dns" keys, which NetworkManager sends
+// as au for IPv4 and aay for IPv6.
+type settingField struct {
+	index  int
+	key    string
+	family string
+}
+
+func settingFields(t reflect.Type) []settingField {
+	var fields []settingField
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("nm")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := settingField{index: i, key: parts[0]}
+		if len(parts) > 1 {
+			field.family = parts[1]
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func settingStruct(setting Setting) (reflect.Value, error) {
+	v := reflect.ValueOf(setting)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil %T", setting)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%T is not a struct", setting)
+	}
+	return v, nil
+}
+
+func marshalSetting(setting Setting) (map[string]interface{}, error) {
+	v, err := settingStruct(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+	for _, field := range settingFields(v.Type()) {
+		value, ok, err := marshalSettingValue(v.Field(field.index), field)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", setting.SettingName(), field.key, err)
+		}
+		if ok {
+			values[field.key] = value
+		}
+	}
+	return values, nil
+}
+
+func marshalSettingValue(v reflect.Value, field settingField) (interface{}, bool, error) {
+	switch v.Type() {
+	case typeBoolPointer:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		return v.Elem().Bool(), true, nil
+
+	case typeBytes, typeHardwareAddr:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		return v.Convert(typeBytes).Interface(), true, nil
+
+	case typeIP4AddressData:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		var data []map[string]dbus.Variant
+		for _, address := range v.Interface().([]IP4AddressData) {
+			data = append(data, addressDataToVariants(address.Address, address.Prefix))
+		}
+		return data, true, nil
+
+	case typeIP6AddressData:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		var data []map[string]dbus.Variant
+		for _, address := range v.Interface().([]IP6AddressData) {
+			data = append(data, addressDataToVariants(address.Address, address.Prefix))
+		}
+		return data, true, nil
+
+	case typeIP4RouteData:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		var data []map[string]dbus.Variant
+		for _, route := range v.Interface().([]IP4RouteData) {
+			variants, err := routeDataToVariants(route.Destination, route.Prefix, route.NextHop, route.Metric, route.AdditionalAttributes)
+			if err != nil {
+				return nil, false, err
+			}
+			data = append(data, variants)
+		}
+		return data, true, nil
+
+	case typeIP6RouteData:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		var data []map[string]dbus.Variant
+		for _, route := range v.Interface().([]IP6RouteData) {
+			variants, err := routeDataToVariants(route.Destination, route.Prefix, route.NextHop, route.Metric, route.AdditionalAttributes)
+			if err != nil {
+				return nil, false, err
+			}
+			data = append(data, variants)
+		}
+		return data, true, nil
+
+	case typeWireGuardPeers:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		return wireGuardPeersToVariants(v.Interface().([]WireGuardPeer)), true, nil
+
+	case typeStrings:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		if field.family != "" {
+			return marshalDNS(v.Interface().([]string), field.family)
+		}
+		return v.Interface(), true, nil
+
+	case typeStringMap:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		return v.Interface(), true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		// Set pointers are sent even with a zero value.
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		value, _, err := marshalSettingValue(v.Elem(), field)
+		return value, err == nil, err
+	case reflect.String:
+		return v.String(), v.String() != "", nil
+	case reflect.Bool:
+		return v.Bool(), v.Bool(), nil
+	case reflect.Int32:
+		return int32(v.Int()), v.Int() != 0, nil
+	case reflect.Int64:
+		return v.Int(), v.Int() != 0, nil
+	case reflect.Uint32:
+		return uint32(v.Uint()), v.Uint() != 0, nil
+	case reflect.Uint64:
+		return v.Uint(), v.Uint() != 0, nil
+	}
+
+	return nil, false, fmt.Errorf("unsupported field type %s", v.Type())
+}
+
+func addressDataToVariants(address string, prefix uint8) map[string]dbus.Variant {
+	return map[string]dbus.Variant{
+		"address": dbus.MakeVariant(address),
+		"prefix":  dbus.MakeVariant(uint32(prefix)),
+	}
+}
+
+// routeAttributeSignatures are the D-Bus types of the route attributes
+// NetworkManager knows; it rejects routes where they have another type. Other
+// attributes are sent as strings.
+var routeAttributeSignatures = map[string]string{
+	"advmss":        "u",
+	"cwnd":          "u",
+	"from":          "s",
+	"initcwnd":      "u",
+	"initrwnd":      "u",
+	"lock-advmss":   "b",
+	"lock-cwnd":     "b",
+	"lock-initcwnd": "b",
+	"lock-initrwnd": "b",
+	"lock-mtu":      "b",
+	"lock-window":   "b",
+	"mtu":           "u",
+	"onlink":        "b",
+	"quickack":      "b",
+	"rto_min":       "u",
+	"scope":         "y",
+	"src":           "s",
+	"table":         "u",
+	"tos":           "y",
+	"type":          "s",
+	"weight":        "u",
+	"window":        "u",
+}
+
+func routeDataToVariants(destination string, prefix uint8, nextHop string, metric *uint32, attributes map[string]string) (map[string]dbus.Variant, error) {
+	data := map[string]dbus.Variant{
+		"dest":   dbus.MakeVariant(destination),
+		"prefix": dbus.MakeVariant(uint32(prefix)),
+	}
+	if nextHop != "" {
+		data["next-hop"] = dbus.MakeVariant(nextHop)
+	}
+	if metric != nil {
+		data["metric"] = dbus.MakeVariant(*metric)
+	}
+	for name, value := range attributes {
+		variant, err := routeAttributeToVariant(name, value)
+		if err != nil {
+			return nil, err
+		}
+		data[name] = variant
+	}
+	return data, nil
+}
+
+// routeAttributeToVariant converts the string form of a route attribute, as
+// kept in AdditionalAttributes, to a variant of the type NetworkManager
+// expects.
+func routeAttributeToVariant(name, value string) (dbus.Variant, error) {
+	switch routeAttributeSignatures[name] {
+	case "u":
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return dbus.Variant{}, fmt.Errorf("route attribute %s: '%s' is not a uint32", name, value)
+		}
+		return dbus.MakeVariant(uint32(n)), nil
+	case "y":
+		n, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return dbus.Variant{}, fmt.Errorf("route attribute %s: '%s' is not a byte", name, value)
+		}
+		return dbus.MakeVariant(byte(n)), nil
+	case "b":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return dbus.Variant{}, fmt.Errorf("route attribute %s: '%s' is not a boolean", name, value)
+		}
+		return dbus.MakeVariant(b), nil
+	}
+	return dbus.MakeVariant(value), nil
+}
+
+// routeAttributeString is the inverse of routeAttributeToVariant.
+func routeAttributeString(variant dbus.Variant) string {
+	switch value := variant.Value().(type) {
+	case string:
+		return value
+	case uint32:
+		return strconv.FormatUint(uint64(value), 10)
+	case byte:
+		return strconv.FormatUint(uint64(value), 10)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return fmt.Sprint(variant.Value())
+}
+
+// marshalDNS converts name server addresses to the legacy "dns" encodings:
+// IPv4 addresses as uint32 in network byte order and IPv6 addresses as raw
+// bytes.
+func marshalDNS(servers []string, family string) (interface{}, bool, error) {
+	switch family {
+	case "ip4":
+		rv := make([]uint32, 0, len(servers))
+		for _, server := range servers {
+			ip := net.ParseIP(server).To4()
+			if ip == nil {
+				return nil, false, fmt.Errorf("invalid IPv4 address '%s'", server)
+			}
+			rv = append(rv, ip4FromBytes(ip))
+		}
+		return rv, true, nil
+
+	case "ip6":
+		rv := make([][]byte, 0, len(servers))
+		for _, server := range servers {
+			ip := net.ParseIP(server)
+			if ip == nil || ip.To4() != nil {
+				return nil, false, fmt.Errorf("invalid IPv6 address '%s'", server)
+			}
+			rv = append(rv, []byte(ip.To16()))
+		}
+		return rv, true, nil
+	}
+
+	return nil, false, fmt.Errorf("unknown address family '%s'", family)
+}
+
+func unmarshalSetting(values map[string]interface{}, setting Setting) error {
+	v, err := settingStruct(setting)
+	if err != nil {
+		return err
+	}
+	if reflect.ValueOf(setting).Kind() != reflect.Ptr {
+		return fmt.Errorf("%T is not a pointer", setting)
+	}
+
+	for _, field := range settingFields(v.Type()) {
+		value, ok := values[field.key]
+		if !ok {
+			continue
+		}
+		if variant, isVariant := value.(dbus.Variant); isVariant {
+			value = variant.Value()
+		}
+
+		if err := unmarshalSettingValue(v.Field(field.index), value, field); err != nil {
+			return fmt.Errorf("%s.%s: %v", setting.SettingName(), field.key, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalSettingValue(v reflect.Value, value interface{}, field settingField) error {
+	mismatch := fmt.Errorf("unexpected value type %T", value)
+
+	switch v.Type() {
+	case typeBoolPointer:
+		b, ok := value.(bool)
+		if !ok {
+			return mismatch
+		}
+		v.Set(reflect.ValueOf(&b))
+		return nil
+
+	case typeBytes, typeHardwareAddr:
+		b, ok := value.([]byte)
+		if !ok {
+			return mismatch
+		}
+		v.Set(reflect.ValueOf(b).Convert(v.Type()))
+		return nil
+
+	case typeIP4AddressData, typeIP6AddressData:
+		data, ok := variantMaps(value)
+		if !ok {
+			return mismatch
+		}
+		rv := reflect.MakeSlice(v.Type(), 0, len(data))
+		for _, d := range data {
+			address, _ := d["address"].Value().(string)
+			prefix, _ := d["prefix"].Value().(uint32)
+			item := reflect.New(v.Type().Elem()).Elem()
+			item.FieldByName("Address").SetString(address)
+			item.FieldByName("Prefix").SetUint(uint64(prefix))
+			rv = reflect.Append(rv, item)
+		}
+		v.Set(rv)
+		return nil
+
+	case typeIP4RouteData, typeIP6RouteData:
+		data, ok := variantMaps(value)
+		if !ok {
+			return mismatch
+		}
+		rv := reflect.MakeSlice(v.Type(), 0, len(data))
+		for _, d := range data {
+			item := reflect.New(v.Type().Elem()).Elem()
+			attributes := make(map[string]string)
+			for name, attribute := range d {
+				switch name {
+				case "dest":
+					destination, _ := attribute.Value().(string)
+					item.FieldByName("Destination").SetString(destination)
+				case "prefix":
+					prefix, _ := attribute.Value().(uint32)
+					item.FieldByName("Prefix").SetUint(uint64(prefix))
+				case "next-hop":
+					nextHop, _ := attribute.Value().(string)
+					item.FieldByName("NextHop").SetString(nextHop)
+				case "metric":
+					if metric, ok := attribute.Value().(uint32); ok {
+						item.FieldByName("Metric").Set(reflect.ValueOf(&metric))
+					}
+				default:
+					attributes[name] = routeAttributeString(attribute)
+				}
+			}
+			if len(attributes) > 0 {
+				item.FieldByName("AdditionalAttributes").Set(reflect.ValueOf(attributes))
+			}
+			rv = reflect.Append(rv, item)
+		}
+		v.Set(rv)
+		return nil
+
+	case typeWireGuardPeers:
+		data, ok := variantMaps(value)
+		if !ok {
+			return mismatch
+		}
+		v.Set(reflect.ValueOf(wireGuardPeersFromVariants(data)))
+		return nil
+
+	case typeStrings:
+		if field.family != "" {
+			servers, err := unmarshalDNS(value)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(servers))
+			return nil
+		}
+		s, ok := value.([]string)
+		if !ok {
+			return mismatch
+		}
+		v.Set(reflect.ValueOf(s))
+		return nil
+
+	case typeStringMap:
+		m, ok := value.(map[string]string)
+		if !ok {
+			return mismatch
+		}
+		v.Set(reflect.ValueOf(m))
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		if err := unmarshalSettingValue(elem.Elem(), value, field); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || rv.Kind() != v.Kind() {
+		return mismatch
+	}
+	v.Set(rv.Convert(v.Type()))
+	return nil
+}
+
+// variantMaps returns an aa{sv} value, which may also have been built as
+// []map[string]interface{}, as []map[string]dbus.Variant.
+func variantMaps(value interface{}) ([]map[string]dbus.Variant, bool) {
+	switch maps := value.(type) {
+	case []map[string]dbus.Variant:
+		return maps, true
+
+	case []map[string]interface{}:
+		rv := make([]map[string]dbus.Variant, 0, len(maps))
+		for _, m := range maps {
+			vm := make(map[string]dbus.Variant, len(m))
+			for k, v := range m {
+				if variant, ok := v.(dbus.Variant); ok {
+					vm[k] = variant
+				} else {
+					vm[k] = dbus.MakeVariant(v)
+				}
+			}
+			rv = append(rv, vm)
+		}
+		return rv, true
+	}
+
+	return nil, false
+}
+
+// sameSettingValue compares two values of a setting key, as returned by
+// NetworkManager or built by the caller.
+func sameSettingValue(a, b interface{}) bool {
+	return settingVariant(a).String() == settingVariant(b).String()
+}
+
+func settingVariant(value interface{}) dbus.Variant {
+	if variant, ok := value.(dbus.Variant); ok {
+		return variant
+	}
+	if maps, ok := variantMaps(value); ok {
+		return dbus.MakeVariant(maps)
+	}
+	return dbus.MakeVariant(value)
+}
+
+func unmarshalDNS(value interface{}) ([]string, error) {
+	switch servers := value.(type) {
+	case []uint32:
+		rv := make([]string, 0, len(servers))
+		for _, server := range servers {
+			rv = append(rv, ip4ToString(server))
+		}
+		return rv, nil
+
+	case [][]byte:
+		rv := make([]string, 0, len(servers))
+		for _, server := range servers {
+			rv = append(rv, net.IP(server).String())
+		}
+		return rv, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value type %T", value)
+}
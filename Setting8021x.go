@@ -0,0 +1,54 @@
+package gonetworkmanager
+
+const (
+	Setting8021xSettingName = "802-1x"
+)
+
+// Setting8021x is the "802-1x" setting holding the EAP configuration of
+// enterprise Wi-Fi and wired 802.1X connections.
+//
+// Certificates and keys are either a path, as "file://" followed by the path
+// and a NUL byte, or the DER or PEM encoded data itself.
+type Setting8021x struct {
+	Eap                           []string `nm:"eap"`                               // Allowed EAP methods, e.g. "peap", "ttls" or "tls".
+	Identity                      string   `nm:"identity"`                          // Identity string for EAP authentication methods.
+	AnonymousIdentity             string   `nm:"anonymous-identity"`                // Unencrypted identity used by tunneled methods.
+	PacFile                       string   `nm:"pac-file"`                          // UTF-8 encoded file path containing PAC for EAP-FAST.
+	CaCert                        []byte   `nm:"ca-cert"`                           // CA certificate checking the server certificate.
+	CaCertPassword                string   `nm:"ca-cert-password"`                  // Password to access the CA certificate when it is a PKCS#11 URI.
+	CaPath                        string   `nm:"ca-path"`                           // Directory of CA certificates.
+	SubjectMatch                  string   `nm:"subject-match"`                     // Substring to be matched against the subject of the server certificate.
+	AltsubjectMatches             []string `nm:"altsubject-matches"`                // Strings to be matched against the altSubjectName of the server certificate.
+	DomainSuffixMatch             string   `nm:"domain-suffix-match"`               // Constraint for the server domain name.
+	ClientCert                    []byte   `nm:"client-cert"`                       // Client certificate for the "tls" method.
+	Phase1Peapver                 string   `nm:"phase1-peapver"`                    // Forced PEAP version, "0" or "1".
+	Phase1Peaplabel               string   `nm:"phase1-peaplabel"`                  // Forced PEAP label, "0" or "1".
+	Phase1FastProvisioning        string   `nm:"phase1-fast-provisioning"`          // In-line PAC provisioning for EAP-FAST, "0" to "3".
+	Phase1AuthFlags               uint32   `nm:"phase1-auth-flags"`                 // TLS versions allowed in the first phase.
+	Phase2Auth                    string   `nm:"phase2-auth"`                       // Inner non-EAP method, e.g. "mschapv2" or "pap".
+	Phase2Autheap                 string   `nm:"phase2-autheap"`                    // Inner EAP method, e.g. "mschapv2" or "md5".
+	Phase2CaCert                  []byte   `nm:"phase2-ca-cert"`                    // CA certificate of the inner authentication.
+	Phase2CaPath                  string   `nm:"phase2-ca-path"`                    // Directory of CA certificates of the inner authentication.
+	Phase2SubjectMatch            string   `nm:"phase2-subject-match"`              // Subject match of the inner authentication.
+	Phase2AltsubjectMatches       []string `nm:"phase2-altsubject-matches"`         // altSubjectName matches of the inner authentication.
+	Phase2DomainSuffixMatch       string   `nm:"phase2-domain-suffix-match"`        // Domain name constraint of the inner authentication.
+	Phase2ClientCert              []byte   `nm:"phase2-client-cert"`                // Client certificate of the inner authentication.
+	Password                      string   `nm:"password"`                          // Password for methods requiring one.
+	PasswordFlags                 uint32   `nm:"password-flags"`                    // Secret flags of Password.
+	PasswordRaw                   []byte   `nm:"password-raw"`                      // Password as raw bytes, for methods requiring one.
+	PasswordRawFlags              uint32   `nm:"password-raw-flags"`                // Secret flags of PasswordRaw.
+	PrivateKey                    []byte   `nm:"private-key"`                       // Private key for the "tls" method.
+	PrivateKeyPassword            string   `nm:"private-key-password"`              // Password to decrypt PrivateKey.
+	PrivateKeyPasswordFlags       uint32   `nm:"private-key-password-flags"`        // Secret flags of PrivateKeyPassword.
+	Phase2PrivateKey              []byte   `nm:"phase2-private-key"`                // Private key of the inner "tls" method.
+	Phase2PrivateKeyPassword      string   `nm:"phase2-private-key-password"`       // Password to decrypt Phase2PrivateKey.
+	Phase2PrivateKeyPasswordFlags uint32   `nm:"phase2-private-key-password-flags"` // Secret flags of Phase2PrivateKeyPassword.
+	Pin                           string   `nm:"pin"`                               // PIN for methods requiring one.
+	PinFlags                      uint32   `nm:"pin-flags"`                         // Secret flags of Pin.
+	SystemCaCerts                 bool     `nm:"system-ca-certs"`                   // Whether to use the system CA certificates when CaCert and CaPath are empty.
+	AuthTimeout                   int32    `nm:"auth-timeout"`                      // Timeout of the authentication in seconds, 0 for the default.
+}
+
+func (s *Setting8021x) SettingName() string {
+	return Setting8021xSettingName
+}
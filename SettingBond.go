@@ -0,0 +1,23 @@
+package gonetworkmanager
+
+const (
+	SettingBondSettingName = "bond"
+
+	/* Modes */
+	SettingBondModeBalanceRr    = "balance-rr"
+	SettingBondModeActiveBackup = "active-backup"
+	SettingBondModeBalanceXor   = "balance-xor"
+	SettingBondModeBroadcast    = "broadcast"
+	SettingBondMode8023ad       = "802.3ad"
+	SettingBondModeBalanceTlb   = "balance-tlb"
+	SettingBondModeBalanceAlb   = "balance-alb"
+)
+
+// SettingBond is the "bond" setting of bond master connections.
+type SettingBond struct {
+	Options map[string]string `nm:"options"` // Bonding driver options, e.g. {"mode": SettingBondModeActiveBackup, "miimon": "100"}.
+}
+
+func (s *SettingBond) SettingName() string {
+	return SettingBondSettingName
+}
@@ -0,0 +1,25 @@
+package gonetworkmanager
+
+import "net"
+
+const (
+	SettingBridgeSettingName = "bridge"
+)
+
+// SettingBridge is the "bridge" setting of bridge master connections.
+type SettingBridge struct {
+	MacAddress        net.HardwareAddr `nm:"mac-address"`        // MAC address of the bridge.
+	Stp               *bool            `nm:"stp"`                // Whether the Spanning Tree Protocol is enabled. Defaults to true.
+	Priority          *uint32          `nm:"priority"`           // STP priority of the bridge; lower values are preferred as the root bridge, 0 makes it always the root. Defaults to 32768.
+	ForwardDelay      uint32           `nm:"forward-delay"`      // STP forwarding delay, in seconds.
+	HelloTime         uint32           `nm:"hello-time"`         // STP hello time, in seconds.
+	MaxAge            uint32           `nm:"max-age"`            // STP maximum message age, in seconds.
+	AgeingTime        *uint32          `nm:"ageing-time"`        // Ethernet MAC address aging time, in seconds, 0 to forget addresses at once. Defaults to 300.
+	MulticastSnooping *bool            `nm:"multicast-snooping"` // Whether IGMP snooping is enabled. Defaults to true.
+	VlanFiltering     bool             `nm:"vlan-filtering"`     // Whether VLAN filtering is enabled on the bridge.
+	VlanDefaultPvid   *uint32          `nm:"vlan-default-pvid"`  // Default PVID of the ports of the bridge, 0 to disable it. Defaults to 1.
+}
+
+func (s *SettingBridge) SettingName() string {
+	return SettingBridgeSettingName
+}
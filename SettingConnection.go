@@ -0,0 +1,43 @@
+package gonetworkmanager
+
+const (
+	SettingConnectionSettingName = "connection"
+
+	/* Slave types */
+	SettingConnectionSlaveTypeBond      = "bond"
+	SettingConnectionSlaveTypeBridge    = "bridge"
+	SettingConnectionSlaveTypeTeam      = "team"
+	SettingConnectionSlaveTypeOvsPort   = "ovs-port"
+	SettingConnectionSlaveTypeOvsBridge = "ovs-bridge"
+)
+
+// SettingConnection is the "connection" setting, holding the general
+// properties of a connection profile.
+type SettingConnection struct {
+	Id                  string   `nm:"id"`                   // A human readable unique identifier for the connection.
+	Uuid                string   `nm:"uuid"`                 // A universally unique identifier for the connection.
+	Type                string   `nm:"type"`                 // Base type of the connection, the name of its main setting, e.g. "802-3-ethernet".
+	InterfaceName       string   `nm:"interface-name"`       // The name of the network interface this connection is bound to.
+	Autoconnect         *bool    `nm:"autoconnect"`          // Whether the connection should be activated automatically. Defaults to true.
+	AutoconnectPriority int32    `nm:"autoconnect-priority"` // Connections with higher priority are preferred for autoconnection.
+	AutoconnectRetries  *int32   `nm:"autoconnect-retries"`  // Number of autoconnect attempts before giving up, 0 for forever. Defaults to -1, the global default.
+	AutoconnectSlaves   *int32   `nm:"autoconnect-slaves"`   // Whether activating a master also activates its slaves: -1 default, 0 no, 1 yes. Defaults to -1.
+	Permissions         []string `nm:"permissions"`          // Users allowed to activate the connection, as "user:<name>" entries.
+	Zone                string   `nm:"zone"`                 // The trust level (firewall zone) of the connection.
+	Master              string   `nm:"master"`               // Interface name or UUID of the master connection.
+	SlaveType           string   `nm:"slave-type"`           // Setting name of the master type, e.g. "bond", when Master is set.
+	Secondaries         []string `nm:"secondaries"`          // UUIDs of connections to activate together with this one (e.g. VPNs).
+	GatewayPingTimeout  uint32   `nm:"gateway-ping-timeout"` // Seconds to wait for the gateway to answer a ping before considering the connection activated.
+	Metered             int32    `nm:"metered"`              // Whether the connection is metered, see NmMetered for the values.
+	Lldp                *int32   `nm:"lldp"`                 // Whether LLDP is enabled: -1 default, 0 disabled, 1 receive only. Defaults to -1.
+	Mdns                *int32   `nm:"mdns"`                 // Whether mDNS is enabled: -1 default, 0 no, 1 resolve, 2 yes. Defaults to -1.
+	StableId            string   `nm:"stable-id"`            // Token used to generate stable addresses, such as the cloned MAC address.
+	AuthRetries         *int32   `nm:"auth-retries"`         // Number of authentication retries, 0 for forever. Defaults to -1, the global default.
+	MultiConnect        int32    `nm:"multi-connect"`        // Whether the connection may be active on several devices at once.
+	Timestamp           uint64   `nm:"timestamp"`            // Time in seconds since the epoch the connection was last activated. Read-only.
+	ReadOnly            bool     `nm:"read-only"`            // Whether the connection can be modified. Read-only.
+}
+
+func (s *SettingConnection) SettingName() string {
+	return SettingConnectionSettingName
+}
@@ -0,0 +1,40 @@
+package gonetworkmanager
+
+const (
+	SettingIP4ConfigSettingName = "ipv4"
+
+	/* Methods */
+	SettingIP4ConfigMethodAuto      = "auto"
+	SettingIP4ConfigMethodLinkLocal = "link-local"
+	SettingIP4ConfigMethodManual    = "manual"
+	SettingIP4ConfigMethodShared    = "shared"
+	SettingIP4ConfigMethodDisabled  = "disabled"
+)
+
+// SettingIP4Config is the "ipv4" setting.
+type SettingIP4Config struct {
+	Method           string           `nm:"method"`             // IPv4 configuration method, one of the SettingIP4ConfigMethod constants.
+	Dns              []string         `nm:"dns,ip4"`            // IPv4 addresses of DNS servers.
+	DnsSearch        []string         `nm:"dns-search"`         // DNS search domains.
+	DnsOptions       []string         `nm:"dns-options"`        // DNS options for resolv.conf.
+	DnsPriority      int32            `nm:"dns-priority"`       // Priority of the DNS servers, lower values are preferred.
+	AddressData      []IP4AddressData `nm:"address-data"`       // Static addresses, used by the manual method.
+	Gateway          string           `nm:"gateway"`            // Gateway of the static addresses.
+	RouteData        []IP4RouteData   `nm:"route-data"`         // Static routes.
+	RouteMetric      *int64           `nm:"route-metric"`       // Metric of the default route and of the routes without a metric. Defaults to -1, the default of the device type.
+	RouteTable       uint32           `nm:"route-table"`        // Routing table of the routes, 0 for the default.
+	IgnoreAutoRoutes bool             `nm:"ignore-auto-routes"` // Whether to ignore the routes obtained automatically.
+	IgnoreAutoDns    bool             `nm:"ignore-auto-dns"`    // Whether to ignore the DNS servers obtained automatically.
+	DhcpClientId     string           `nm:"dhcp-client-id"`     // Client ID sent to the DHCP server.
+	DhcpTimeout      int32            `nm:"dhcp-timeout"`       // Timeout of the DHCP transaction in seconds.
+	DhcpSendHostname *bool            `nm:"dhcp-send-hostname"` // Whether the hostname is sent to the DHCP server. Defaults to true.
+	DhcpHostname     string           `nm:"dhcp-hostname"`      // Hostname sent to the DHCP server.
+	DhcpFqdn         string           `nm:"dhcp-fqdn"`          // FQDN sent to the DHCP server.
+	NeverDefault     bool             `nm:"never-default"`      // Whether the connection never gets the default route.
+	MayFail          *bool            `nm:"may-fail"`           // Whether the connection may activate without IPv4. Defaults to true.
+	DadTimeout       *int32           `nm:"dad-timeout"`        // Timeout of the duplicate address detection in milliseconds, 0 to disable it. Defaults to -1, the global default.
+}
+
+func (s *SettingIP4Config) SettingName() string {
+	return SettingIP4ConfigSettingName
+}
@@ -0,0 +1,42 @@
+package gonetworkmanager
+
+const (
+	SettingIP6ConfigSettingName = "ipv6"
+
+	/* Methods */
+	SettingIP6ConfigMethodIgnore    = "ignore"
+	SettingIP6ConfigMethodAuto      = "auto"
+	SettingIP6ConfigMethodDhcp      = "dhcp"
+	SettingIP6ConfigMethodLinkLocal = "link-local"
+	SettingIP6ConfigMethodManual    = "manual"
+	SettingIP6ConfigMethodShared    = "shared"
+	SettingIP6ConfigMethodDisabled  = "disabled"
+)
+
+// SettingIP6Config is the "ipv6" setting.
+type SettingIP6Config struct {
+	Method           string           `nm:"method"`             // IPv6 configuration method, one of the SettingIP6ConfigMethod constants.
+	Dns              []string         `nm:"dns,ip6"`            // IPv6 addresses of DNS servers.
+	DnsSearch        []string         `nm:"dns-search"`         // DNS search domains.
+	DnsOptions       []string         `nm:"dns-options"`        // DNS options for resolv.conf.
+	DnsPriority      int32            `nm:"dns-priority"`       // Priority of the DNS servers, lower values are preferred.
+	AddressData      []IP6AddressData `nm:"address-data"`       // Static addresses, used by the manual method.
+	Gateway          string           `nm:"gateway"`            // Gateway of the static addresses.
+	RouteData        []IP6RouteData   `nm:"route-data"`         // Static routes.
+	RouteMetric      *int64           `nm:"route-metric"`       // Metric of the default route and of the routes without a metric. Defaults to -1, the default of the device type.
+	RouteTable       uint32           `nm:"route-table"`        // Routing table of the routes, 0 for the default.
+	IgnoreAutoRoutes bool             `nm:"ignore-auto-routes"` // Whether to ignore the routes obtained automatically.
+	IgnoreAutoDns    bool             `nm:"ignore-auto-dns"`    // Whether to ignore the DNS servers obtained automatically.
+	NeverDefault     bool             `nm:"never-default"`      // Whether the connection never gets the default route.
+	MayFail          *bool            `nm:"may-fail"`           // Whether the connection may activate without IPv6. Defaults to true.
+	Ip6Privacy       *int32           `nm:"ip6-privacy"`        // Privacy extensions: -1 default, 0 disabled, 1 prefer public, 2 prefer temporary addresses. Defaults to -1.
+	AddrGenMode      *int32           `nm:"addr-gen-mode"`      // Interface identifier generation: 0 EUI-64, 1 stable privacy. Defaults to 1.
+	DhcpDuid         string           `nm:"dhcp-duid"`          // DUID sent to the DHCPv6 server.
+	DhcpSendHostname *bool            `nm:"dhcp-send-hostname"` // Whether the hostname is sent to the DHCPv6 server. Defaults to true.
+	DhcpHostname     string           `nm:"dhcp-hostname"`      // Hostname sent to the DHCPv6 server.
+	Token            string           `nm:"token"`              // Interface identifier used with the EUI-64 generation mode.
+}
+
+func (s *SettingIP6Config) SettingName() string {
+	return SettingIP6ConfigSettingName
+}
@@ -0,0 +1,24 @@
+package gonetworkmanager
+
+const (
+	SettingMacvlanSettingName = "macvlan"
+
+	/* Modes */
+	SettingMacvlanModeVepa     = 1
+	SettingMacvlanModeBridge   = 2
+	SettingMacvlanModePrivate  = 3
+	SettingMacvlanModePassthru = 4
+	SettingMacvlanModeSource   = 5
+)
+
+// SettingMacvlan is the "macvlan" setting of MACVLAN connections.
+type SettingMacvlan struct {
+	Parent      string `nm:"parent"`      // Interface name or connection UUID of the parent device.
+	Mode        uint32 `nm:"mode"`        // MACVLAN mode, one of the SettingMacvlanMode constants.
+	Promiscuous *bool  `nm:"promiscuous"` // Whether the interface may go into promiscuous mode. Defaults to true.
+	Tap         bool   `nm:"tap"`         // Whether the interface is a MACVTAP.
+}
+
+func (s *SettingMacvlan) SettingName() string {
+	return SettingMacvlanSettingName
+}
@@ -0,0 +1,62 @@
+package gonetworkmanager
+
+const (
+	SettingOvsBridgeSettingName    = "ovs-bridge"
+	SettingOvsPortSettingName      = "ovs-port"
+	SettingOvsInterfaceSettingName = "ovs-interface"
+	SettingOvsPatchSettingName     = "ovs-patch"
+
+	/* Interface types */
+	SettingOvsInterfaceTypeInternal = "internal"
+	SettingOvsInterfaceTypeSystem   = "system"
+	SettingOvsInterfaceTypePatch    = "patch"
+	SettingOvsInterfaceTypeDpdk     = "dpdk"
+)
+
+// SettingOvsBridge is the "ovs-bridge" setting of Open vSwitch bridge
+// connections.
+type SettingOvsBridge struct {
+	FailMode            string `nm:"fail-mode"`             // Behavior when the controller cannot be reached, "secure" or "standalone".
+	McastSnoopingEnable bool   `nm:"mcast-snooping-enable"` // Whether multicast snooping is enabled.
+	RstpEnable          bool   `nm:"rstp-enable"`           // Whether the Rapid Spanning Tree Protocol is enabled.
+	StpEnable           bool   `nm:"stp-enable"`            // Whether the Spanning Tree Protocol is enabled.
+	DatapathType        string `nm:"datapath-type"`         // Datapath type, "system" or "netdev".
+}
+
+func (s *SettingOvsBridge) SettingName() string {
+	return SettingOvsBridgeSettingName
+}
+
+// SettingOvsPort is the "ovs-port" setting of Open vSwitch port connections.
+type SettingOvsPort struct {
+	VlanMode      string `nm:"vlan-mode"`      // VLAN mode, "access", "native-tagged", "native-untagged", "trunk" or "dot1q-tunnel".
+	Tag           uint32 `nm:"tag"`            // VLAN tag of access ports, 0 to 4095.
+	Lacp          string `nm:"lacp"`           // LACP mode of bonded ports, "active", "off" or "passive".
+	BondMode      string `nm:"bond-mode"`      // Bonding mode, "active-backup", "balance-slb" or "balance-tcp".
+	BondUpdelay   uint32 `nm:"bond-updelay"`   // Milliseconds to wait before enabling a bond slave.
+	BondDowndelay uint32 `nm:"bond-downdelay"` // Milliseconds to wait before disabling a bond slave.
+}
+
+func (s *SettingOvsPort) SettingName() string {
+	return SettingOvsPortSettingName
+}
+
+// SettingOvsInterface is the "ovs-interface" setting of Open vSwitch
+// interface connections.
+type SettingOvsInterface struct {
+	Type string `nm:"type"` // Interface type, one of the SettingOvsInterfaceType constants.
+}
+
+func (s *SettingOvsInterface) SettingName() string {
+	return SettingOvsInterfaceSettingName
+}
+
+// SettingOvsPatch is the "ovs-patch" setting of Open vSwitch interfaces of the
+// patch type.
+type SettingOvsPatch struct {
+	Peer string `nm:"peer"` // Name of the patch interface on the other side.
+}
+
+func (s *SettingOvsPatch) SettingName() string {
+	return SettingOvsPatchSettingName
+}
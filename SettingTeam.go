@@ -0,0 +1,14 @@
+package gonetworkmanager
+
+const (
+	SettingTeamSettingName = "team"
+)
+
+// SettingTeam is the "team" setting of team master connections.
+type SettingTeam struct {
+	Config string `nm:"config"` // JSON configuration of teamd, e.g. {"runner": {"name": "activebackup"}}.
+}
+
+func (s *SettingTeam) SettingName() string {
+	return SettingTeamSettingName
+}
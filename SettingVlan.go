@@ -0,0 +1,18 @@
+package gonetworkmanager
+
+const (
+	SettingVlanSettingName = "vlan"
+)
+
+// SettingVlan is the "vlan" setting of VLAN connections.
+type SettingVlan struct {
+	Parent             string   `nm:"parent"`               // Interface name or connection UUID of the parent device.
+	Id                 uint32   `nm:"id"`                   // VLAN identifier, 0 to 4094.
+	Flags              *uint32  `nm:"flags"`                // VLAN flags: 0x1 reorder headers, 0x2 GVRP, 0x4 loose binding, 0x8 MVRP. Defaults to 0x1.
+	IngressPriorityMap []string `nm:"ingress-priority-map"` // Mappings of the 802.1p priority of incoming frames to Linux priorities, as "from:to".
+	EgressPriorityMap  []string `nm:"egress-priority-map"`  // Mappings of Linux priorities to the 802.1p priority of outgoing frames, as "from:to".
+}
+
+func (s *SettingVlan) SettingName() string {
+	return SettingVlanSettingName
+}
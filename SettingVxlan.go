@@ -0,0 +1,29 @@
+package gonetworkmanager
+
+const (
+	SettingVxlanSettingName = "vxlan"
+)
+
+// SettingVxlan is the "vxlan" setting of VXLAN connections.
+type SettingVxlan struct {
+	Parent          string  `nm:"parent"`           // Interface name or connection UUID of the parent device.
+	Id              uint32  `nm:"id"`               // VXLAN Network Identifier, 0 to 16777215.
+	Local           string  `nm:"local"`            // Local IPv4 or IPv6 address to send the packets from.
+	Remote          string  `nm:"remote"`           // Unicast destination or multicast group IP address of the packets. Required.
+	SourcePortMin   uint32  `nm:"source-port-min"`  // Lowest UDP source port of the packets.
+	SourcePortMax   uint32  `nm:"source-port-max"`  // Highest UDP source port of the packets.
+	DestinationPort uint32  `nm:"destination-port"` // UDP destination port of the packets. NetworkManager defaults to 8472.
+	Tos             uint32  `nm:"tos"`              // TOS value of the packets.
+	Ttl             uint32  `nm:"ttl"`              // TTL of the packets, 0 to inherit it.
+	Ageing          *uint32 `nm:"ageing"`           // Lifetime in seconds of the learned FDB entries, 0 to keep them forever. Defaults to 300.
+	Limit           uint32  `nm:"limit"`            // Maximum number of FDB entries, 0 for no limit.
+	Learning        *bool   `nm:"learning"`         // Whether unknown source link layer addresses are learned. Defaults to true.
+	Proxy           bool    `nm:"proxy"`            // Whether ARP proxy is turned on.
+	Rsc             bool    `nm:"rsc"`              // Whether route short circuit is turned on.
+	L2Miss          bool    `nm:"l2-miss"`          // Whether netlink LL ADDR miss notifications are generated.
+	L3Miss          bool    `nm:"l3-miss"`          // Whether netlink IP ADDR miss notifications are generated.
+}
+
+func (s *SettingVxlan) SettingName() string {
+	return SettingVxlanSettingName
+}
@@ -0,0 +1,73 @@
+package gonetworkmanager
+
+import "github.com/godbus/dbus/v5"
+
+const (
+	SettingWireGuardSettingName = "wireguard"
+)
+
+// SettingWireGuard is the "wireguard" setting of WireGuard connections.
+type SettingWireGuard struct {
+	PrivateKey      string          `nm:"private-key"`       // Base64 private key of the interface. Secret.
+	PrivateKeyFlags uint32          `nm:"private-key-flags"` // Flags of the private key secret.
+	ListenPort      uint32          `nm:"listen-port"`       // UDP port to listen on, 0 to pick one randomly.
+	Fwmark          uint32          `nm:"fwmark"`            // Firewall mark of the outgoing packets, 0 to disable it.
+	PeerRoutes      *bool           `nm:"peer-routes"`       // Whether routes to the allowed IPs of the peers are added. Defaults to true.
+	Mtu             uint32          `nm:"mtu"`               // MTU of the interface, 0 to keep the default.
+	Peers           []WireGuardPeer `nm:"peers"`             // The peers of the interface.
+}
+
+func (s *SettingWireGuard) SettingName() string {
+	return SettingWireGuardSettingName
+}
+
+// WireGuardPeer is one peer of a SettingWireGuard.
+type WireGuardPeer struct {
+	PublicKey           string   // Base64 public key of the peer, identifying it.
+	Endpoint            string   // Address and port of the peer, e.g. "203.0.113.1:51820" or "[2001:db8::1]:51820".
+	AllowedIps          []string // Networks routed to the peer, e.g. "10.0.0.0/24".
+	PresharedKey        string   // Base64 preshared key, for post-quantum resistance. Secret.
+	PresharedKeyFlags   uint32   // Flags of the preshared key secret.
+	PersistentKeepalive uint32   // Interval in seconds of the keepalive packets, 0 to disable them.
+}
+
+func wireGuardPeersToVariants(peers []WireGuardPeer) []map[string]dbus.Variant {
+	data := make([]map[string]dbus.Variant, 0, len(peers))
+	for _, peer := range peers {
+		d := map[string]dbus.Variant{
+			"public-key": dbus.MakeVariant(peer.PublicKey),
+		}
+		if peer.Endpoint != "" {
+			d["endpoint"] = dbus.MakeVariant(peer.Endpoint)
+		}
+		if peer.AllowedIps != nil {
+			d["allowed-ips"] = dbus.MakeVariant(peer.AllowedIps)
+		}
+		if peer.PresharedKey != "" {
+			// The flags always go along with the key, whose default flags
+			// would make NetworkManager ignore it.
+			d["preshared-key"] = dbus.MakeVariant(peer.PresharedKey)
+			d["preshared-key-flags"] = dbus.MakeVariant(peer.PresharedKeyFlags)
+		}
+		if peer.PersistentKeepalive != 0 {
+			d["persistent-keepalive"] = dbus.MakeVariant(peer.PersistentKeepalive)
+		}
+		data = append(data, d)
+	}
+	return data
+}
+
+func wireGuardPeersFromVariants(data []map[string]dbus.Variant) []WireGuardPeer {
+	peers := make([]WireGuardPeer, 0, len(data))
+	for _, d := range data {
+		var peer WireGuardPeer
+		peer.PublicKey, _ = d["public-key"].Value().(string)
+		peer.Endpoint, _ = d["endpoint"].Value().(string)
+		peer.AllowedIps, _ = d["allowed-ips"].Value().([]string)
+		peer.PresharedKey, _ = d["preshared-key"].Value().(string)
+		peer.PresharedKeyFlags, _ = d["preshared-key-flags"].Value().(uint32)
+		peer.PersistentKeepalive, _ = d["persistent-keepalive"].Value().(uint32)
+		peers = append(peers, peer)
+	}
+	return peers
+}
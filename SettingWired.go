@@ -0,0 +1,30 @@
+package gonetworkmanager
+
+import "net"
+
+const (
+	SettingWiredSettingName = "802-3-ethernet"
+)
+
+// SettingWired is the "802-3-ethernet" setting of wired connections.
+type SettingWired struct {
+	Port                   string            `nm:"port"`                      // Specific port type to use, e.g. "tp" or "mii". Deprecated by NetworkManager.
+	Speed                  uint32            `nm:"speed"`                     // Fixed link speed in Mbit/s, used when AutoNegotiate is false.
+	Duplex                 string            `nm:"duplex"`                    // Fixed duplex mode, "half" or "full", used when AutoNegotiate is false.
+	AutoNegotiate          bool              `nm:"auto-negotiate"`            // Whether speed and duplex are auto-negotiated.
+	MacAddress             net.HardwareAddr  `nm:"mac-address"`               // Restrict the connection to the device with this permanent MAC address.
+	ClonedMacAddress       net.HardwareAddr  `nm:"cloned-mac-address"`        // MAC address to set on the device.
+	AssignedMacAddress     string            `nm:"assigned-mac-address"`      // MAC address to set, or one of "preserve", "permanent", "random" and "stable".
+	GenerateMacAddressMask string            `nm:"generate-mac-address-mask"` // Mask applied to generated MAC addresses.
+	MacAddressBlacklist    []string          `nm:"mac-address-blacklist"`     // Devices with these MAC addresses never match the connection.
+	Mtu                    uint32            `nm:"mtu"`                       // MTU of the device, 0 to keep the default.
+	S390Subchannels        []string          `nm:"s390-subchannels"`          // Subchannels of s390 network devices.
+	S390Nettype            string            `nm:"s390-nettype"`              // Network type of s390 devices, "qeth", "lcs" or "ctc".
+	S390Options            map[string]string `nm:"s390-options"`              // Driver options of s390 devices.
+	WakeOnLan              *uint32           `nm:"wake-on-lan"`               // Wake-on-LAN options, a combination of NM_SETTING_WIRED_WAKE_ON_LAN flags, 0 to disable it. Defaults to 1, the global default.
+	WakeOnLanPassword      string            `nm:"wake-on-lan-password"`      // Password for the Wake-on-LAN magic packet, as a MAC address.
+}
+
+func (s *SettingWired) SettingName() string {
+	return SettingWiredSettingName
+}
@@ -0,0 +1,42 @@
+package gonetworkmanager
+
+import "net"
+
+const (
+	SettingWirelessSettingName = "802-11-wireless"
+
+	/* Modes */
+	SettingWirelessModeInfrastructure = "infrastructure"
+	SettingWirelessModeAdhoc          = "adhoc"
+	SettingWirelessModeAp             = "ap"
+
+	/* Bands */
+	SettingWirelessBandA  = "a"
+	SettingWirelessBandBg = "bg"
+)
+
+// SettingWireless is the "802-11-wireless" setting of Wi-Fi connections.
+type SettingWireless struct {
+	Ssid                    []byte           `nm:"ssid"`                      // SSID of the network.
+	Mode                    string           `nm:"mode"`                      // Wi-Fi mode, one of the SettingWirelessMode constants.
+	Band                    string           `nm:"band"`                      // Frequency band, SettingWirelessBandA (5 GHz) or SettingWirelessBandBg (2.4 GHz).
+	Channel                 uint32           `nm:"channel"`                   // Channel to use, which requires Band to be set.
+	Bssid                   net.HardwareAddr `nm:"bssid"`                     // Restrict the connection to the access point with this BSSID.
+	Rate                    uint32           `nm:"rate"`                      // Fixed bitrate in Kbit/s. Deprecated by NetworkManager.
+	TxPower                 uint32           `nm:"tx-power"`                  // Fixed transmit power in mW. Deprecated by NetworkManager.
+	MacAddress              net.HardwareAddr `nm:"mac-address"`               // Restrict the connection to the device with this permanent MAC address.
+	ClonedMacAddress        net.HardwareAddr `nm:"cloned-mac-address"`        // MAC address to set on the device.
+	AssignedMacAddress      string           `nm:"assigned-mac-address"`      // MAC address to set, or one of "preserve", "permanent", "random" and "stable".
+	GenerateMacAddressMask  string           `nm:"generate-mac-address-mask"` // Mask applied to generated MAC addresses.
+	MacAddressBlacklist     []string         `nm:"mac-address-blacklist"`     // Devices with these MAC addresses never match the connection.
+	MacAddressRandomization uint32           `nm:"mac-address-randomization"` // Deprecated in favor of AssignedMacAddress.
+	Mtu                     uint32           `nm:"mtu"`                       // MTU of the device, 0 to keep the default.
+	SeenBssids              []string         `nm:"seen-bssids"`               // BSSIDs of the access points the connection was seen on. Read-only.
+	Hidden                  bool             `nm:"hidden"`                    // Whether the network does not broadcast its SSID.
+	Powersave               uint32           `nm:"powersave"`                 // Wi-Fi power saving: 0 default, 1 ignore, 2 disable, 3 enable.
+	WakeOnWlan              *uint32          `nm:"wake-on-wlan"`              // Wake-on-WLAN options, a combination of NM_SETTING_WIRELESS_WAKE_ON_WLAN flags, 0 to disable it. Defaults to 1, the global default.
+}
+
+func (s *SettingWireless) SettingName() string {
+	return SettingWirelessSettingName
+}
@@ -0,0 +1,48 @@
+package gonetworkmanager
+
+const (
+	SettingWirelessSecuritySettingName = "802-11-wireless-security"
+
+	/* Key management */
+	SettingWirelessSecurityKeyMgmtNone            = "none"                // WEP
+	SettingWirelessSecurityKeyMgmtIeee8021x       = "ieee8021x"           // Dynamic WEP
+	SettingWirelessSecurityKeyMgmtWpaPsk          = "wpa-psk"             // WPA2 and WPA3 personal with a pre-shared key
+	SettingWirelessSecurityKeyMgmtWpaEap          = "wpa-eap"             // WPA2 and WPA3 enterprise
+	SettingWirelessSecurityKeyMgmtWpaEapSuiteB192 = "wpa-eap-suite-b-192" // WPA3 enterprise 192-bit mode
+	SettingWirelessSecurityKeyMgmtSae             = "sae"                 // WPA3 personal
+	SettingWirelessSecurityKeyMgmtOwe             = "owe"                 // Opportunistic Wireless Encryption (Enhanced Open)
+
+	/* Authentication algorithms */
+	SettingWirelessSecurityAuthAlgOpen   = "open"
+	SettingWirelessSecurityAuthAlgShared = "shared"
+	SettingWirelessSecurityAuthAlgLeap   = "leap"
+)
+
+// SettingWirelessSecurity is the "802-11-wireless-security" setting of
+// protected Wi-Fi connections.
+type SettingWirelessSecurity struct {
+	KeyMgmt           string   `nm:"key-mgmt"`            // Key management, one of the SettingWirelessSecurityKeyMgmt constants.
+	WepTxKeyidx       uint32   `nm:"wep-tx-keyidx"`       // Index of the WEP key to use, 0 to 3.
+	AuthAlg           string   `nm:"auth-alg"`            // Authentication algorithm, one of the SettingWirelessSecurityAuthAlg constants.
+	Proto             []string `nm:"proto"`               // Allowed WPA protocol versions, "wpa" and/or "rsn".
+	Pairwise          []string `nm:"pairwise"`            // Allowed pairwise ciphers, "tkip" and/or "ccmp".
+	Group             []string `nm:"group"`               // Allowed group ciphers, "wep40", "wep104", "tkip" and/or "ccmp".
+	Pmf               int32    `nm:"pmf"`                 // Protected Management Frames: 0 default, 1 disable, 2 optional, 3 required.
+	LeapUsername      string   `nm:"leap-username"`       // LEAP user name.
+	WepKey0           string   `nm:"wep-key0"`            // WEP key at index 0.
+	WepKey1           string   `nm:"wep-key1"`            // WEP key at index 1.
+	WepKey2           string   `nm:"wep-key2"`            // WEP key at index 2.
+	WepKey3           string   `nm:"wep-key3"`            // WEP key at index 3.
+	WepKeyFlags       uint32   `nm:"wep-key-flags"`       // Secret flags of the WEP keys.
+	WepKeyType        uint32   `nm:"wep-key-type"`        // Interpretation of the WEP keys: 1 hexadecimal or ASCII key, 2 passphrase.
+	Psk               string   `nm:"psk"`                 // Pre-shared key, 8 to 63 characters or 64 hexadecimal digits.
+	PskFlags          uint32   `nm:"psk-flags"`           // Secret flags of Psk.
+	LeapPassword      string   `nm:"leap-password"`       // LEAP password.
+	LeapPasswordFlags uint32   `nm:"leap-password-flags"` // Secret flags of LeapPassword.
+	WpsMethod         uint32   `nm:"wps-method"`          // Allowed WPS methods.
+	Fils              int32    `nm:"fils"`                // Fast Initial Link Setup: 0 default, 1 disable, 2 optional, 3 required.
+}
+
+func (s *SettingWirelessSecurity) SettingName() string {
+	return SettingWirelessSecuritySettingName
+}
@@ -0,0 +1,289 @@
+package gonetworkmanager_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func int32p(v int32) *int32    { return &v }
+func int64p(v int64) *int64    { return &v }
+func uint32p(v uint32) *uint32 { return &v }
+func boolp(v bool) *bool       { return &v }
+
+// testSettings returns a valid ethernet profile using most kinds of keys,
+// with pointers set to their zero value.
+func testSettings() []gnm.Setting {
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	return []gnm.Setting{
+		&gnm.SettingConnection{
+			Id:                 "test",
+			Uuid:               "0b8d1c36-9a4e-4cf2-8a3a-1b9b8cdbd7c1",
+			Type:               gnm.SettingWiredSettingName,
+			InterfaceName:      "eth0",
+			Autoconnect:        boolp(false),
+			AutoconnectRetries: int32p(0),
+			Permissions:        []string{"user:alice"},
+		},
+		&gnm.SettingWired{
+			MacAddress: mac,
+			Mtu:        1400,
+			WakeOnLan:  uint32p(0),
+		},
+		&gnm.SettingIP4Config{
+			Method:      gnm.SettingIP4ConfigMethodManual,
+			Dns:         []string{"192.168.1.1", "1.1.1.1"},
+			AddressData: []gnm.IP4AddressData{{Address: "192.168.1.10", Prefix: 24}},
+			Gateway:     "192.168.1.1",
+			RouteData: []gnm.IP4RouteData{{
+				Destination:          "10.0.0.0",
+				Prefix:               8,
+				NextHop:              "192.168.1.2",
+				Metric:               uint32p(600),
+				AdditionalAttributes: map[string]string{"table": "100", "onlink": "true", "src": "192.168.1.10"},
+			}},
+			RouteMetric: int64p(0),
+			DadTimeout:  int32p(0),
+		},
+		&gnm.SettingIP6Config{
+			Method:      gnm.SettingIP6ConfigMethodManual,
+			Dns:         []string{"2001:db8::53"},
+			AddressData: []gnm.IP6AddressData{{Address: "2001:db8::10", Prefix: 64}},
+			Ip6Privacy:  int32p(0),
+			AddrGenMode: int32p(0),
+		},
+	}
+}
+
+func TestConnectionSettingsRoundTrip(t *testing.T) {
+	settings := testSettings()
+
+	cs, err := gnm.NewConnectionSettings(settings...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cs[gnm.SettingConnectionSettingName]["autoconnect-retries"]; got != int32(0) {
+		t.Errorf("connection.autoconnect-retries = %#v, want int32(0)", got)
+	}
+	if _, ok := cs[gnm.SettingConnectionSettingName]["autoconnect-priority"]; ok {
+		t.Error("connection.autoconnect-priority is sent while unset")
+	}
+
+	routes, _ := cs[gnm.SettingIP4ConfigSettingName]["route-data"].([]map[string]dbus.Variant)
+	if len(routes) != 1 {
+		t.Fatalf("ipv4.route-data = %#v, want one route", cs[gnm.SettingIP4ConfigSettingName]["route-data"])
+	}
+	for name, want := range map[string]interface{}{"table": uint32(100), "onlink": true, "src": "192.168.1.10"} {
+		if got := routes[0][name].Value(); got != want {
+			t.Errorf("route attribute %s = %#v, want %#v", name, got, want)
+		}
+	}
+
+	if err := gnm.ValidateSettings(cs); err != nil {
+		t.Fatalf("ValidateSettings() = %v", err)
+	}
+
+	for _, want := range settings {
+		got := reflect.New(reflect.TypeOf(want).Elem()).Interface().(gnm.Setting)
+		if err := cs.GetSetting(got); err != nil {
+			t.Fatalf("GetSetting(%s) = %v", want.SettingName(), err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %+v, want %+v", want.SettingName(), got, want)
+		}
+	}
+}
+
+func TestRouteMetric(t *testing.T) {
+	tests := []struct {
+		metric *uint32
+		want   interface{}
+	}{
+		{nil, nil},
+		{uint32p(0), uint32(0)},
+		{uint32p(600), uint32(600)},
+	}
+
+	for _, tt := range tests {
+		want := &gnm.SettingIP6Config{
+			Method:    gnm.SettingIP6ConfigMethodAuto,
+			RouteData: []gnm.IP6RouteData{{Destination: "2001:db8:1::", Prefix: 48, Metric: tt.metric}},
+		}
+		cs, err := gnm.NewConnectionSettings(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		routes := cs[gnm.SettingIP6ConfigSettingName]["route-data"].([]map[string]dbus.Variant)
+		var got interface{}
+		if metric, ok := routes[0]["metric"]; ok {
+			got = metric.Value()
+		}
+		if got != tt.want {
+			t.Errorf("metric = %#v, want %#v", got, tt.want)
+		}
+
+		var decoded gnm.SettingIP6Config
+		if err := cs.GetSetting(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(&decoded, want) {
+			t.Errorf("GetSetting() = %+v, want %+v", decoded, want)
+		}
+	}
+}
+
+func TestRouteAttributeInvalid(t *testing.T) {
+	_, err := gnm.NewConnectionSettings(&gnm.SettingIP4Config{
+		Method:    gnm.SettingIP4ConfigMethodAuto,
+		RouteData: []gnm.IP4RouteData{{Destination: "10.0.0.0", Prefix: 8, AdditionalAttributes: map[string]string{"table": "main"}}},
+	})
+	if err == nil {
+		t.Error("NewConnectionSettings() with a table that is not a number succeeded")
+	}
+}
+
+func TestConnectionSettingsOverDBus(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	settings := testSettings()
+	cs, err := gnm.NewConnectionSettings(settings...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := s.AddConnection(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The settings as NetworkManager returns them, every value decoded by
+	// godbus.
+	read, err := c.GetSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gnm.ValidateSettings(read); err != nil {
+		t.Errorf("ValidateSettings() of the settings read back = %v", err)
+	}
+
+	for _, want := range settings {
+		got := reflect.New(reflect.TypeOf(want).Elem()).Interface().(gnm.Setting)
+		if err := read.GetSetting(got); err != nil {
+			t.Fatalf("GetSetting(%s) = %v", want.SettingName(), err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %+v, want %+v", want.SettingName(), got, want)
+		}
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	base := func(settings ...map[string]map[string]interface{}) gnm.ConnectionSettings {
+		cs := gnm.ConnectionSettings{
+			"connection": {"id": "test", "uuid": "0b8d1c36-9a4e-4cf2-8a3a-1b9b8cdbd7c1", "type": gnm.SettingWiredSettingName},
+		}
+		for _, s := range settings {
+			for name, values := range s {
+				cs[name] = values
+			}
+		}
+		return cs
+	}
+
+	tests := []struct {
+		name     string
+		settings gnm.ConnectionSettings
+		want     []string // setting.key of the expected errors
+	}{
+		{
+			name:     "auto",
+			settings: base(map[string]map[string]interface{}{"ipv4": {"method": "auto"}, "ipv6": {"method": "auto"}}),
+		},
+		{
+			name:     "ipv6 disabled",
+			settings: base(map[string]map[string]interface{}{"ipv6": {"method": "disabled"}}),
+		},
+		{
+			name: "legacy ipv4 addresses",
+			settings: base(map[string]map[string]interface{}{"ipv4": {
+				"method":    "manual",
+				"addresses": [][]uint32{{0x0a01a8c0, 24, 0x0101a8c0}},
+				"routes":    [][]uint32{{0x0000000a, 8, 0x0201a8c0, 0}},
+				"gateway":   "192.168.1.1",
+			}}),
+		},
+		{
+			name:     "missing connection",
+			settings: gnm.ConnectionSettings{"ipv4": {"method": "auto"}},
+			want:     []string{"connection"},
+		},
+		{
+			name: "invalid uuid and method",
+			settings: gnm.ConnectionSettings{
+				"connection": {"id": "test", "uuid": "nope", "type": gnm.SettingWiredSettingName},
+				"ipv4":       {"method": "static"},
+			},
+			want: []string{"connection.uuid", "ipv4.method"},
+		},
+		{
+			name:     "manual without address",
+			settings: base(map[string]map[string]interface{}{"ipv4": {"method": "manual", "gateway": "192.168.1.1"}}),
+			want:     []string{"ipv4.address-data", "ipv4.gateway"},
+		},
+		{
+			name:     "wrong type",
+			settings: base(map[string]map[string]interface{}{"ipv4": {"method": "auto", "dns-priority": "high"}}),
+			want:     []string{"ipv4.dns-priority"},
+		},
+		{
+			name:     "wrong legacy type",
+			settings: base(map[string]map[string]interface{}{"ipv4": {"method": "manual", "addresses": []string{"192.168.1.10/24"}}}),
+			want:     []string{"ipv4.addresses", "ipv4.address-data"},
+		},
+		{
+			name:     "addresses with ipv6 disabled",
+			settings: base(map[string]map[string]interface{}{"ipv6": {"method": "disabled", "address-data": []map[string]interface{}{{"address": "2001:db8::10", "prefix": uint32(64)}}}}),
+			want:     []string{"ipv6.address-data"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gnm.ValidateSettings(tt.settings)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateSettings() = %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(gnm.ValidationErrors)
+			if !ok {
+				t.Fatalf("ValidateSettings() = %v, want ValidationErrors", err)
+			}
+			var got []string
+			for _, e := range errs {
+				if e.Key == "" {
+					got = append(got, e.Setting)
+				} else {
+					got = append(got, e.Setting+"."+e.Key)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateSettings() = %v, want errors for %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package gonetworkmanager
 
 import (
+	"context"
+
 	"github.com/godbus/dbus/v5"
 )
 
@@ -24,23 +26,58 @@ const (
 )
 
 type Settings interface {
+	// WithValidation returns a copy of the settings object whose AddConnection and AddConnectionUnsaved methods, with or without a context, check the settings with ValidateSettings and return its error instead of sending an invalid profile to NetworkManager. The connections it returns are made with WithValidation too.
+	WithValidation() Settings
+
+	// WithoutCache returns a copy of the settings object whose property getters, with or without a context, read the properties over D-Bus even when a Cache is enabled.
+	WithoutCache() Settings
+
 	// ListConnections gets list the saved network connections known to NetworkManager
 	ListConnections() ([]Connection, error)
+	ListConnectionsContext(ctx context.Context) ([]Connection, error)
+
+	// Retrieve the object path of a connection, given that connection's UUID.
+	GetConnectionByUuid(uuid string) (Connection, error)
+	GetConnectionByUuidContext(ctx context.Context, uuid string) (Connection, error)
+
+	// GetConnectionIndex reads the settings of every saved connection once, to look connections up by id, interface name, type or SSID.
+	GetConnectionIndex() (*ConnectionIndex, error)
+	GetConnectionIndexContext(ctx context.Context) (*ConnectionIndex, error)
 
 	// AddConnection adds new connection and save it to disk.
 	AddConnection(settings ConnectionSettings) (Connection, error)
+	AddConnectionContext(ctx context.Context, settings ConnectionSettings) (Connection, error)
+
+	// AddConnectionFromSettings adds a new connection built from typed settings, see NewConnectionSettings, and saves it to disk.
+	AddConnectionFromSettings(settings ...Setting) (Connection, error)
+	AddConnectionFromSettingsContext(ctx context.Context, settings ...Setting) (Connection, error)
 
 	// Add new connection but do not save it to disk immediately. This operation does not start the network connection unless (1) device is idle and able to connect to the network described by the new connection, and (2) the connection is allowed to be started automatically. Use the 'Save' method on the connection to save these changes to disk. Note that unsaved changes will be lost if the connection is reloaded from disk (either automatically on file change or due to an explicit ReloadConnections call).
 	AddConnectionUnsaved(settings ConnectionSettings) (Connection, error)
+	AddConnectionUnsavedContext(ctx context.Context, settings ConnectionSettings) (Connection, error)
+
+	// Loads or reloads the indicated connections from disk. You should call this after making changes directly to an on-disk connection file to make sure that NetworkManager sees the changes. (If "monitor-connection-files" in NetworkManager.conf is "true", then this will have no real effect, but is harmless.) As with AddConnection(), this operation does not necessarily start the network connection.
+	// filenames: Array of paths to on-disk connection profiles in directories monitored by NetworkManager.
+	// returns: Success or failure of the operation as a whole. True if NetworkManager at least tried to load the indicated connections, even if it did not succeed. False if an error occurred before trying to load the connections (eg, permission denied), and the paths of the files that could not be loaded.
+	LoadConnections(filenames []string) (bool, []string, error)
+	LoadConnectionsContext(ctx context.Context, filenames []string) (bool, []string, error)
+
+	// Tells NetworkManager to reload all connection files from disk, including noticing any added or deleted connection files. By default, connections are re-read automatically any time they change, so you only need to use this command if you have set "monitor-connection-files=false" in NetworkManager.conf.
+	// returns: This always returns true.
+	ReloadConnections() (bool, error)
+	ReloadConnectionsContext(ctx context.Context) (bool, error)
 
 	// Save the hostname to persistent configuration.
 	SaveHostname(hostname string) error
+	SaveHostnameContext(ctx context.Context, hostname string) error
 
 	// If true, adding and modifying connections is supported.
 	GetPropertyCanModify() (bool, error)
+	GetPropertyCanModifyContext(ctx context.Context) (bool, error)
 
 	// The machine hostname stored in persistent configuration.
 	GetPropertyHostname() (string, error)
+	GetPropertyHostnameContext(ctx context.Context) (string, error)
 }
 
 func NewSettings() (Settings, error) {
@@ -48,14 +85,47 @@ func NewSettings() (Settings, error) {
 	return &s, s.init(NetworkManagerInterface, SettingsObjectPath)
 }
 
+func NewSettingsWithConn(conn *dbus.Conn) (Settings, error) {
+	var s settings
+	return &s, s.initWithConn(conn, NetworkManagerInterface, SettingsObjectPath)
+}
+
 type settings struct {
 	dbusBase
+
+	validate bool
+}
+
+func (s *settings) WithValidation() Settings {
+	copied := *s
+	copied.validate = true
+	return &copied
+}
+
+func (s *settings) WithoutCache() Settings {
+	copied := *s
+	copied.uncached = true
+	return &copied
+}
+
+// newConnection returns a connection that validates the profiles it sends
+// when s does.
+func (s *settings) newConnection(path dbus.ObjectPath) (Connection, error) {
+	c, err := NewConnectionWithConn(s.conn, path)
+	if err != nil || !s.validate {
+		return c, err
+	}
+	return c.WithValidation(), nil
 }
 
 func (s *settings) ListConnections() ([]Connection, error) {
+	return s.ListConnectionsContext(context.Background())
+}
+
+func (s *settings) ListConnectionsContext(ctx context.Context) ([]Connection, error) {
 	var connectionPaths []dbus.ObjectPath
 
-	err := s.callWithReturn(&connectionPaths, SettingsListConnections)
+	err := s.callWithReturn(ctx, &connectionPaths, SettingsListConnections)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +133,7 @@ func (s *settings) ListConnections() ([]Connection, error) {
 	connections := make([]Connection, len(connectionPaths))
 
 	for i, path := range connectionPaths {
-		connections[i], err = NewConnection(path)
+		connections[i], err = s.newConnection(path)
 		if err != nil {
 			return connections, err
 		}
@@ -73,34 +143,120 @@ func (s *settings) ListConnections() ([]Connection, error) {
 }
 
 func (s *settings) AddConnection(settings ConnectionSettings) (Connection, error) {
+	return s.AddConnectionContext(context.Background(), settings)
+}
+
+func (s *settings) AddConnectionContext(ctx context.Context, settings ConnectionSettings) (Connection, error) {
+	if err := validateBeforeSend(s.validate, settings); err != nil {
+		return nil, err
+	}
+
 	var path dbus.ObjectPath
-	err := s.callWithReturn(&path, SettingsAddConnection, settings)
+	err := s.callWithReturn(ctx, &path, SettingsAddConnection, settings)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewConnection(path)
+	return s.newConnection(path)
+}
+
+func (s *settings) AddConnectionFromSettings(settings ...Setting) (Connection, error) {
+	return s.AddConnectionFromSettingsContext(context.Background(), settings...)
+}
+
+func (s *settings) AddConnectionFromSettingsContext(ctx context.Context, settings ...Setting) (Connection, error) {
+	connectionSettings, err := NewConnectionSettings(settings...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.AddConnectionContext(ctx, connectionSettings)
 }
 
 func (s *settings) AddConnectionUnsaved(settings ConnectionSettings) (Connection, error) {
+	return s.AddConnectionUnsavedContext(context.Background(), settings)
+}
+
+func (s *settings) AddConnectionUnsavedContext(ctx context.Context, settings ConnectionSettings) (Connection, error) {
+	if err := validateBeforeSend(s.validate, settings); err != nil {
+		return nil, err
+	}
+
 	var path dbus.ObjectPath
-	err := s.callWithReturn(&path, SettingsAddConnectionUnsaved, settings)
+	err := s.callWithReturn(ctx, &path, SettingsAddConnectionUnsaved, settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newConnection(path)
+}
+
+func (s *settings) GetConnectionByUuid(uuid string) (Connection, error) {
+	return s.GetConnectionByUuidContext(context.Background(), uuid)
+}
 
+func (s *settings) GetConnectionByUuidContext(ctx context.Context, uuid string) (Connection, error) {
+	var path dbus.ObjectPath
+	err := s.callWithReturn(ctx, &path, SettingsGetConnectionByUuid, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewConnection(path)
+	return s.newConnection(path)
+}
+
+func (s *settings) GetConnectionIndex() (*ConnectionIndex, error) {
+	return s.GetConnectionIndexContext(context.Background())
+}
+
+func (s *settings) GetConnectionIndexContext(ctx context.Context) (*ConnectionIndex, error) {
+	connections, err := s.ListConnectionsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConnectionIndex(ctx, connections)
+}
+
+func (s *settings) LoadConnections(filenames []string) (bool, []string, error) {
+	return s.LoadConnectionsContext(context.Background(), filenames)
+}
+
+func (s *settings) LoadConnectionsContext(ctx context.Context, filenames []string) (status bool, failures []string, err error) {
+	err = s.callWithReturn2(ctx, &status, &failures, SettingsLoadConnections, filenames)
+	return
+}
+
+func (s *settings) ReloadConnections() (bool, error) {
+	return s.ReloadConnectionsContext(context.Background())
+}
+
+func (s *settings) ReloadConnectionsContext(ctx context.Context) (status bool, err error) {
+	err = s.callWithReturn(ctx, &status, SettingsReloadConnections)
+	return
 }
 
 func (s *settings) SaveHostname(hostname string) error {
-	return s.call(SettingsSaveHostname, hostname)
+	return s.SaveHostnameContext(context.Background(), hostname)
+}
+
+func (s *settings) SaveHostnameContext(ctx context.Context, hostname string) error {
+	return s.call(ctx, SettingsSaveHostname, hostname)
 }
 
 func (s *settings) GetPropertyHostname() (string, error) {
-	return s.getStringProperty(SettingsPropertyHostname)
+	return s.GetPropertyHostnameContext(context.Background())
+}
+
+func (s *settings) GetPropertyHostnameContext(ctx context.Context) (string, error) {
+	return s.getStringProperty(ctx, SettingsPropertyHostname)
 }
 
 func (s *settings) GetPropertyCanModify() (bool, error) {
-	return s.getBoolProperty(SettingsPropertyCanModify)
+	return s.GetPropertyCanModifyContext(context.Background())
+}
+
+func (s *settings) GetPropertyCanModifyContext(ctx context.Context) (bool, error) {
+	return s.getBoolProperty(ctx, SettingsPropertyCanModify)
 }
@@ -0,0 +1,122 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestGetConnectionByUuid(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	c, _ := f.addConnection(t, &gnm.SettingConnection{Id: "test", Uuid: "0b8d1c36-9a4e-4cf2-8a3a-1b9b8cdbd7c1", Type: gnm.SettingWiredSettingName})
+	f.addConnection(t)
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetConnectionByUuid("0b8d1c36-9a4e-4cf2-8a3a-1b9b8cdbd7c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetPath() != c.GetPath() {
+		t.Errorf("GetConnectionByUuid() = %s, want %s", got.GetPath(), c.GetPath())
+	}
+
+	if _, err := s.GetConnectionByUuid("9a1f0d54-2c5e-4b64-b1c7-0f0e5d0a3f10"); err == nil {
+		t.Error("GetConnectionByUuid() of an unknown uuid succeeded")
+	}
+}
+
+func TestConnectionIndex(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	wired, _ := f.addConnection(t, &gnm.SettingConnection{Id: "test", Type: gnm.SettingWiredSettingName, InterfaceName: "eth0"})
+
+	wifiSettings, err := gnm.NewConnectionSettings(
+		&gnm.SettingConnection{Id: "home", Type: gnm.SettingWirelessSettingName},
+		&gnm.SettingWireless{Ssid: []byte("home")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wifi := f.srv.AddConnection(wifiSettings)
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := s.GetConnectionIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := func(connections []gnm.Connection) []dbus.ObjectPath {
+		var rv []dbus.ObjectPath
+		for _, c := range connections {
+			rv = append(rv, c.GetPath())
+		}
+		return rv
+	}
+
+	if got := paths(index.Connections()); len(got) != 2 {
+		t.Errorf("Connections() = %v, want 2 connections", got)
+	}
+	if got := paths(index.ById("test")); len(got) != 1 || got[0] != wired.GetPath() {
+		t.Errorf("ById(test) = %v, want %s", got, wired.GetPath())
+	}
+	if got := paths(index.ByInterfaceName("eth0")); len(got) != 1 || got[0] != wired.GetPath() {
+		t.Errorf("ByInterfaceName(eth0) = %v, want %s", got, wired.GetPath())
+	}
+	if got := paths(index.ByType(gnm.SettingWirelessSettingName)); len(got) != 1 || got[0] != wifi.Path() {
+		t.Errorf("ByType(%s) = %v, want %s", gnm.SettingWirelessSettingName, got, wifi.Path())
+	}
+	if got := paths(index.BySsid("home")); len(got) != 1 || got[0] != wifi.Path() {
+		t.Errorf("BySsid(home) = %v, want %s", got, wifi.Path())
+	}
+	if got := index.BySsid("other"); len(got) != 0 {
+		t.Errorf("BySsid(other) = %v, want none", paths(got))
+	}
+
+	uuid, _ := wifiSettings["connection"]["uuid"].(string)
+	if got := index.ByUuid(uuid); got == nil || got.GetPath() != wifi.Path() {
+		t.Errorf("ByUuid(%s) = %v, want %s", uuid, got, wifi.Path())
+	}
+	if got := index.Settings(wifi.Path()); got["connection"]["id"] != "home" {
+		t.Errorf("Settings(%s) = %v, want the home profile", wifi.Path(), got)
+	}
+
+	// The index is a snapshot.
+	f.srv.RemoveConnection(wifi)
+	if got := index.ByUuid(uuid); got == nil {
+		t.Error("ByUuid() no longer finds a connection deleted after the index was built")
+	}
+}
+
+func TestLoadConnections(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, failures, err := s.LoadConnections([]string{"/etc/NetworkManager/system-connections/missing.nmconnection"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status || len(failures) != 1 {
+		t.Errorf("LoadConnections() = %v, %v, want false and the file", status, failures)
+	}
+
+	if status, err := s.ReloadConnections(); err != nil || !status {
+		t.Errorf("ReloadConnections() = %v, %v, want true", status, err)
+	}
+}
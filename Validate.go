@@ -0,0 +1,612 @@
+package gonetworkmanager
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// FieldError is one problem found in a connection profile.
+type FieldError struct {
+	Setting string // The setting name, e.g. "ipv4".
+	Key     string // The key within the setting, empty when the whole setting is concerned.
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Key == "" {
+		return e.Setting + ": " + e.Message
+	}
+	return e.Setting + "." + e.Key + ": " + e.Message
+}
+
+// ValidationErrors is the list of problems returned by ValidateSettings.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "invalid connection settings: " + strings.Join(msgs, "; ")
+}
+
+// knownSettings are the settings ValidateSettings knows the keys of.
+var knownSettings = []Setting{
+	&SettingConnection{},
+	&SettingWired{},
+	&SettingWireless{},
+	&SettingWirelessSecurity{},
+	&Setting8021x{},
+	&SettingIP4Config{},
+	&SettingIP6Config{},
+	&SettingBond{},
+	&SettingBridge{},
+	&SettingTeam{},
+	&SettingVlan{},
+	&SettingMacvlan{},
+	&SettingVxlan{},
+	&SettingWireGuard{},
+	&SettingOvsBridge{},
+	&SettingOvsPort{},
+	&SettingOvsInterface{},
+	&SettingOvsPatch{},
+}
+
+// ValidateSettings checks a connection profile before it is sent to
+// NetworkManager: the required keys, the D-Bus types of the keys of the
+// settings this package has typed structs for, the ranges of enumerated
+// values, the addresses and prefixes, and the rules binding settings
+// together. It returns nil or a ValidationErrors listing every problem found.
+//
+// The checks are a subset of the ones NetworkManager does, so a profile
+// passing them may still be rejected.
+func ValidateSettings(settings ConnectionSettings) error {
+	v := validator{settings: settings}
+
+	typed := make(map[string]Setting)
+	for _, known := range knownSettings {
+		name := known.SettingName()
+		if _, ok := settings[name]; !ok {
+			continue
+		}
+		if v.checkTypes(known) {
+			setting := reflect.New(reflect.TypeOf(known).Elem()).Interface().(Setting)
+			if err := settings.GetSetting(setting); err == nil {
+				typed[name] = setting
+			}
+		}
+	}
+
+	v.checkConnection(typed)
+	v.checkWireless(typed)
+	v.checkWired(typed)
+	v.checkVirtual(typed)
+	v.checkWireGuard(typed)
+	v.checkOvs(typed)
+	v.checkIPConfig(SettingIP4ConfigSettingName, 32)
+	v.checkIPConfig(SettingIP6ConfigSettingName, 128)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// validateBeforeSend runs ValidateSettings on a profile about to be sent by
+// a Settings or Connection made with WithValidation.
+func validateBeforeSend(validate bool, settings ConnectionSettings) error {
+	if !validate {
+		return nil
+	}
+	return ValidateSettings(settings)
+}
+
+type validator struct {
+	settings ConnectionSettings
+	errs     ValidationErrors
+}
+
+func (v *validator) add(setting, key, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Setting: setting, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) has(setting string) bool {
+	_, ok := v.settings[setting]
+	return ok
+}
+
+// checkTypes compares the D-Bus signature of every known key of a setting to
+// the one NetworkManager expects. It returns whether all of them match.
+func (v *validator) checkTypes(setting Setting) bool {
+	name := setting.SettingName()
+	t := reflect.TypeOf(setting).Elem()
+	ok := true
+
+	fields := settingFields(t)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+
+	for _, field := range fields {
+		value, present := v.settings[name][field.key]
+		if !present {
+			continue
+		}
+
+		expected := settingSignature(t.Field(field.index).Type, field)
+		if actual := valueSignature(value); actual != expected {
+			v.add(name, field.key, "has D-Bus type %s, expected %s", actual, expected)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func settingSignature(t reflect.Type, field settingField) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case typeHardwareAddr:
+		return "ay"
+	case typeIP4AddressData, typeIP6AddressData, typeIP4RouteData, typeIP6RouteData, typeWireGuardPeers:
+		return "aa{sv}"
+	case typeStrings:
+		switch field.family {
+		case "ip4":
+			return "au"
+		case "ip6":
+			return "aay"
+		}
+	}
+	return dbus.SignatureOfType(t).String()
+}
+
+func valueSignature(value interface{}) string {
+	if variant, ok := value.(dbus.Variant); ok {
+		return variant.Signature().String()
+	}
+	if value == nil {
+		return "nil"
+	}
+	return dbus.SignatureOf(value).String()
+}
+
+func (v *validator) checkConnection(typed map[string]Setting) {
+	if !v.has(SettingConnectionSettingName) {
+		v.add(SettingConnectionSettingName, "", "setting is missing")
+		return
+	}
+	c, ok := typed[SettingConnectionSettingName].(*SettingConnection)
+	if !ok {
+		return
+	}
+
+	if c.Id == "" {
+		v.add(SettingConnectionSettingName, "id", "is required")
+	}
+	if c.Uuid == "" {
+		v.add(SettingConnectionSettingName, "uuid", "is required")
+	} else if !isUUID(c.Uuid) {
+		v.add(SettingConnectionSettingName, "uuid", "'%s' is not a valid UUID", c.Uuid)
+	}
+	if c.Type == "" {
+		v.add(SettingConnectionSettingName, "type", "is required")
+	}
+
+	if c.InterfaceName != "" {
+		if len(c.InterfaceName) > 15 || strings.ContainsAny(c.InterfaceName, "/: \t\n") {
+			v.add(SettingConnectionSettingName, "interface-name", "'%s' is not a valid interface name", c.InterfaceName)
+		}
+	}
+
+	if (c.Master == "") != (c.SlaveType == "") {
+		v.add(SettingConnectionSettingName, "slave-type", "master and slave-type must be set together")
+	}
+
+	v.checkRange(SettingConnectionSettingName, "metered", int64(c.Metered), 0, 2)
+	if c.Lldp != nil {
+		v.checkRange(SettingConnectionSettingName, "lldp", int64(*c.Lldp), -1, 1)
+	}
+	if c.Mdns != nil {
+		v.checkRange(SettingConnectionSettingName, "mdns", int64(*c.Mdns), -1, 2)
+	}
+	v.checkRange(SettingConnectionSettingName, "multi-connect", int64(c.MultiConnect), 0, 3)
+	if c.AutoconnectSlaves != nil {
+		v.checkRange(SettingConnectionSettingName, "autoconnect-slaves", int64(*c.AutoconnectSlaves), -1, 1)
+	}
+
+	switch c.Type {
+	case SettingWirelessSettingName:
+		if !v.has(SettingWirelessSettingName) {
+			v.add(SettingWirelessSettingName, "", "setting is required by connection type %s", c.Type)
+		}
+	}
+}
+
+func (v *validator) checkWireless(typed map[string]Setting) {
+	if v.has(SettingWirelessSecuritySettingName) && !v.has(SettingWirelessSettingName) {
+		v.add(SettingWirelessSecuritySettingName, "", "setting requires the %s setting", SettingWirelessSettingName)
+	}
+
+	if w, ok := typed[SettingWirelessSettingName].(*SettingWireless); ok {
+		if len(w.Ssid) == 0 || len(w.Ssid) > 32 {
+			v.add(SettingWirelessSettingName, "ssid", "must be 1 to 32 bytes long")
+		}
+		v.checkOneOf(SettingWirelessSettingName, "mode", w.Mode, SettingWirelessModeInfrastructure, SettingWirelessModeAdhoc, SettingWirelessModeAp, "mesh")
+		v.checkOneOf(SettingWirelessSettingName, "band", w.Band, SettingWirelessBandA, SettingWirelessBandBg)
+		if w.Channel != 0 && w.Band == "" {
+			v.add(SettingWirelessSettingName, "channel", "requires band to be set")
+		}
+		v.checkRange(SettingWirelessSettingName, "powersave", int64(w.Powersave), 0, 3)
+	}
+
+	s, ok := typed[SettingWirelessSecuritySettingName].(*SettingWirelessSecurity)
+	if !ok {
+		if v.has(Setting8021xSettingName) && !v.isType(SettingWiredSettingName) {
+			v.add(Setting8021xSettingName, "", "setting requires the %s setting or a wired connection", SettingWirelessSecuritySettingName)
+		}
+		return
+	}
+
+	if s.KeyMgmt == "" {
+		v.add(SettingWirelessSecuritySettingName, "key-mgmt", "is required")
+	}
+	v.checkOneOf(SettingWirelessSecuritySettingName, "key-mgmt", s.KeyMgmt,
+		SettingWirelessSecurityKeyMgmtNone, SettingWirelessSecurityKeyMgmtIeee8021x, SettingWirelessSecurityKeyMgmtWpaPsk,
+		SettingWirelessSecurityKeyMgmtWpaEap, SettingWirelessSecurityKeyMgmtWpaEapSuiteB192, SettingWirelessSecurityKeyMgmtSae,
+		SettingWirelessSecurityKeyMgmtOwe)
+	v.checkOneOf(SettingWirelessSecuritySettingName, "auth-alg", s.AuthAlg,
+		SettingWirelessSecurityAuthAlgOpen, SettingWirelessSecurityAuthAlgShared, SettingWirelessSecurityAuthAlgLeap)
+	v.checkRange(SettingWirelessSecuritySettingName, "wep-tx-keyidx", int64(s.WepTxKeyidx), 0, 3)
+	v.checkRange(SettingWirelessSecuritySettingName, "wep-key-type", int64(s.WepKeyType), 0, 2)
+	v.checkRange(SettingWirelessSecuritySettingName, "pmf", int64(s.Pmf), 0, 3)
+	v.checkRange(SettingWirelessSecuritySettingName, "fils", int64(s.Fils), 0, 3)
+
+	if s.KeyMgmt == SettingWirelessSecurityKeyMgmtWpaPsk && s.Psk != "" && !isValidPsk(s.Psk) {
+		v.add(SettingWirelessSecuritySettingName, "psk", "must be 8 to 63 characters or 64 hexadecimal digits")
+	}
+
+	switch s.KeyMgmt {
+	case SettingWirelessSecurityKeyMgmtIeee8021x, SettingWirelessSecurityKeyMgmtWpaEap, SettingWirelessSecurityKeyMgmtWpaEapSuiteB192:
+		if !v.has(Setting8021xSettingName) {
+			v.add(Setting8021xSettingName, "", "setting is required by key-mgmt %s", s.KeyMgmt)
+		}
+	default:
+		if v.has(Setting8021xSettingName) {
+			v.add(Setting8021xSettingName, "", "setting is only allowed with key-mgmt %s, %s or %s",
+				SettingWirelessSecurityKeyMgmtIeee8021x, SettingWirelessSecurityKeyMgmtWpaEap, SettingWirelessSecurityKeyMgmtWpaEapSuiteB192)
+		}
+	}
+}
+
+func (v *validator) checkWired(typed map[string]Setting) {
+	w, ok := typed[SettingWiredSettingName].(*SettingWired)
+	if !ok {
+		return
+	}
+	v.checkOneOf(SettingWiredSettingName, "duplex", w.Duplex, "half", "full")
+}
+
+func (v *validator) checkVirtual(typed map[string]Setting) {
+	if vlan, ok := typed[SettingVlanSettingName].(*SettingVlan); ok {
+		v.checkRange(SettingVlanSettingName, "id", int64(vlan.Id), 0, 4094)
+	}
+
+	if macvlan, ok := typed[SettingMacvlanSettingName].(*SettingMacvlan); ok {
+		v.checkRange(SettingMacvlanSettingName, "mode", int64(macvlan.Mode), SettingMacvlanModeVepa, SettingMacvlanModeSource)
+	}
+
+	if vxlan, ok := typed[SettingVxlanSettingName].(*SettingVxlan); ok {
+		v.checkRange(SettingVxlanSettingName, "id", int64(vxlan.Id), 0, 1<<24-1)
+		if vxlan.Remote == "" {
+			v.add(SettingVxlanSettingName, "remote", "is required")
+		} else if net.ParseIP(vxlan.Remote) == nil {
+			v.add(SettingVxlanSettingName, "remote", "'%s' is not a valid IP address", vxlan.Remote)
+		}
+	}
+}
+
+func (v *validator) checkWireGuard(typed map[string]Setting) {
+	w, ok := typed[SettingWireGuardSettingName].(*SettingWireGuard)
+	if !ok {
+		return
+	}
+
+	if w.PrivateKey != "" && !isWireGuardKey(w.PrivateKey) {
+		v.add(SettingWireGuardSettingName, "private-key", "is not a base64 encoded 32 byte key")
+	}
+	for i, peer := range w.Peers {
+		if !isWireGuardKey(peer.PublicKey) {
+			v.add(SettingWireGuardSettingName, "peers", "peer %d: public-key is not a base64 encoded 32 byte key", i)
+		}
+		if peer.PresharedKey != "" && !isWireGuardKey(peer.PresharedKey) {
+			v.add(SettingWireGuardSettingName, "peers", "peer %d: preshared-key is not a base64 encoded 32 byte key", i)
+		}
+		for _, allowed := range peer.AllowedIps {
+			if _, _, err := net.ParseCIDR(allowed); err != nil && net.ParseIP(allowed) == nil {
+				v.add(SettingWireGuardSettingName, "peers", "peer %d: '%s' is not a valid allowed IP", i, allowed)
+			}
+		}
+	}
+}
+
+func (v *validator) checkOvs(typed map[string]Setting) {
+	if port, ok := typed[SettingOvsPortSettingName].(*SettingOvsPort); ok {
+		v.checkOneOf(SettingOvsPortSettingName, "vlan-mode", port.VlanMode, "access", "native-tagged", "native-untagged", "trunk", "dot1q-tunnel")
+		v.checkRange(SettingOvsPortSettingName, "tag", int64(port.Tag), 0, 4095)
+	}
+
+	if iface, ok := typed[SettingOvsInterfaceSettingName].(*SettingOvsInterface); ok {
+		v.checkOneOf(SettingOvsInterfaceSettingName, "type", iface.Type, SettingOvsInterfaceTypeInternal,
+			SettingOvsInterfaceTypeSystem, SettingOvsInterfaceTypePatch, SettingOvsInterfaceTypeDpdk)
+		if iface.Type == SettingOvsInterfaceTypePatch && !v.has(SettingOvsPatchSettingName) {
+			v.add(SettingOvsPatchSettingName, "", "setting is required by interface type %s", iface.Type)
+		}
+	}
+}
+
+func (v *validator) checkIPConfig(name string, bits int) {
+	values, ok := v.settings[name]
+	if !ok {
+		return
+	}
+
+	method, _ := values["method"].(string)
+	switch name {
+	case SettingIP4ConfigSettingName:
+		v.checkOneOf(name, "method", method, SettingIP4ConfigMethodAuto, SettingIP4ConfigMethodLinkLocal,
+			SettingIP4ConfigMethodManual, SettingIP4ConfigMethodShared, SettingIP4ConfigMethodDisabled)
+	case SettingIP6ConfigSettingName:
+		v.checkOneOf(name, "method", method, SettingIP6ConfigMethodIgnore, SettingIP6ConfigMethodAuto,
+			SettingIP6ConfigMethodDhcp, SettingIP6ConfigMethodLinkLocal, SettingIP6ConfigMethodManual,
+			SettingIP6ConfigMethodShared, SettingIP6ConfigMethodDisabled)
+	}
+
+	if name == SettingIP6ConfigSettingName {
+		if privacy, ok := values["ip6-privacy"].(int32); ok {
+			v.checkRange(name, "ip6-privacy", int64(privacy), -1, 2)
+		}
+		if mode, ok := values["addr-gen-mode"].(int32); ok {
+			v.checkRange(name, "addr-gen-mode", int64(mode), 0, 1)
+		}
+	}
+
+	addresses, _ := variantMaps(values["address-data"])
+	for i, address := range addresses {
+		ip, _ := address["address"].Value().(string)
+		if !isIP(ip, bits) {
+			v.add(name, "address-data", "address %d: '%s' is not a valid IPv%d address", i, ip, ipVersion(bits))
+		}
+		if prefix, ok := address["prefix"].Value().(uint32); !ok || prefix > uint32(bits) {
+			v.add(name, "address-data", "address %d: prefix must be a uint32 between 0 and %d", i, bits)
+		}
+	}
+
+	legacyAddresses := v.checkLegacyEntries(name, "addresses", bits)
+	v.checkLegacyEntries(name, "routes", bits)
+	count := len(addresses) + legacyAddresses
+
+	switch method {
+	case SettingIP4ConfigMethodManual:
+		if count == 0 {
+			v.add(name, "address-data", "at least one address is required by method %s", method)
+		}
+	case SettingIP4ConfigMethodLinkLocal, SettingIP4ConfigMethodDisabled, SettingIP6ConfigMethodIgnore:
+		if len(addresses) > 0 {
+			v.add(name, "address-data", "addresses are not allowed with method %s", method)
+		}
+		if legacyAddresses > 0 {
+			v.add(name, "addresses", "addresses are not allowed with method %s", method)
+		}
+	}
+
+	if gateway, ok := values["gateway"].(string); ok && gateway != "" {
+		if !isIP(gateway, bits) {
+			v.add(name, "gateway", "'%s' is not a valid IPv%d address", gateway, ipVersion(bits))
+		}
+		if count == 0 {
+			v.add(name, "gateway", "requires at least one address")
+		}
+	}
+
+	routes, _ := variantMaps(values["route-data"])
+	for i, route := range routes {
+		dest, _ := route["dest"].Value().(string)
+		if !isIP(dest, bits) {
+			v.add(name, "route-data", "route %d: '%s' is not a valid IPv%d destination", i, dest, ipVersion(bits))
+		}
+		if prefix, ok := route["prefix"].Value().(uint32); !ok || prefix > uint32(bits) {
+			v.add(name, "route-data", "route %d: prefix must be a uint32 between 0 and %d", i, bits)
+		}
+		if nextHop, ok := route["next-hop"]; ok {
+			if s, _ := nextHop.Value().(string); !isIP(s, bits) {
+				v.add(name, "route-data", "route %d: '%s' is not a valid IPv%d next hop", i, s, ipVersion(bits))
+			}
+		}
+	}
+}
+
+// checkLegacyEntries checks the deprecated "addresses" or "routes" key of an
+// IP setting, still sent by older clients and returned by older versions of
+// NetworkManager. For IPv4 every entry is a list of uint32: address, prefix and
+// gateway, or destination, prefix, next hop and metric, with the addresses in
+// network byte order. For IPv6 every entry is a structure of the same fields
+// with the addresses as 16 bytes. It returns the number of entries.
+func (v *validator) checkLegacyEntries(name, key string, bits int) int {
+	value, ok := v.settings[name][key]
+	if !ok {
+		return 0
+	}
+
+	fields := 3
+	if key == "routes" {
+		fields = 4
+	}
+	expected := "aau"
+	if bits == 128 {
+		expected = "a(ayuay)"
+		if fields == 4 {
+			expected = "a(ayuayu)"
+		}
+	}
+
+	entries, ok := legacyEntries(value)
+	if !ok {
+		v.add(name, key, "has D-Bus type %s, expected %s", valueSignature(value), expected)
+		return 0
+	}
+
+	for i, entry := range entries {
+		if !isLegacyEntry(entry, fields, bits) {
+			v.add(name, key, "entry %d: must have the D-Bus type %s", i, expected[1:])
+			continue
+		}
+		if prefix := entry[1].(uint32); prefix > uint32(bits) {
+			v.add(name, key, "entry %d: prefix must be between 0 and %d", i, bits)
+		}
+	}
+	return len(entries)
+}
+
+// legacyEntries returns the fields of every entry of a legacy "addresses" or
+// "routes" value, whether it was built by the caller, e.g. as [][]uint32 or a
+// slice of structures, or decoded by godbus as [][]interface{}.
+func legacyEntries(value interface{}) ([][]interface{}, bool) {
+	if variant, ok := value.(dbus.Variant); ok {
+		value = variant.Value()
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	entries := make([][]interface{}, rv.Len())
+	for i := range entries {
+		entry := rv.Index(i)
+		if entry.Kind() == reflect.Interface {
+			entry = entry.Elem()
+		}
+
+		switch entry.Kind() {
+		case reflect.Slice:
+			if entry.Type().Elem().Kind() == reflect.Uint8 {
+				return nil, false
+			}
+			for j := 0; j < entry.Len(); j++ {
+				entries[i] = append(entries[i], entry.Index(j).Interface())
+			}
+		case reflect.Struct:
+			for j := 0; j < entry.NumField(); j++ {
+				if entry.Type().Field(j).PkgPath != "" {
+					return nil, false
+				}
+				entries[i] = append(entries[i], entry.Field(j).Interface())
+			}
+		default:
+			return nil, false
+		}
+	}
+	return entries, true
+}
+
+// isLegacyEntry reports whether the fields of a legacy entry have the types of
+// its IP family: uint32 for IPv4; alternately 16 bytes and uint32 for IPv6.
+func isLegacyEntry(entry []interface{}, fields, bits int) bool {
+	if len(entry) != fields {
+		return false
+	}
+	for i, field := range entry {
+		if bits == 32 || i%2 == 1 {
+			if _, ok := field.(uint32); !ok {
+				return false
+			}
+		} else if b, ok := field.([]byte); !ok || len(b) != 16 {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *validator) checkRange(setting, key string, value, min, max int64) {
+	if _, ok := v.settings[setting][key]; !ok {
+		return
+	}
+	if value < min || value > max {
+		v.add(setting, key, "%d is out of range [%d, %d]", value, min, max)
+	}
+}
+
+func (v *validator) checkOneOf(setting, key, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(setting, key, "'%s' is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+func (v *validator) isType(setting string) bool {
+	t, _ := v.settings[SettingConnectionSettingName]["type"].(string)
+	return t == setting
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isWireGuardKey(key string) bool {
+	b, err := base64.StdEncoding.DecodeString(key)
+	return err == nil && len(b) == 32
+}
+
+func isValidPsk(psk string) bool {
+	if len(psk) == 64 {
+		_, err := hex.DecodeString(psk)
+		return err == nil
+	}
+	return len(psk) >= 8 && len(psk) <= 63
+}
+
+func isIP(s string, bits int) bool {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return false
+	}
+	if bits == 32 {
+		return ip.To4() != nil
+	}
+	return ip.To4() == nil
+}
+
+func ipVersion(bits int) int {
+	if bits == 32 {
+		return 4
+	}
+	return 6
+}
@@ -0,0 +1,63 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"fmt"
+)
+
+func (nm *networkManager) AddAndActivateVlan(ctx context.Context, parent Device, id uint32, settings ...Setting) (ActiveConnection, error) {
+	parentInterface, err := parent.GetPropertyInterfaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The name iproute2 gives VLANs, unless it is too long for an interface
+	// name.
+	name := fmt.Sprintf("%s.%d", parentInterface, id)
+	if len(name) > 15 {
+		name = fmt.Sprintf("vlan%d", id)
+	}
+
+	return nm.addAndActivateVirtual(ctx, name, &SettingVlan{Parent: parentInterface, Id: id}, settings)
+}
+
+func (nm *networkManager) AddAndActivateVxlan(ctx context.Context, parent Device, id uint32, remote string, settings ...Setting) (ActiveConnection, error) {
+	vxlan := &SettingVxlan{Id: id, Remote: remote}
+	if parent != nil {
+		var err error
+		if vxlan.Parent, err = parent.GetPropertyInterfaceContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return nm.addAndActivateVirtual(ctx, fmt.Sprintf("vxlan%d", id), vxlan, settings)
+}
+
+// addAndActivateVirtual adds the profile of a software device and activates
+// it, letting NetworkManager create the device. The profile is deleted when
+// the activation fails.
+func (nm *networkManager) addAndActivateVirtual(ctx context.Context, name string, setting Setting, settings []Setting) (ActiveConnection, error) {
+	// The given settings come last to replace the default ones.
+	profile, err := NewConnectionSettings(append([]Setting{
+		&SettingConnection{Id: name, Type: setting.SettingName(), InterfaceName: name},
+		setting,
+	}, settings...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewSettingsWithConn(nm.conn)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := s.AddConnectionContext(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	ac, err := nm.ActivateAndWait(ctx, connection, nil)
+	if err != nil {
+		return nil, deleteConnections([]Connection{connection}, err)
+	}
+	return ac, nil
+}
@@ -0,0 +1,158 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// activeProfile returns the profile of an active connection.
+func activeProfile(t *testing.T, f *fake, ac gnm.ActiveConnection) gnm.ConnectionSettings {
+	t.Helper()
+
+	c, err := ac.GetPropertyConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f.srv.Object(c.GetPath()).ConnectionSettings()
+}
+
+func TestAddAndActivateVlan(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	tests := []struct {
+		parent string
+		id     uint32
+		want   string
+	}{
+		{"eth0", 10, "eth0.10"},
+		{"enx020000000001", 4094, "vlan4094"},
+	}
+
+	for _, tt := range tests {
+		parent, _ := f.addDevice(t, tt.parent, gnm.NmDeviceTypeEthernet)
+		vobj := f.srv.AddDevice(tt.want, gnm.NmDeviceTypeVlan)
+
+		ac, err := f.nm.AddAndActivateVlan(context.Background(), parent, tt.id,
+			&gnm.SettingIP4Config{Method: gnm.SettingIP4ConfigMethodDisabled})
+		if err != nil {
+			t.Fatalf("AddAndActivateVlan(%s, %d) = %v", tt.parent, tt.id, err)
+		}
+		if devices, err := ac.GetPropertyDevices(); err != nil || len(devices) != 1 || devices[0].GetPath() != vobj.Path() {
+			t.Errorf("GetPropertyDevices() = %v, %v, want %s", devices, err, vobj.Path())
+		}
+
+		profile := activeProfile(t, f, ac)
+		var connection gnm.SettingConnection
+		var vlan gnm.SettingVlan
+		if err := profile.GetSetting(&connection); err != nil {
+			t.Fatal(err)
+		}
+		if err := profile.GetSetting(&vlan); err != nil {
+			t.Fatal(err)
+		}
+		if connection.InterfaceName != tt.want || connection.Type != gnm.SettingVlanSettingName {
+			t.Errorf("interface-name, type = %s, %s, want %s, %s", connection.InterfaceName, connection.Type, tt.want, gnm.SettingVlanSettingName)
+		}
+		if vlan.Parent != tt.parent || vlan.Id != tt.id {
+			t.Errorf("parent, id = %s, %d, want %s, %d", vlan.Parent, vlan.Id, tt.parent, tt.id)
+		}
+		if method := profile["ipv4"]["method"]; method != gnm.SettingIP4ConfigMethodDisabled {
+			t.Errorf("ipv4.method = %v, want %s", method, gnm.SettingIP4ConfigMethodDisabled)
+		}
+	}
+}
+
+func TestAddAndActivateVxlan(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	xobj := f.srv.AddDevice("vxlan42", gnm.NmDeviceTypeVxlan)
+	d, err := gnm.DeviceFactoryWithConn(f.conn, xobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.(gnm.DeviceVxlan); !ok {
+		t.Fatalf("DeviceFactoryWithConn() of a VXLAN = %T, want a DeviceVxlan", d)
+	}
+
+	// The settings given replace the default ones.
+	ac, err := f.nm.AddAndActivateVxlan(context.Background(), nil, 42, "203.0.113.1",
+		&gnm.SettingConnection{Id: "overlay", Type: gnm.SettingVxlanSettingName, InterfaceName: "vxlan42"})
+	if err != nil {
+		t.Fatalf("AddAndActivateVxlan() = %v", err)
+	}
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("GetPropertyState() = %v, %v, want activated", state, err)
+	}
+
+	profile := activeProfile(t, f, ac)
+	var vxlan gnm.SettingVxlan
+	if err := profile.GetSetting(&vxlan); err != nil {
+		t.Fatal(err)
+	}
+	if vxlan.Parent != "" || vxlan.Id != 42 || vxlan.Remote != "203.0.113.1" {
+		t.Errorf("parent, id, remote = %q, %d, %s, want none, 42, 203.0.113.1", vxlan.Parent, vxlan.Id, vxlan.Remote)
+	}
+	if id := profile["connection"]["id"]; id != "overlay" {
+		t.Errorf("id = %v, want overlay", id)
+	}
+}
+
+func TestAddAndActivateVlanTimeout(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	parent, _ := f.addDevice(t, "eth0", gnm.NmDeviceTypeEthernet)
+	// The activation never completes.
+	f.srv.SetActivationFunc(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	ac, err := f.nm.AddAndActivateVlan(ctx, parent, 10)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AddAndActivateVlan() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if ac != nil {
+		t.Errorf("AddAndActivateVlan() returned %s, want no active connection", ac.GetPath())
+	}
+	// The profile is deleted although ctx is done.
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Errorf("%d profiles left, want none", len(profiles))
+	}
+}
+
+func TestSettingVirtualZeroValues(t *testing.T) {
+	tests := []struct {
+		setting gnm.Setting
+		key     string
+	}{
+		{&gnm.SettingVlan{Parent: "eth0", Id: 10, Flags: uint32p(0)}, "flags"},
+		{&gnm.SettingVxlan{Id: 1, Remote: "192.0.2.1", Ageing: uint32p(0)}, "ageing"},
+	}
+
+	for _, tt := range tests {
+		cs, err := gnm.NewConnectionSettings(tt.setting)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := tt.setting.SettingName()
+		if value, ok := cs[name][tt.key]; !ok || value != uint32(0) {
+			t.Errorf("%s.%s = %#v, want uint32(0)", name, tt.key, value)
+		}
+
+		got := reflect.New(reflect.TypeOf(tt.setting).Elem()).Interface().(gnm.Setting)
+		if err := cs.GetSetting(got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.setting) {
+			t.Errorf("GetSetting() = %+v, want %+v", got, tt.setting)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package gonetworkmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrAccessPointNotFound is returned by NetworkManager.ConnectWifi when the
+// device sees no access point broadcasting the SSID.
+var ErrAccessPointNotFound = errors.New("no access point found for the SSID")
+
+// ConnectWifiOptions are the optional parameters of NetworkManager.ConnectWifi.
+type ConnectWifiOptions struct {
+	// Connect to a network that does not broadcast its SSID. When no access
+	// point of the network is visible, the key management cannot be derived
+	// and is taken from KeyMgmt, or is WPA-PSK when a passphrase is given.
+	Hidden bool
+
+	// Key management to use instead of the one derived from the flags of the
+	// access point, one of the SettingWirelessSecurityKeyMgmt constants.
+	KeyMgmt string
+
+	// EAP configuration of 802.1X networks, required by them. The passphrase
+	// is used as its Password when it has none.
+	Setting8021x *Setting8021x
+
+	// Id of the connection profile created when none exists for the SSID.
+	// Defaults to the SSID.
+	Id string
+}
+
+func (nm *networkManager) ConnectWifi(ctx context.Context, d DeviceWireless, ssid string, passphrase string, opts *ConnectWifiOptions) (ActiveConnection, error) {
+	if opts == nil {
+		opts = &ConnectWifiOptions{}
+	}
+
+	ap, err := strongestAccessPoint(ctx, d, ssid)
+	if err != nil {
+		return nil, err
+	}
+	if ap == nil {
+		// Probing for the SSID also finds the access points of hidden
+		// networks.
+		if _, err = d.ScanAndWait(ctx, ScanOptions{Ssids: []string{ssid}}); err != nil {
+			return nil, err
+		}
+		if ap, err = strongestAccessPoint(ctx, d, ssid); err != nil {
+			return nil, err
+		}
+	}
+	if ap == nil && !opts.Hidden {
+		return nil, ErrAccessPointNotFound
+	}
+
+	keyMgmt := opts.KeyMgmt
+	if keyMgmt == "" {
+		if ap != nil {
+			if keyMgmt, err = accessPointKeyMgmt(ctx, ap); err != nil {
+				return nil, err
+			}
+		} else if passphrase != "" {
+			keyMgmt = SettingWirelessSecurityKeyMgmtWpaPsk
+		}
+	}
+
+	connection, err := nm.wifiConnection(ctx, d, ssid)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings ConnectionSettings
+	if connection == nil {
+		if settings, err = wifiSettings(ssid, passphrase, keyMgmt, opts); err != nil {
+			return nil, err
+		}
+	} else if passphrase != "" {
+		if err = setWifiSecret(ctx, connection, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	sub, err := newEventSubscription(ctx, nm.dbusBase, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Close()
+
+	var ac ActiveConnection
+	switch {
+	case connection == nil && ap == nil:
+		ac, err = nm.AddAndActivateConnectionContext(ctx, settings, d)
+	case connection == nil:
+		ac, err = nm.AddAndActivateWirelessConnectionContext(ctx, settings, d, ap)
+	case ap == nil:
+		ac, err = nm.ActivateConnectionContext(ctx, connection, d)
+	default:
+		ac, err = nm.ActivateWirelessConnectionContext(ctx, connection, d, ap)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ac, nm.waitForActivation(ctx, sub, ac.GetPath())
+}
+
+// strongestAccessPoint returns the access point of the SSID with the best
+// signal, or nil if the device sees none.
+func strongestAccessPoint(ctx context.Context, d DeviceWireless, ssid string) (AccessPoint, error) {
+	aps, err := d.GetAccessPointsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var strongest AccessPoint
+	var strength uint8
+	for _, ap := range aps {
+		p, err := ap.GetPropertiesContext(ctx)
+		if isUnknownObject(err) {
+			// The access point disappeared since it was listed.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if string(p.Ssid) == ssid && (strongest == nil || p.Strength > strength) {
+			strongest, strength = ap, p.Strength
+		}
+	}
+	return strongest, nil
+}
+
+// accessPointKeyMgmt derives the key management of a connection to the
+// access point from its flags, or returns "" for an open network.
+func accessPointKeyMgmt(ctx context.Context, ap AccessPoint) (string, error) {
+	p, err := ap.GetPropertiesContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sec := p.WpaFlags | p.RsnFlags
+	switch {
+	case sec&Nm80211APSecKeyMgmtEAPSuiteB192 != 0 && sec&Nm80211APSecKeyMgmt8021X == 0:
+		return SettingWirelessSecurityKeyMgmtWpaEapSuiteB192, nil
+	case sec&Nm80211APSecKeyMgmt8021X != 0:
+		return SettingWirelessSecurityKeyMgmtWpaEap, nil
+	case sec&Nm80211APSecKeyMgmtPSK != 0:
+		// Also used by WPA2/WPA3 transition networks, which accept both.
+		return SettingWirelessSecurityKeyMgmtWpaPsk, nil
+	case sec&Nm80211APSecKeyMgmtSAE != 0:
+		return SettingWirelessSecurityKeyMgmtSae, nil
+	case sec&(Nm80211APSecKeyMgmtOWE|Nm80211APSecKeyMgmtOWETM) != 0:
+		return SettingWirelessSecurityKeyMgmtOwe, nil
+	case p.Flags&Nm80211APFlagsPrivacy != 0:
+		// WEP, either static or with keys given by 802.1X.
+		return SettingWirelessSecurityKeyMgmtNone, nil
+	}
+	return "", nil
+}
+
+// wifiConnection returns a saved connection profile of the SSID usable on the
+// device, or nil.
+func (nm *networkManager) wifiConnection(ctx context.Context, d DeviceWireless, ssid string) (Connection, error) {
+	s, err := NewSettingsWithConn(nm.conn)
+	if err != nil {
+		return nil, err
+	}
+	index, err := s.GetConnectionIndexContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iface, err := d.GetPropertyInterfaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connection := range index.BySsid(ssid) {
+		settings := index.Settings(connection.GetPath())
+		if name, _ := settings[SettingConnectionSettingName]["interface-name"].(string); name != "" && name != iface {
+			continue
+		}
+		if mode, _ := settings[SettingWirelessSettingName]["mode"].(string); mode != "" && mode != SettingWirelessModeInfrastructure {
+			continue
+		}
+		return connection, nil
+	}
+	return nil, nil
+}
+
+// wifiSettings builds the connection profile of a network.
+func wifiSettings(ssid, passphrase, keyMgmt string, opts *ConnectWifiOptions) (ConnectionSettings, error) {
+	id := opts.Id
+	if id == "" {
+		id = ssid
+	}
+
+	settings := []Setting{
+		&SettingConnection{Id: id, Type: SettingWirelessSettingName},
+		&SettingWireless{Ssid: []byte(ssid), Mode: SettingWirelessModeInfrastructure, Hidden: opts.Hidden},
+	}
+
+	security := &SettingWirelessSecurity{KeyMgmt: keyMgmt}
+	switch keyMgmt {
+	case "":
+		security = nil
+
+	case SettingWirelessSecurityKeyMgmtWpaPsk, SettingWirelessSecurityKeyMgmtSae:
+		security.Psk = passphrase
+
+	case SettingWirelessSecurityKeyMgmtNone:
+		if opts.Setting8021x != nil {
+			security.KeyMgmt = SettingWirelessSecurityKeyMgmtIeee8021x
+			break
+		}
+		security.WepKey0 = passphrase
+		security.WepKeyType = wepKeyType(passphrase)
+
+	case SettingWirelessSecurityKeyMgmtWpaEap, SettingWirelessSecurityKeyMgmtWpaEapSuiteB192, SettingWirelessSecurityKeyMgmtIeee8021x:
+		if opts.Setting8021x == nil {
+			return nil, fmt.Errorf("key management %s requires a Setting8021x", keyMgmt)
+		}
+	}
+	if security != nil {
+		settings = append(settings, security)
+	}
+
+	if opts.Setting8021x != nil && security != nil && security.KeyMgmt != SettingWirelessSecurityKeyMgmtNone {
+		setting8021x := *opts.Setting8021x
+		if setting8021x.Password == "" {
+			setting8021x.Password = passphrase
+		}
+		settings = append(settings, &setting8021x)
+	}
+
+	return NewConnectionSettings(settings...)
+}
+
+// wepKeyType returns the wep-key-type of a WEP key: 1 for the 40 or 104 bit
+// keys, written in ASCII or hexadecimal, and 2 for the passphrases hashed
+// into a key.
+func wepKeyType(key string) uint32 {
+	switch len(key) {
+	case 5, 10, 13, 26:
+		return 1
+	}
+	return 2
+}
+
+// setWifiSecret replaces the secret of a saved Wi-Fi connection profile. The
+// profile stays in memory or on disk, wherever it was.
+func setWifiSecret(ctx context.Context, c Connection, passphrase string) error {
+	settings, err := c.GetSettingsContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	security, ok := settings[SettingWirelessSecuritySettingName]
+	if !ok {
+		return nil
+	}
+
+	switch security["key-mgmt"] {
+	case SettingWirelessSecurityKeyMgmtWpaPsk, SettingWirelessSecurityKeyMgmtSae:
+		security["psk"] = passphrase
+	case SettingWirelessSecurityKeyMgmtNone:
+		security["wep-key0"] = passphrase
+	case SettingWirelessSecurityKeyMgmtWpaEap, SettingWirelessSecurityKeyMgmtWpaEapSuiteB192, SettingWirelessSecurityKeyMgmtIeee8021x:
+		if _, ok := settings[Setting8021xSettingName]; !ok {
+			return nil
+		}
+		settings[Setting8021xSettingName]["password"] = passphrase
+	default:
+		return nil
+	}
+
+	_, err = c.Update2Context(ctx, settings, NmSettingsUpdate2FlagsNone, nil)
+	return err
+}
@@ -0,0 +1,80 @@
+package gonetworkmanager
+
+// WifiBand is a Wi-Fi frequency band, as returned by WifiFrequencyBand.
+type WifiBand string
+
+const (
+	WifiBandUnknown WifiBand = ""
+	WifiBand2GHz    WifiBand = "2.4GHz"
+	WifiBand5GHz    WifiBand = "5GHz"
+	WifiBand6GHz    WifiBand = "6GHz"
+)
+
+// WifiFrequencyBand returns the band of a frequency in MHz, e.g. of
+// AccessPoint.GetPropertyFrequency, or WifiBandUnknown.
+func WifiFrequencyBand(frequency uint32) WifiBand {
+	switch {
+	case frequency >= 2400 && frequency <= 2500:
+		return WifiBand2GHz
+	case frequency >= 4900 && frequency < 5925:
+		return WifiBand5GHz
+	case frequency >= 5925 && frequency <= 7125:
+		return WifiBand6GHz
+	}
+	return WifiBandUnknown
+}
+
+// WifiFrequencyChannel returns the channel number of a frequency in MHz, or 0
+// if it is not the center frequency of a 20 MHz channel. Channel numbers
+// repeat across bands.
+func WifiFrequencyChannel(frequency uint32) uint32 {
+	switch WifiFrequencyBand(frequency) {
+	case WifiBand2GHz:
+		if frequency == 2484 {
+			return 14
+		}
+		if frequency >= 2412 && frequency <= 2472 && (frequency-2407)%5 == 0 {
+			return (frequency - 2407) / 5
+		}
+	case WifiBand5GHz:
+		// Including the 4.9 GHz channels of Japan.
+		if frequency < 5000 && frequency%5 == 0 {
+			return (frequency - 4000) / 5
+		}
+		if frequency%5 == 0 {
+			return (frequency - 5000) / 5
+		}
+	case WifiBand6GHz:
+		if frequency == 5935 {
+			return 2
+		}
+		if frequency >= 5955 && (frequency-5950)%5 == 0 {
+			return (frequency - 5950) / 5
+		}
+	}
+	return 0
+}
+
+// Has reports whether all the capabilities of c are set.
+func (f NmDeviceWifiCapabilities) Has(c NmDeviceWifiCapabilities) bool {
+	return f&c == c
+}
+
+// SupportsBand reports whether the device can use the band. Devices that do
+// not report their frequency capabilities, without
+// NmDeviceWifiCapabilitiesFreqValid, are assumed to support every band.
+func (f NmDeviceWifiCapabilities) SupportsBand(band WifiBand) bool {
+	if !f.Has(NmDeviceWifiCapabilitiesFreqValid) {
+		return band != WifiBandUnknown
+	}
+
+	switch band {
+	case WifiBand2GHz:
+		return f.Has(NmDeviceWifiCapabilitiesFreq2GHz)
+	case WifiBand5GHz:
+		return f.Has(NmDeviceWifiCapabilitiesFreq5GHz)
+	case WifiBand6GHz:
+		return f.Has(NmDeviceWifiCapabilitiesFreq6GHz)
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestWifiFrequencyChannel(t *testing.T) {
+	tests := []struct {
+		frequency uint32
+		band      gnm.WifiBand
+		channel   uint32
+	}{
+		{2412, gnm.WifiBand2GHz, 1},
+		{2437, gnm.WifiBand2GHz, 6},
+		{2472, gnm.WifiBand2GHz, 13},
+		{2484, gnm.WifiBand2GHz, 14},
+		{2413, gnm.WifiBand2GHz, 0},
+		{4920, gnm.WifiBand5GHz, 184},
+		{5180, gnm.WifiBand5GHz, 36},
+		{5825, gnm.WifiBand5GHz, 165},
+		{5935, gnm.WifiBand6GHz, 2},
+		{5955, gnm.WifiBand6GHz, 1},
+		{6115, gnm.WifiBand6GHz, 33},
+		{7115, gnm.WifiBand6GHz, 233},
+		{0, gnm.WifiBandUnknown, 0},
+		{60480, gnm.WifiBandUnknown, 0},
+	}
+
+	for _, tt := range tests {
+		if band := gnm.WifiFrequencyBand(tt.frequency); band != tt.band {
+			t.Errorf("WifiFrequencyBand(%d) = %q, want %q", tt.frequency, band, tt.band)
+		}
+		if channel := gnm.WifiFrequencyChannel(tt.frequency); channel != tt.channel {
+			t.Errorf("WifiFrequencyChannel(%d) = %d, want %d", tt.frequency, channel, tt.channel)
+		}
+	}
+}
+
+func TestSupportsBand(t *testing.T) {
+	dual := gnm.NmDeviceWifiCapabilitiesFreqValid | gnm.NmDeviceWifiCapabilitiesFreq2GHz | gnm.NmDeviceWifiCapabilitiesFreq5GHz
+
+	tests := []struct {
+		name         string
+		capabilities gnm.NmDeviceWifiCapabilities
+		band         gnm.WifiBand
+		want         bool
+	}{
+		{"dual band 2.4GHz", dual, gnm.WifiBand2GHz, true},
+		{"dual band 5GHz", dual, gnm.WifiBand5GHz, true},
+		{"dual band 6GHz", dual, gnm.WifiBand6GHz, false},
+		{"dual band unknown", dual, gnm.WifiBandUnknown, false},
+		{"6GHz only", gnm.NmDeviceWifiCapabilitiesFreqValid | gnm.NmDeviceWifiCapabilitiesFreq6GHz, gnm.WifiBand6GHz, true},
+		{"bands without FreqValid", gnm.NmDeviceWifiCapabilitiesFreq2GHz, gnm.WifiBand5GHz, true},
+		{"not reported 5GHz", gnm.NmDeviceWifiCapabilitiesRSN, gnm.WifiBand5GHz, true},
+		{"not reported unknown", gnm.NmDeviceWifiCapabilitiesNone, gnm.WifiBandUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.capabilities.SupportsBand(tt.band); got != tt.want {
+				t.Errorf("%v.SupportsBand(%q) = %v, want %v", tt.capabilities, tt.band, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessPointBand(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	_, dobj := newWifiDevice(t, f)
+	obj := f.srv.AddAccessPoint(dobj, "home", 5180, 70)
+
+	ap, err := gnm.NewAccessPointWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if band, err := ap.Band(); err != nil || band != gnm.WifiBand5GHz {
+		t.Errorf("Band() = %q, %v, want %q", band, err, gnm.WifiBand5GHz)
+	}
+	if channel, err := ap.Channel(); err != nil || channel != 36 {
+		t.Errorf("Channel() = %d, %v, want 36", channel, err)
+	}
+	if bandwidth, err := ap.GetPropertyBandwidth(); err != nil || bandwidth != 20 {
+		t.Errorf("GetPropertyBandwidth() = %d, %v, want 20", bandwidth, err)
+	}
+}
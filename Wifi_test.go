@@ -0,0 +1,272 @@
+package gonetworkmanager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+func newWifiDevice(t *testing.T, f *fake) (gnm.DeviceWireless, *nmfake.Object) {
+	t.Helper()
+
+	obj := f.srv.AddDevice("wlan0", gnm.NmDeviceTypeWifi)
+	d, err := gnm.NewDeviceWirelessWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, obj
+}
+
+func TestConnectWifi(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+
+	f.srv.AddAccessPoint(dobj, "home", 2412, 40)
+	ap := f.srv.AddAccessPoint(dobj, "home", 5180, 80)
+	f.srv.AddAccessPoint(dobj, "other", 2437, 100)
+	ap.Set(gnm.AccessPointPropertyFlags, uint32(gnm.Nm80211APFlagsPrivacy))
+	ap.Set(gnm.AccessPointPropertyRsnFlags, uint32(gnm.Nm80211APSecKeyMgmtPSK|gnm.Nm80211APSecPairCCMP))
+
+	ac, err := f.nm.ConnectWifi(context.Background(), d, "home", "passphrase", nil)
+	if err != nil {
+		t.Fatalf("ConnectWifi() = %v", err)
+	}
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("GetPropertyState() = %v, %v, want activated", state, err)
+	}
+	if specific, err := ac.GetPropertySpecificObject(); err != nil || specific.GetPath() != ap.Path() {
+		t.Errorf("GetPropertySpecificObject() = %v, %v, want the strongest access point %s", specific, err, ap.Path())
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 1 {
+		t.Fatalf("%d profiles, want 1", len(profiles))
+	}
+	var security gnm.SettingWirelessSecurity
+	if err := profiles[0].GetSetting(&security); err != nil {
+		t.Fatal(err)
+	}
+	if security.KeyMgmt != gnm.SettingWirelessSecurityKeyMgmtWpaPsk || security.Psk != "passphrase" {
+		t.Errorf("key-mgmt, psk = %s, %s, want %s, passphrase", security.KeyMgmt, security.Psk, gnm.SettingWirelessSecurityKeyMgmtWpaPsk)
+	}
+
+	// The saved profile is reused, with the new passphrase.
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "home", "changed", nil); err != nil {
+		t.Fatalf("ConnectWifi() again = %v", err)
+	}
+	profiles = f.profiles(t)
+	if len(profiles) != 1 {
+		t.Fatalf("%d profiles after connecting again, want 1", len(profiles))
+	}
+	if psk := profiles[0][gnm.SettingWirelessSecuritySettingName]["psk"]; psk != "changed" {
+		t.Errorf("psk = %v, want changed", psk)
+	}
+}
+
+func TestConnectWifiUnsavedProfile(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+	ap := f.srv.AddAccessPoint(dobj, "home", 2412, 40)
+	ap.Set(gnm.AccessPointPropertyFlags, uint32(gnm.Nm80211APFlagsPrivacy))
+	ap.Set(gnm.AccessPointPropertyRsnFlags, uint32(gnm.Nm80211APSecKeyMgmtPSK|gnm.Nm80211APSecPairCCMP))
+
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "home", "passphrase", nil); err != nil {
+		t.Fatalf("ConnectWifi() = %v", err)
+	}
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connections, err := s.ListConnections()
+	if err != nil || len(connections) != 1 {
+		t.Fatalf("ListConnections() = %v, %v, want 1 connection", connections, err)
+	}
+	settings, err := connections[0].GetSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = connections[0].UpdateUnsaved(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new passphrase must not write the in-memory profile to disk.
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "home", "changed", nil); err != nil {
+		t.Fatalf("ConnectWifi() again = %v", err)
+	}
+	if unsaved, err := connections[0].GetPropertyUnsaved(); err != nil || !unsaved {
+		t.Errorf("GetPropertyUnsaved() = %v, %v, want true", unsaved, err)
+	}
+	if psk := f.profiles(t)[0][gnm.SettingWirelessSecuritySettingName]["psk"]; psk != "changed" {
+		t.Errorf("psk = %v, want changed", psk)
+	}
+}
+
+func TestConnectWifiAccessPointError(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+	f.srv.AddAccessPoint(dobj, "home", 2412, 40)
+
+	// Only the access points that disappeared are skipped, other errors are
+	// not mistaken for a missing network.
+	if err := f.srv.RemoveMethod("org.freedesktop.DBus.Properties.GetAll"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := f.nm.ConnectWifi(context.Background(), d, "home", "passphrase", nil)
+	if dbusErr, ok := err.(dbus.Error); !ok || dbusErr.Name != "org.freedesktop.DBus.Error.UnknownMethod" {
+		t.Errorf("ConnectWifi() = %v, want the UnknownMethod error", err)
+	}
+}
+
+func TestConnectWifiHidden(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, _ := newWifiDevice(t, f)
+
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "hidden", "passphrase", nil); err != gnm.ErrAccessPointNotFound {
+		t.Errorf("ConnectWifi() to a missing network = %v, want %v", err, gnm.ErrAccessPointNotFound)
+	}
+	if profiles := f.profiles(t); len(profiles) != 0 {
+		t.Fatalf("%d profiles after a failure, want none", len(profiles))
+	}
+
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "hidden", "passphrase", &gnm.ConnectWifiOptions{Hidden: true, Id: "office"}); err != nil {
+		t.Fatalf("ConnectWifi() = %v", err)
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 1 {
+		t.Fatalf("%d profiles, want 1", len(profiles))
+	}
+	var wireless gnm.SettingWireless
+	if err := profiles[0].GetSetting(&wireless); err != nil {
+		t.Fatal(err)
+	}
+	if string(wireless.Ssid) != "hidden" || !wireless.Hidden {
+		t.Errorf("ssid, hidden = %s, %v, want hidden, true", wireless.Ssid, wireless.Hidden)
+	}
+	if id := profiles[0]["connection"]["id"]; id != "office" {
+		t.Errorf("id = %v, want office", id)
+	}
+	if keyMgmt := profiles[0][gnm.SettingWirelessSecuritySettingName]["key-mgmt"]; keyMgmt != gnm.SettingWirelessSecurityKeyMgmtWpaPsk {
+		t.Errorf("key-mgmt = %v, want %s", keyMgmt, gnm.SettingWirelessSecurityKeyMgmtWpaPsk)
+	}
+}
+
+func TestConnectWifiScan(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+
+	// The network is only found when probed for.
+	f.srv.SetScanFunc(func(s *nmfake.Server, dev *nmfake.Object, ssids []string) {
+		for _, ssid := range ssids {
+			if ssid == "hidden" {
+				s.AddAccessPoint(dev, "hidden", 2412, 60)
+			}
+		}
+	})
+
+	if _, err := f.nm.ConnectWifi(context.Background(), d, "missing", "", nil); err != gnm.ErrAccessPointNotFound {
+		t.Errorf("ConnectWifi() to a missing network = %v, want %v", err, gnm.ErrAccessPointNotFound)
+	}
+
+	ac, err := f.nm.ConnectWifi(context.Background(), d, "hidden", "", nil)
+	if err != nil {
+		t.Fatalf("ConnectWifi() = %v", err)
+	}
+	if specific, err := ac.GetPropertySpecificObject(); err != nil || specific.GetPath() == "/" {
+		t.Errorf("GetPropertySpecificObject() = %v, %v, want the access point found by the scan", specific, err)
+	}
+	if aps := dobj.Get(gnm.DeviceWirelessPropertyAccessPoints).Value(); len(aps.([]dbus.ObjectPath)) != 1 {
+		t.Errorf("access points = %v, want the one found by the scan", aps)
+	}
+
+	profiles := f.profiles(t)
+	if len(profiles) != 1 {
+		t.Fatalf("%d profiles, want 1", len(profiles))
+	}
+	if _, ok := profiles[0][gnm.SettingWirelessSecuritySettingName]; ok {
+		t.Error("the profile of an open network has a security setting")
+	}
+}
+
+func TestScanAndWait(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+	f.srv.AddAccessPoint(dobj, "home", 2412, 40)
+
+	probed := make(chan []string, 1)
+	f.srv.SetScanFunc(func(s *nmfake.Server, dev *nmfake.Object, ssids []string) {
+		probed <- ssids
+		// The scan takes a while to find the new access point.
+		time.Sleep(50 * time.Millisecond)
+		s.AddAccessPoint(dev, "office", 5180, 60)
+	})
+
+	aps, err := d.ScanAndWait(context.Background(), gnm.ScanOptions{Ssids: []string{"office"}})
+	if err != nil {
+		t.Fatalf("ScanAndWait() = %v", err)
+	}
+	if len(aps) != 2 {
+		t.Fatalf("ScanAndWait() = %d access points, want 2", len(aps))
+	}
+	if ssids := <-probed; len(ssids) != 1 || ssids[0] != "office" {
+		t.Errorf("probed SSIDs = %v, want office", ssids)
+	}
+
+	// The scan never finishes.
+	f.srv.SetScanFunc(func(s *nmfake.Server, dev *nmfake.Object, ssids []string) {
+		time.Sleep(time.Second)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := d.ScanAndWait(ctx, gnm.ScanOptions{}); err != context.DeadlineExceeded {
+		t.Errorf("ScanAndWait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSubscribeAccessPoints(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	d, dobj := newWifiDevice(t, f)
+
+	sub, err := d.SubscribeAccessPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	// Only the access point events are delivered.
+	f.srv.SetDeviceState(dobj, gnm.NmDeviceStateUnavailable, gnm.NmDeviceStateReasonNone)
+	dobj.Set(gnm.DevicePropertyMtu, uint32(1400))
+
+	ap := f.srv.AddAccessPoint(dobj, "home", 2412, 40)
+	f.srv.RemoveAccessPoint(dobj, ap)
+
+	e := nextEvent(t, sub, func(gnm.Event) bool { return true })
+	if added, ok := e.(*gnm.AccessPointAddedEvent); !ok || added.AccessPoint.GetPath() != ap.Path() {
+		t.Errorf("first event = %#v, want the AccessPointAddedEvent of %s", e, ap.Path())
+	}
+	e = nextEvent(t, sub, func(gnm.Event) bool { return true })
+	if removed, ok := e.(*gnm.AccessPointRemovedEvent); !ok || removed.AccessPoint.GetPath() != ap.Path() {
+		t.Errorf("second event = %#v, want the AccessPointRemovedEvent of %s", e, ap.Path())
+	}
+}
@@ -0,0 +1,43 @@
+package gonetworkmanager
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// GenerateWireGuardKeyPair generates a WireGuard private key and its public
+// key, both base64 encoded as in SettingWireGuard and WireGuardPeer.
+func GenerateWireGuardKeyPair() (privateKey string, publicKey string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	// Clamped like the keys of wg genkey.
+	b[0] &= 248
+	b[31] = b[31]&127 | 64
+
+	public, err := curve25519.X25519(b, curve25519.Basepoint)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), base64.StdEncoding.EncodeToString(public), nil
+}
+
+// WireGuardPublicKey returns the base64 encoded public key of a base64
+// encoded WireGuard private key.
+func WireGuardPublicKey(privateKey string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(b) != 32 {
+		return "", errors.New("invalid WireGuard private key")
+	}
+
+	public, err := curve25519.X25519(b, curve25519.Basepoint)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(public), nil
+}
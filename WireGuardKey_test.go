@@ -0,0 +1,40 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func TestGenerateWireGuardKeyPair(t *testing.T) {
+	priv, pub, err := gnm.GenerateWireGuardKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := gnm.WireGuardPublicKey(priv); err != nil || got != pub {
+		t.Errorf("WireGuardPublicKey() = %q, %v, want %q", got, err, pub)
+	}
+	if priv2, _, _ := gnm.GenerateWireGuardKeyPair(); priv2 == priv {
+		t.Error("GenerateWireGuardKeyPair() returned the same key twice")
+	}
+}
+
+func TestWireGuardPublicKey(t *testing.T) {
+	tests := []struct {
+		privateKey string
+		want       string
+		wantErr    bool
+	}{
+		// RFC 7748, section 6.1.
+		{"dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo=", "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo=", false},
+		{"not base64", "", true},
+		{"AAAA", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := gnm.WireGuardPublicKey(tt.privateKey)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("WireGuardPublicKey(%q) = %q, %v, want %q", tt.privateKey, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package gonetworkmanager_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// wireGuardKey returns a base64 encoded 32 byte key made of b.
+func wireGuardKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+// wireGuardPeers returns the peers of the wireguard setting of settings.
+func wireGuardPeers(t *testing.T, settings gnm.ConnectionSettings) []gnm.WireGuardPeer {
+	t.Helper()
+
+	var wireGuard gnm.SettingWireGuard
+	if err := settings.GetSetting(&wireGuard); err != nil {
+		t.Fatal(err)
+	}
+	return wireGuard.Peers
+}
+
+func TestWireGuardPeers(t *testing.T) {
+	f := newFake(t)
+	defer f.Close()
+
+	dobj := f.srv.AddDevice("wg0", gnm.NmDeviceTypeWireguard)
+	d, err := gnm.NewDeviceWireGuardWithConn(f.conn, dobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer := gnm.WireGuardPeer{PublicKey: wireGuardKey(1), Endpoint: "203.0.113.1:51820", AllowedIps: []string{"10.0.0.0/24"}, PresharedKey: wireGuardKey(2)}
+	if err := d.AddPeer(context.Background(), peer); err != gnm.ErrDeviceNotActive {
+		t.Fatalf("AddPeer() without an active connection = %v, want %v", err, gnm.ErrDeviceNotActive)
+	}
+
+	_, cobj := f.addConnection(t,
+		&gnm.SettingConnection{Id: "wg0", Type: gnm.SettingWireGuardSettingName, InterfaceName: "wg0"},
+		&gnm.SettingWireGuard{PrivateKey: wireGuardKey(3), Peers: []gnm.WireGuardPeer{peer}})
+	f.srv.Activate(cobj, dobj, "/")
+
+	// A new peer is added and the preshared key of the other one is kept.
+	added := gnm.WireGuardPeer{PublicKey: wireGuardKey(4), Endpoint: "203.0.113.2:51820", AllowedIps: []string{"10.0.1.0/24"}, PersistentKeepalive: 25}
+	if err := d.AddPeer(context.Background(), added); err != nil {
+		t.Fatalf("AddPeer() = %v", err)
+	}
+	peers := wireGuardPeers(t, cobj.ConnectionSettings())
+	if len(peers) != 2 || peers[0].PresharedKey != peer.PresharedKey || peers[1].PublicKey != added.PublicKey || peers[1].PersistentKeepalive != 25 {
+		t.Fatalf("profile peers after AddPeer() = %+v, want %+v and %+v", peers, peer, added)
+	}
+	applied, _ := dobj.AppliedConnection()
+	if peers := wireGuardPeers(t, applied); len(peers) != 2 || peers[1].PublicKey != added.PublicKey {
+		t.Errorf("applied peers after AddPeer() = %+v, want two", peers)
+	}
+	if key := cobj.ConnectionSettings()[gnm.SettingWireGuardSettingName]["private-key"]; key != wireGuardKey(3) {
+		t.Errorf("private-key after AddPeer() = %v, want %s", key, wireGuardKey(3))
+	}
+
+	// A peer with the same public key is replaced.
+	peer.Endpoint = "203.0.113.3:51820"
+	peer.PresharedKey = ""
+	if err := d.AddPeer(context.Background(), peer); err != nil {
+		t.Fatalf("AddPeer() = %v", err)
+	}
+	peers = wireGuardPeers(t, cobj.ConnectionSettings())
+	if len(peers) != 2 || peers[0].Endpoint != peer.Endpoint || peers[0].PresharedKey != wireGuardKey(2) {
+		t.Fatalf("profile peers after replacing a peer = %+v, want %s with its preshared key", peers, peer.Endpoint)
+	}
+
+	if err := d.RemovePeer(context.Background(), peer.PublicKey); err != nil {
+		t.Fatalf("RemovePeer() = %v", err)
+	}
+	peers = wireGuardPeers(t, cobj.ConnectionSettings())
+	if len(peers) != 1 || peers[0].PublicKey != added.PublicKey {
+		t.Errorf("profile peers after RemovePeer() = %+v, want only %s", peers, added.PublicKey)
+	}
+	applied, _ = dobj.AppliedConnection()
+	if peers := wireGuardPeers(t, applied); len(peers) != 1 || peers[0].PublicKey != added.PublicKey {
+		t.Errorf("applied peers after RemovePeer() = %+v, want only %s", peers, added.PublicKey)
+	}
+}
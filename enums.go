@@ -36,6 +36,30 @@ const (
 	NmCheckpointCreateFlagsAllowOverlapping     NmCheckpointCreateFlags = 0x08 // by default, creating a checkpoint fails if there are already existing checkoints that reference the same devices. With this flag, creation of such checkpoints is allowed, however, if an older checkpoint that references overlapping devices gets rolled back, it will automatically destroy this checkpoint during rollback. This allows to create several overlapping checkpoints in parallel, and rollback to them at will. With the special case that rolling back to an older checkpoint will invalidate all overlapping younger checkpoints. This opts-in that the checkpoint can be automatically destroyed by the rollback of an older checkpoint. (Since: 1.12)
 )
 
+//go:generate stringer -type=NmRollbackResult
+type NmRollbackResult uint32
+
+const (
+	NmRollbackResultOk                 NmRollbackResult = 0 // the rollback succeeded.
+	NmRollbackResultErrNoDevice        NmRollbackResult = 1 // the device no longer exists.
+	NmRollbackResultErrDeviceUnmanaged NmRollbackResult = 2 // the device is now unmanaged.
+	NmRollbackResultErrFailed          NmRollbackResult = 3 // other errors during rollback.
+)
+
+//go:generate stringer -type=NmSettingsUpdate2Flags
+type NmSettingsUpdate2Flags uint32
+
+const (
+	NmSettingsUpdate2FlagsNone             NmSettingsUpdate2Flags = 0x00 // an alias for numeric zero, no flags set.
+	NmSettingsUpdate2FlagsToDisk           NmSettingsUpdate2Flags = 0x01 // to persist the connection to disk.
+	NmSettingsUpdate2FlagsInMemory         NmSettingsUpdate2Flags = 0x02 // to make the connection in-memory only. If the connection was previously persistent, the corresponding file on disk is not deleted but merely the connection is decoupled from the file on disk until the next time the connection is persisted.
+	NmSettingsUpdate2FlagsInMemoryDetached NmSettingsUpdate2Flags = 0x04 // this is like NmSettingsUpdate2FlagsInMemory, but if the connection has a corresponding file on disk, the association between the connection and the file is forgotten but the file is not modified. The difference to NmSettingsUpdate2FlagsInMemory is that if the connection has a file on disk, it is not deleted on delete.
+	NmSettingsUpdate2FlagsInMemoryOnly     NmSettingsUpdate2Flags = 0x08 // this is like NmSettingsUpdate2FlagsInMemory, but if the connection has a corresponding file on disk, the file on disk will be deleted.
+	NmSettingsUpdate2FlagsVolatile         NmSettingsUpdate2Flags = 0x10 // This can be specified with either NmSettingsUpdate2FlagsInMemory, NmSettingsUpdate2FlagsInMemoryDetached or NmSettingsUpdate2FlagsInMemoryOnly. After making the connection in-memory only, the connection is marked as volatile. That means, if the connection is currently not active it will be deleted right away. Otherwise, it is marked to for deletion once the connection deactivates. A volatile connection cannot autoactivate again (because it's about to be deleted), but a manual activation will clear the volatile flag.
+	NmSettingsUpdate2FlagsBlockAutoconnect NmSettingsUpdate2Flags = 0x20 // usually, when the connection has autoconnect enabled and is modified, it becomes eligible to autoconnect right away. Setting this flag, disables autoconnect until the connection is manually activated.
+	NmSettingsUpdate2FlagsNoReapply        NmSettingsUpdate2Flags = 0x40 // when a profile gets modified that is currently active, then these changes don't take effect for the active device unless the profile gets reactivated or the configuration reapplied. There are two exceptions: by default "connection.zone" and "connection.metered" properties take effect immediately. Specify this flag to prevent these properties to take effect, so that the change is restricted to modify the profile. (Since: 1.20)
+)
+
 //go:generate stringer -type=NmCapability
 type NmCapability uint32
 
@@ -73,6 +97,91 @@ const (
 	NmDeviceStateFailed       NmDeviceState = 120 // the device failed to connect to the requested network and is cleaning up the connection request
 )
 
+//go:generate stringer -type=NmDeviceStateReason
+type NmDeviceStateReason uint32
+
+const (
+	NmDeviceStateReasonNone                        NmDeviceStateReason = 0  // No reason given
+	NmDeviceStateReasonUnknown                     NmDeviceStateReason = 1  // Unknown error
+	NmDeviceStateReasonNowManaged                  NmDeviceStateReason = 2  // Device is now managed
+	NmDeviceStateReasonNowUnmanaged                NmDeviceStateReason = 3  // Device is now unmanaged
+	NmDeviceStateReasonConfigFailed                NmDeviceStateReason = 4  // The device could not be readied for configuration
+	NmDeviceStateReasonIpConfigUnavailable         NmDeviceStateReason = 5  // IP configuration could not be reserved (no available address, timeout, etc)
+	NmDeviceStateReasonIpConfigExpired             NmDeviceStateReason = 6  // The IP config is no longer valid
+	NmDeviceStateReasonNoSecrets                   NmDeviceStateReason = 7  // Secrets were required, but not provided
+	NmDeviceStateReasonSupplicantDisconnect        NmDeviceStateReason = 8  // 802.1x supplicant disconnected
+	NmDeviceStateReasonSupplicantConfigFailed      NmDeviceStateReason = 9  // 802.1x supplicant configuration failed
+	NmDeviceStateReasonSupplicantFailed            NmDeviceStateReason = 10 // 802.1x supplicant failed
+	NmDeviceStateReasonSupplicantTimeout           NmDeviceStateReason = 11 // 802.1x supplicant took too long to authenticate
+	NmDeviceStateReasonPppStartFailed              NmDeviceStateReason = 12 // PPP service failed to start
+	NmDeviceStateReasonPppDisconnect               NmDeviceStateReason = 13 // PPP service disconnected
+	NmDeviceStateReasonPppFailed                   NmDeviceStateReason = 14 // PPP failed
+	NmDeviceStateReasonDhcpStartFailed             NmDeviceStateReason = 15 // DHCP client failed to start
+	NmDeviceStateReasonDhcpError                   NmDeviceStateReason = 16 // DHCP client error
+	NmDeviceStateReasonDhcpFailed                  NmDeviceStateReason = 17 // DHCP client failed
+	NmDeviceStateReasonSharedStartFailed           NmDeviceStateReason = 18 // Shared connection service failed to start
+	NmDeviceStateReasonSharedFailed                NmDeviceStateReason = 19 // Shared connection service failed
+	NmDeviceStateReasonAutoipStartFailed           NmDeviceStateReason = 20 // AutoIP service failed to start
+	NmDeviceStateReasonAutoipError                 NmDeviceStateReason = 21 // AutoIP service error
+	NmDeviceStateReasonAutoipFailed                NmDeviceStateReason = 22 // AutoIP service failed
+	NmDeviceStateReasonModemBusy                   NmDeviceStateReason = 23 // The line is busy
+	NmDeviceStateReasonModemNoDialTone             NmDeviceStateReason = 24 // No dial tone
+	NmDeviceStateReasonModemNoCarrier              NmDeviceStateReason = 25 // No carrier could be established
+	NmDeviceStateReasonModemDialTimeout            NmDeviceStateReason = 26 // The dialing request timed out
+	NmDeviceStateReasonModemDialFailed             NmDeviceStateReason = 27 // The dialing attempt failed
+	NmDeviceStateReasonModemInitFailed             NmDeviceStateReason = 28 // Modem initialization failed
+	NmDeviceStateReasonGsmApnFailed                NmDeviceStateReason = 29 // Failed to select the specified APN
+	NmDeviceStateReasonGsmRegistrationNotSearching NmDeviceStateReason = 30 // Not searching for networks
+	NmDeviceStateReasonGsmRegistrationDenied       NmDeviceStateReason = 31 // Network registration denied
+	NmDeviceStateReasonGsmRegistrationTimeout      NmDeviceStateReason = 32 // Network registration timed out
+	NmDeviceStateReasonGsmRegistrationFailed       NmDeviceStateReason = 33 // Failed to register with the requested network
+	NmDeviceStateReasonGsmPinCheckFailed           NmDeviceStateReason = 34 // PIN check failed
+	NmDeviceStateReasonFirmwareMissing             NmDeviceStateReason = 35 // Necessary firmware for the device may be missing
+	NmDeviceStateReasonRemoved                     NmDeviceStateReason = 36 // The device was removed
+	NmDeviceStateReasonSleeping                    NmDeviceStateReason = 37 // NetworkManager went to sleep
+	NmDeviceStateReasonConnectionRemoved           NmDeviceStateReason = 38 // The device's active connection disappeared
+	NmDeviceStateReasonUserRequested               NmDeviceStateReason = 39 // Device disconnected by user or client
+	NmDeviceStateReasonCarrier                     NmDeviceStateReason = 40 // Carrier/link changed
+	NmDeviceStateReasonConnectionAssumed           NmDeviceStateReason = 41 // The device's existing connection was assumed
+	NmDeviceStateReasonSupplicantAvailable         NmDeviceStateReason = 42 // The supplicant is now available
+	NmDeviceStateReasonModemNotFound               NmDeviceStateReason = 43 // The modem could not be found
+	NmDeviceStateReasonBtFailed                    NmDeviceStateReason = 44 // The Bluetooth connection failed or timed out
+	NmDeviceStateReasonGsmSimNotInserted           NmDeviceStateReason = 45 // GSM Modem's SIM Card not inserted
+	NmDeviceStateReasonGsmSimPinRequired           NmDeviceStateReason = 46 // GSM Modem's SIM Pin required
+	NmDeviceStateReasonGsmSimPukRequired           NmDeviceStateReason = 47 // GSM Modem's SIM Puk required
+	NmDeviceStateReasonGsmSimWrong                 NmDeviceStateReason = 48 // GSM Modem's SIM wrong
+	NmDeviceStateReasonInfinibandMode              NmDeviceStateReason = 49 // InfiniBand device does not support connected mode
+	NmDeviceStateReasonDependencyFailed            NmDeviceStateReason = 50 // A dependency of the connection failed
+	NmDeviceStateReasonBr2684Failed                NmDeviceStateReason = 51 // Problem with the RFC 2684 Ethernet over ADSL bridge
+	NmDeviceStateReasonModemManagerUnavailable     NmDeviceStateReason = 52 // ModemManager not running
+	NmDeviceStateReasonSsidNotFound                NmDeviceStateReason = 53 // The Wi-Fi network could not be found
+	NmDeviceStateReasonSecondaryConnectionFailed   NmDeviceStateReason = 54 // A secondary connection of the base connection failed
+	NmDeviceStateReasonDcbFcoeFailed               NmDeviceStateReason = 55 // DCB or FCoE setup failed
+	NmDeviceStateReasonTeamdControlFailed          NmDeviceStateReason = 56 // teamd control failed
+	NmDeviceStateReasonModemFailed                 NmDeviceStateReason = 57 // Modem failed or no longer available
+	NmDeviceStateReasonModemAvailable              NmDeviceStateReason = 58 // Modem now ready and available
+	NmDeviceStateReasonSimPinIncorrect             NmDeviceStateReason = 59 // SIM PIN was incorrect
+	NmDeviceStateReasonNewActivation               NmDeviceStateReason = 60 // New connection activation was enqueued
+	NmDeviceStateReasonParentChanged               NmDeviceStateReason = 61 // the device's parent changed
+	NmDeviceStateReasonParentManagedChanged        NmDeviceStateReason = 62 // the device parent's management changed
+	NmDeviceStateReasonOvsdbFailed                 NmDeviceStateReason = 63 // problem communicating with Open vSwitch database
+	NmDeviceStateReasonIpAddressDuplicate          NmDeviceStateReason = 64 // a duplicate IP address was detected
+	NmDeviceStateReasonIpMethodUnsupported         NmDeviceStateReason = 65 // The selected IP method is not supported
+	NmDeviceStateReasonSriovConfigurationFailed    NmDeviceStateReason = 66 // configuration of SR-IOV parameters failed
+	NmDeviceStateReasonPeerNotFound                NmDeviceStateReason = 67 // The Wi-Fi P2P peer could not be found
+	NmDeviceStateReasonDeviceHandlerFailed         NmDeviceStateReason = 68 // The device handler dispatcher returned an error
+	NmDeviceStateReasonUnmanagedByDefault          NmDeviceStateReason = 69 // The device is unmanaged because the device type is unmanaged by default
+	NmDeviceStateReasonUnmanagedExternalDown       NmDeviceStateReason = 70 // The device is unmanaged because it is an external device and is unconfigured (down or without addresses)
+	NmDeviceStateReasonUnmanagedLinkNotInit        NmDeviceStateReason = 71 // The device is unmanaged because the link is not initialized by udev
+	NmDeviceStateReasonUnmanagedQuitting           NmDeviceStateReason = 72 // The device is unmanaged because NetworkManager is quitting
+	NmDeviceStateReasonUnmanagedSleeping           NmDeviceStateReason = 73 // The device is unmanaged because networking is disabled or the system is suspended
+	NmDeviceStateReasonUnmanagedUserConf           NmDeviceStateReason = 74 // The device is unmanaged by user decision in NetworkManager.conf
+	NmDeviceStateReasonUnmanagedUserExplicit       NmDeviceStateReason = 75 // The device is unmanaged by explicit user decision, e.g. 'nmcli device set $DEV managed no'
+	NmDeviceStateReasonUnmanagedUserSettings       NmDeviceStateReason = 76 // The device is unmanaged by user decision via a settings plugin
+	NmDeviceStateReasonUnmanagedUserUdev           NmDeviceStateReason = 77 // The device is unmanaged via an udev rule
+	NmDeviceStateReasonNetworkingOff               NmDeviceStateReason = 78 // NetworkManager was disabled (networking off)
+)
+
 //go:generate stringer -type=NmActiveConnectionState
 type NmActiveConnectionState uint32
 
@@ -84,6 +193,27 @@ const (
 	NmActiveConnectionStateDeactivated  NmActiveConnectionState = 4 // The network connection is disconnected and will be removed
 )
 
+//go:generate stringer -type=NmActiveConnectionStateReason
+type NmActiveConnectionStateReason uint32
+
+const (
+	NmActiveConnectionStateReasonUnknown             NmActiveConnectionStateReason = 0  // The reason for the active connection state change is unknown.
+	NmActiveConnectionStateReasonNone                NmActiveConnectionStateReason = 1  // No reason was given for the active connection state change.
+	NmActiveConnectionStateReasonUserDisconnected    NmActiveConnectionStateReason = 2  // The active connection changed state because the user disconnected it.
+	NmActiveConnectionStateReasonDeviceDisconnected  NmActiveConnectionStateReason = 3  // The active connection changed state because the device it was using was disconnected.
+	NmActiveConnectionStateReasonServiceStopped      NmActiveConnectionStateReason = 4  // The service providing the VPN connection was stopped.
+	NmActiveConnectionStateReasonIpConfigInvalid     NmActiveConnectionStateReason = 5  // The IP config of the active connection was invalid.
+	NmActiveConnectionStateReasonConnectTimeout      NmActiveConnectionStateReason = 6  // The connection attempt to the VPN service timed out.
+	NmActiveConnectionStateReasonServiceStartTimeout NmActiveConnectionStateReason = 7  // A timeout occurred while starting the service providing the VPN connection.
+	NmActiveConnectionStateReasonServiceStartFailed  NmActiveConnectionStateReason = 8  // Starting the service providing the VPN connection failed.
+	NmActiveConnectionStateReasonNoSecrets           NmActiveConnectionStateReason = 9  // Necessary secrets for the connection were not provided.
+	NmActiveConnectionStateReasonLoginFailed         NmActiveConnectionStateReason = 10 // Authentication to the server failed.
+	NmActiveConnectionStateReasonConnectionRemoved   NmActiveConnectionStateReason = 11 // The connection was deleted from settings.
+	NmActiveConnectionStateReasonDependencyFailed    NmActiveConnectionStateReason = 12 // Master connection of this connection failed to activate.
+	NmActiveConnectionStateReasonDeviceRealizeFailed NmActiveConnectionStateReason = 13 // Could not create the software device link.
+	NmActiveConnectionStateReasonDeviceRemoved       NmActiveConnectionStateReason = 14 // The device this connection depended on disappeared.
+)
+
 //go:generate stringer -type=NmActivationStateFlag
 type NmActivationStateFlag uint32
 
@@ -141,23 +271,30 @@ type Nm80211APFlags uint32
 const (
 	Nm80211APFlagsNone    Nm80211APFlags = 0x0
 	Nm80211APFlagsPrivacy Nm80211APFlags = 0x1
+	Nm80211APFlagsWps     Nm80211APFlags = 0x2
+	Nm80211APFlagsWpsPbc  Nm80211APFlags = 0x4
+	Nm80211APFlagsWpsPin  Nm80211APFlags = 0x8
 )
 
 //go:generate stringer -type=Nm80211APSec
 type Nm80211APSec uint32
 
 const (
-	Nm80211APSecNone         Nm80211APSec = 0x0
-	Nm80211APSecPairWEP40    Nm80211APSec = 0x1
-	Nm80211APSecPairWEP104   Nm80211APSec = 0x2
-	Nm80211APSecPairTKIP     Nm80211APSec = 0x4
-	Nm80211APSecPairCCMP     Nm80211APSec = 0x8
-	Nm80211APSecGroupWEP40   Nm80211APSec = 0x10
-	Nm80211APSecGroupWEP104  Nm80211APSec = 0x20
-	Nm80211APSecGroupTKIP    Nm80211APSec = 0x40
-	Nm80211APSecGroupCCMP    Nm80211APSec = 0x80
-	Nm80211APSecKeyMgmtPSK   Nm80211APSec = 0x100
-	Nm80211APSecKeyMgmt8021X Nm80211APSec = 0x200
+	Nm80211APSecNone                Nm80211APSec = 0x0
+	Nm80211APSecPairWEP40           Nm80211APSec = 0x1
+	Nm80211APSecPairWEP104          Nm80211APSec = 0x2
+	Nm80211APSecPairTKIP            Nm80211APSec = 0x4
+	Nm80211APSecPairCCMP            Nm80211APSec = 0x8
+	Nm80211APSecGroupWEP40          Nm80211APSec = 0x10
+	Nm80211APSecGroupWEP104         Nm80211APSec = 0x20
+	Nm80211APSecGroupTKIP           Nm80211APSec = 0x40
+	Nm80211APSecGroupCCMP           Nm80211APSec = 0x80
+	Nm80211APSecKeyMgmtPSK          Nm80211APSec = 0x100
+	Nm80211APSecKeyMgmt8021X        Nm80211APSec = 0x200
+	Nm80211APSecKeyMgmtSAE          Nm80211APSec = 0x400
+	Nm80211APSecKeyMgmtOWE          Nm80211APSec = 0x800
+	Nm80211APSecKeyMgmtOWETM        Nm80211APSec = 0x1000
+	Nm80211APSecKeyMgmtEAPSuiteB192 Nm80211APSec = 0x2000
 )
 
 //go:generate stringer -type=Nm80211Mode
@@ -169,3 +306,119 @@ const (
 	Nm80211ModeInfra   Nm80211Mode = 2
 	Nm80211ModeAp      Nm80211Mode = 3
 )
+
+//go:generate stringer -type=NmDeviceWifiCapabilities
+type NmDeviceWifiCapabilities uint32
+
+const (
+	NmDeviceWifiCapabilitiesNone         NmDeviceWifiCapabilities = 0x0    // device has no encryption/authentication capabilities
+	NmDeviceWifiCapabilitiesCipherWEP40  NmDeviceWifiCapabilities = 0x1    // device supports 40/64-bit WEP encryption
+	NmDeviceWifiCapabilitiesCipherWEP104 NmDeviceWifiCapabilities = 0x2    // device supports 104/128-bit WEP encryption
+	NmDeviceWifiCapabilitiesCipherTKIP   NmDeviceWifiCapabilities = 0x4    // device supports TKIP encryption
+	NmDeviceWifiCapabilitiesCipherCCMP   NmDeviceWifiCapabilities = 0x8    // device supports AES/CCMP encryption
+	NmDeviceWifiCapabilitiesWPA          NmDeviceWifiCapabilities = 0x10   // device supports WPA1 authentication
+	NmDeviceWifiCapabilitiesRSN          NmDeviceWifiCapabilities = 0x20   // device supports WPA2/RSN authentication
+	NmDeviceWifiCapabilitiesAP           NmDeviceWifiCapabilities = 0x40   // device supports Access Point mode
+	NmDeviceWifiCapabilitiesAdhoc        NmDeviceWifiCapabilities = 0x80   // device supports Ad-Hoc mode
+	NmDeviceWifiCapabilitiesFreqValid    NmDeviceWifiCapabilities = 0x100  // device reports frequency capabilities
+	NmDeviceWifiCapabilitiesFreq2GHz     NmDeviceWifiCapabilities = 0x200  // device supports 2.4GHz frequencies
+	NmDeviceWifiCapabilitiesFreq5GHz     NmDeviceWifiCapabilities = 0x400  // device supports 5GHz frequencies
+	NmDeviceWifiCapabilitiesFreq6GHz     NmDeviceWifiCapabilities = 0x800  // device supports 6GHz frequencies (Since: 1.46)
+	NmDeviceWifiCapabilitiesMesh         NmDeviceWifiCapabilities = 0x1000 // device supports acting as a mesh point (Since: 1.20)
+	NmDeviceWifiCapabilitiesIBSSRSN      NmDeviceWifiCapabilities = 0x2000 // device supports WPA2/RSN in an IBSS network (Since: 1.22)
+)
+
+//go:generate stringer -type=NmDeviceModemCapabilities
+type NmDeviceModemCapabilities uint32
+
+const (
+	NmDeviceModemCapabilitiesNone     NmDeviceModemCapabilities = 0x0  // modem has no usable capabilities
+	NmDeviceModemCapabilitiesPots     NmDeviceModemCapabilities = 0x1  // modem uses the analog wired telephone network and is not a "mobile broadband" device
+	NmDeviceModemCapabilitiesCdmaEvdo NmDeviceModemCapabilities = 0x2  // modem supports at least one of CDMA 1xRTT, EVDO revision 0, EVDO revision A, or EVDO revision B
+	NmDeviceModemCapabilitiesGsmUmts  NmDeviceModemCapabilities = 0x4  // modem supports at least one of GSM, GPRS, EDGE, UMTS, HSDPA, HSUPA, or HSPA+ packet switched data capability
+	NmDeviceModemCapabilitiesLte      NmDeviceModemCapabilities = 0x8  // modem has LTE data capability
+	NmDeviceModemCapabilities5GNR     NmDeviceModemCapabilities = 0x40 // modem has 5GNR data capability (Since: 1.36)
+)
+
+//go:generate stringer -type=NmSecretAgentGetSecretsFlags
+type NmSecretAgentGetSecretsFlags uint32
+
+const (
+	NmSecretAgentGetSecretsFlagsNone             NmSecretAgentGetSecretsFlags = 0x0        // no special behavior; by default no user interaction is allowed and requests for secrets are fulfilled from persistent storage, or if no secrets are available an error is returned.
+	NmSecretAgentGetSecretsFlagsAllowInteraction NmSecretAgentGetSecretsFlags = 0x1        // allows the request to interact with the user, possibly prompting via UI for secrets if any are required, or if none are found in persistent storage.
+	NmSecretAgentGetSecretsFlagsRequestNew       NmSecretAgentGetSecretsFlags = 0x2        // explicitly prompt for new secrets from the user. This flag signals that NetworkManager thinks any existing secrets are invalid or wrong. This flag implies that interaction is allowed.
+	NmSecretAgentGetSecretsFlagsUserRequested    NmSecretAgentGetSecretsFlags = 0x4        // set if the request was initiated by user-requested action via the D-Bus interface, as opposed to automatically initiated by NetworkManager in response to (for example) scan results or carrier changes.
+	NmSecretAgentGetSecretsFlagsWpsPbcActive     NmSecretAgentGetSecretsFlags = 0x8        // indicates that WPS enrollment is active with PBC method. The agent may suggest that the user pushes a button on the router instead of supplying a PSK.
+	NmSecretAgentGetSecretsFlagsNoErrors         NmSecretAgentGetSecretsFlags = 0x40000000 // internal flag, not part of the D-Bus API.
+	NmSecretAgentGetSecretsFlagsOnlySystem       NmSecretAgentGetSecretsFlags = 0x80000000 // internal flag, not part of the D-Bus API.
+)
+
+//go:generate stringer -type=NmSecretAgentCapabilities
+type NmSecretAgentCapabilities uint32
+
+const (
+	NmSecretAgentCapabilitiesNone     NmSecretAgentCapabilities = 0x0 // the agent supports no special capabilities
+	NmSecretAgentCapabilitiesVpnHints NmSecretAgentCapabilities = 0x1 // the agent supports passing hints to VPN plugin authentication dialogs.
+)
+
+//go:generate stringer -type=MmModemState
+type MmModemState int32
+
+const (
+	MmModemStateFailed        MmModemState = -1 // the modem is unusable
+	MmModemStateUnknown       MmModemState = 0  // state unknown or not reportable
+	MmModemStateInitializing  MmModemState = 1  // the modem is currently being initialized
+	MmModemStateLocked        MmModemState = 2  // the modem needs to be unlocked
+	MmModemStateDisabled      MmModemState = 3  // the modem is not enabled and is powered down
+	MmModemStateDisabling     MmModemState = 4  // the modem is currently transitioning to the disabled state
+	MmModemStateEnabling      MmModemState = 5  // the modem is currently transitioning to the enabled state
+	MmModemStateEnabled       MmModemState = 6  // the modem is enabled and powered on but not registered with a network provider and not available for data connections
+	MmModemStateSearching     MmModemState = 7  // the modem is searching for a network provider to register with
+	MmModemStateRegistered    MmModemState = 8  // the modem is registered with a network provider, and data connections and messaging may be available for use
+	MmModemStateDisconnecting MmModemState = 9  // the modem is disconnecting and deactivating the last active packet data bearer
+	MmModemStateConnecting    MmModemState = 10 // the modem is activating and connecting the first packet data bearer
+	MmModemStateConnected     MmModemState = 11 // one or more packet data bearers is active and connected
+)
+
+//go:generate stringer -type=MmModemAccessTechnology
+type MmModemAccessTechnology uint32
+
+const (
+	MmModemAccessTechnologyUnknown    MmModemAccessTechnology = 0x0     // the access technology used is unknown
+	MmModemAccessTechnologyPots       MmModemAccessTechnology = 0x1     // analog wireline telephone
+	MmModemAccessTechnologyGsm        MmModemAccessTechnology = 0x2     // GSM
+	MmModemAccessTechnologyGsmCompact MmModemAccessTechnology = 0x4     // Compact GSM
+	MmModemAccessTechnologyGprs       MmModemAccessTechnology = 0x8     // GPRS
+	MmModemAccessTechnologyEdge       MmModemAccessTechnology = 0x10    // EDGE (ETSI 27.007: "GSM w/EGPRS")
+	MmModemAccessTechnologyUmts       MmModemAccessTechnology = 0x20    // UMTS (ETSI 27.007: "UTRAN")
+	MmModemAccessTechnologyHsdpa      MmModemAccessTechnology = 0x40    // HSDPA (ETSI 27.007: "UTRAN w/HSDPA")
+	MmModemAccessTechnologyHsupa      MmModemAccessTechnology = 0x80    // HSUPA (ETSI 27.007: "UTRAN w/HSUPA")
+	MmModemAccessTechnologyHspa       MmModemAccessTechnology = 0x100   // HSPA (ETSI 27.007: "UTRAN w/HSDPA and HSUPA")
+	MmModemAccessTechnologyHspaPlus   MmModemAccessTechnology = 0x200   // HSPA+ (ETSI 27.007: "UTRAN w/HSPA+")
+	MmModemAccessTechnology1xrtt      MmModemAccessTechnology = 0x400   // CDMA2000 1xRTT
+	MmModemAccessTechnologyEvdo0      MmModemAccessTechnology = 0x800   // CDMA2000 EVDO revision 0
+	MmModemAccessTechnologyEvdoA      MmModemAccessTechnology = 0x1000  // CDMA2000 EVDO revision A
+	MmModemAccessTechnologyEvdoB      MmModemAccessTechnology = 0x2000  // CDMA2000 EVDO revision B
+	MmModemAccessTechnologyLte        MmModemAccessTechnology = 0x4000  // LTE (ETSI 27.007: "E-UTRAN")
+	MmModemAccessTechnology5GNR       MmModemAccessTechnology = 0x8000  // 5GNR (ETSI 27.007: "NG-RAN")
+	MmModemAccessTechnologyLteCatM    MmModemAccessTechnology = 0x10000 // Cat-M (ETSI 23.401: LTE Category M1/M2)
+	MmModemAccessTechnologyLteNbIot   MmModemAccessTechnology = 0x20000 // NB IoT (ETSI 23.401: LTE Category NB1/NB2)
+)
+
+//go:generate stringer -type=MmModem3gppRegistrationState
+type MmModem3gppRegistrationState uint32
+
+const (
+	MmModem3gppRegistrationStateIdle                    MmModem3gppRegistrationState = 0  // not registered, not searching for new operator to register
+	MmModem3gppRegistrationStateHome                    MmModem3gppRegistrationState = 1  // registered on home network
+	MmModem3gppRegistrationStateSearching               MmModem3gppRegistrationState = 2  // not registered, searching for new operator to register with
+	MmModem3gppRegistrationStateDenied                  MmModem3gppRegistrationState = 3  // registration denied
+	MmModem3gppRegistrationStateUnknown                 MmModem3gppRegistrationState = 4  // unknown registration status
+	MmModem3gppRegistrationStateRoaming                 MmModem3gppRegistrationState = 5  // registered on a roaming network
+	MmModem3gppRegistrationStateHomeSmsOnly             MmModem3gppRegistrationState = 6  // registered for "SMS only", home network
+	MmModem3gppRegistrationStateRoamingSmsOnly          MmModem3gppRegistrationState = 7  // registered for "SMS only", roaming network
+	MmModem3gppRegistrationStateEmergencyOnly           MmModem3gppRegistrationState = 8  // emergency services only
+	MmModem3gppRegistrationStateHomeCsfbNotPreferred    MmModem3gppRegistrationState = 9  // registered for "CSFB not preferred", home network
+	MmModem3gppRegistrationStateRoamingCsfbNotPreferred MmModem3gppRegistrationState = 10 // registered for "CSFB not preferred", roaming network
+	MmModem3gppRegistrationStateAttachedRlos            MmModem3gppRegistrationState = 11 // attached for access to Restricted Local Operator Services
+)
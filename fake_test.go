@@ -0,0 +1,100 @@
+package gonetworkmanager_test
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+// fake is a fake NetworkManager on a private bus and a client connected to
+// it.
+type fake struct {
+	srv  *nmfake.Server
+	conn *dbus.Conn
+	nm   gnm.NetworkManager
+}
+
+// newFake starts a fake NetworkManager, skipping the test when no
+// dbus-daemon is available to run it.
+func newFake(t *testing.T) *fake {
+	t.Helper()
+
+	srv, err := nmfake.New()
+	if err != nil {
+		t.Skipf("cannot start the fake NetworkManager: %v", err)
+	}
+
+	conn, err := srv.Dial()
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+
+	nm, err := gnm.NewNetworkManagerWithConn(conn)
+	if err != nil {
+		conn.Close()
+		srv.Close()
+		t.Fatal(err)
+	}
+
+	return &fake{srv: srv, conn: conn, nm: nm}
+}
+
+func (f *fake) Close() {
+	f.conn.Close()
+	f.srv.Close()
+}
+
+// addConnection adds a saved ethernet profile with settings and returns it
+// along with its fake object.
+func (f *fake) addConnection(t *testing.T, settings ...gnm.Setting) (gnm.Connection, *nmfake.Object) {
+	t.Helper()
+
+	settings = append([]gnm.Setting{&gnm.SettingConnection{Id: "test", Type: gnm.SettingWiredSettingName}}, settings...)
+	cs, err := gnm.NewConnectionSettings(settings...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := f.srv.AddConnection(cs)
+	c, err := gnm.NewConnectionWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, obj
+}
+
+// addDevice adds a device to the fake and returns it.
+func (f *fake) addDevice(t *testing.T, iface string, deviceType gnm.NmDeviceType) (gnm.Device, *nmfake.Object) {
+	t.Helper()
+
+	obj := f.srv.AddDevice(iface, deviceType)
+	d, err := gnm.NewDeviceWithConn(f.conn, obj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, obj
+}
+
+// profiles returns the settings of the saved profiles, secrets included.
+func (f *fake) profiles(t *testing.T) []gnm.ConnectionSettings {
+	t.Helper()
+
+	s, err := gnm.NewSettingsWithConn(f.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connections, err := s.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profiles := make([]gnm.ConnectionSettings, len(connections))
+	for i, c := range connections {
+		profiles[i] = f.srv.Object(c.GetPath()).ConnectionSettings()
+	}
+	return profiles
+}
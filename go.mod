@@ -1,5 +1,8 @@
 module github.com/Wifx/gonetworkmanager
 
-go 1.12
+go 1.13
 
-require github.com/godbus/dbus/v5 v5.0.2
+require (
+	github.com/godbus/dbus/v5 v5.0.2
+	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
+)
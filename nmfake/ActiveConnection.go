@@ -0,0 +1,161 @@
+package nmfake
+
+import (
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// Activate starts activating a connection profile, exactly like an
+// ActivateConnection call would. dev may be nil, in which case the device is
+// chosen by the connection.interface-name setting.
+func (s *Server) Activate(c *Object, dev *Object, specificObject dbus.ObjectPath) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.startActivation(c, dev, specificObject)
+}
+
+// SetActiveConnectionState moves an active connection to a new state and
+// emits StateChanged. The activated state also makes the connection the
+// primary one and the deactivated state removes it.
+func (s *Server) SetActiveConnectionState(ac *Object, state gnm.NmActiveConnectionState, reason gnm.NmActiveConnectionStateReason) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setActiveConnectionState(ac, state, reason)
+}
+
+func (s *Server) startActivation(c *Object, dev *Object, specificObject dbus.ObjectPath) *Object {
+	if dev == nil {
+		name := settingString(c.settings, "connection", "interface-name")
+		for _, path := range s.manager.get(gnm.NetworkManagerPropertyDevices).([]dbus.ObjectPath) {
+			if name != "" && s.objects[path].get(gnm.DevicePropertyInterface) == name {
+				dev = s.objects[path]
+				break
+			}
+		}
+	}
+
+	devices := []dbus.ObjectPath{}
+	if dev != nil {
+		if ac, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; ok {
+			s.deactivate(ac, gnm.NmActiveConnectionStateReasonDeviceDisconnected)
+		}
+		devices = append(devices, dev.path)
+	}
+
+	if specificObject == "" {
+		specificObject = "/"
+	}
+
+	path := s.nextPath("ActiveConnection")
+	ac := s.newObject(path, map[string]map[string]interface{}{
+		gnm.ActiveConnectionInterface: {
+			"Connection":     c.path,
+			"SpecificObject": specificObject,
+			"Id":             settingString(c.settings, "connection", "id"),
+			"Uuid":           settingString(c.settings, "connection", "uuid"),
+			"Type":           settingString(c.settings, "connection", "type"),
+			"Devices":        devices,
+			"State":          uint32(gnm.NmActiveConnectionStateActivating),
+			"StateFlags":     uint32(0),
+			"Default":        false,
+			"Ip4Config":      dbus.ObjectPath("/"),
+			"Dhcp4Config":    dbus.ObjectPath("/"),
+			"Default6":       false,
+			"Ip6Config":      dbus.ObjectPath("/"),
+			"Dhcp6Config":    dbus.ObjectPath("/"),
+			"Vpn":            false,
+			"Master":         dbus.ObjectPath("/"),
+		},
+	})
+
+	s.manager.set(gnm.NetworkManagerPropertyActiveConnections, appendPath(s.manager.get(gnm.NetworkManagerPropertyActiveConnections).([]dbus.ObjectPath), path))
+	s.manager.set(gnm.NetworkManagerPropertyActivatingConnection, path)
+	if s.manager.get(gnm.NetworkManagerPropertyState).(uint32) < uint32(gnm.NmStateConnecting) {
+		s.setManagerState(gnm.NmStateConnecting)
+	}
+
+	if dev != nil {
+		dev.settings = copySettings(c.settings)
+		dev.version++
+		dev.set(gnm.DevicePropertyActiveConnection, path)
+		s.setDeviceState(dev, gnm.NmDeviceStatePrepare, gnm.NmDeviceStateReasonNewActivation)
+	}
+
+	if s.activate != nil {
+		go s.activate(s, ac)
+	}
+
+	return ac
+}
+
+func (s *Server) setActiveConnectionState(ac *Object, state gnm.NmActiveConnectionState, reason gnm.NmActiveConnectionStateReason) {
+	if ac == nil || s.objects[ac.path] != ac {
+		return
+	}
+
+	if ac.get(gnm.ActiveConnectionPropertyState).(uint32) == uint32(state) {
+		return
+	}
+
+	ac.set(gnm.ActiveConnectionPropertyState, uint32(state))
+	ac.Emit(gnm.ActiveConnectionInterface+".StateChanged", uint32(state), uint32(reason))
+
+	switch state {
+	case gnm.NmActiveConnectionStateActivated:
+		if s.manager.get(gnm.NetworkManagerPropertyActivatingConnection) == ac.path {
+			s.manager.set(gnm.NetworkManagerPropertyActivatingConnection, dbus.ObjectPath("/"))
+		}
+		if s.manager.get(gnm.NetworkManagerPropertyPrimaryConnection) == dbus.ObjectPath("/") {
+			ac.set(gnm.ActiveConnectionPropertyDefault, true)
+			s.manager.set(gnm.NetworkManagerPropertyPrimaryConnection, ac.path)
+			s.manager.set(gnm.NetworkManagerPropertyPrimaryConnectionType, ac.get(gnm.ActiveConnectionPropertyType))
+		}
+		s.setManagerState(gnm.NmStateConnectedGlobal)
+
+	case gnm.NmActiveConnectionStateDeactivated:
+		s.removeActiveConnection(ac)
+	}
+}
+
+func (s *Server) deactivate(ac *Object, reason gnm.NmActiveConnectionStateReason) {
+	deviceReason := gnm.NmDeviceStateReasonNone
+	switch reason {
+	case gnm.NmActiveConnectionStateReasonUserDisconnected:
+		deviceReason = gnm.NmDeviceStateReasonUserRequested
+	case gnm.NmActiveConnectionStateReasonDeviceRemoved:
+		deviceReason = gnm.NmDeviceStateReasonRemoved
+	}
+
+	s.setActiveConnectionState(ac, gnm.NmActiveConnectionStateDeactivating, reason)
+	for _, path := range ac.get(gnm.ActiveConnectionPropertyDevices).([]dbus.ObjectPath) {
+		s.setDeviceState(s.objects[path], gnm.NmDeviceStateDisconnected, deviceReason)
+	}
+	s.setActiveConnectionState(ac, gnm.NmActiveConnectionStateDeactivated, reason)
+}
+
+func (s *Server) removeActiveConnection(ac *Object) {
+	for _, path := range ac.get(gnm.ActiveConnectionPropertyDevices).([]dbus.ObjectPath) {
+		if dev, ok := s.objects[path]; ok && dev.get(gnm.DevicePropertyActiveConnection) == ac.path {
+			dev.set(gnm.DevicePropertyActiveConnection, dbus.ObjectPath("/"))
+		}
+	}
+
+	remaining := removePath(s.manager.get(gnm.NetworkManagerPropertyActiveConnections).([]dbus.ObjectPath), ac.path)
+	s.manager.set(gnm.NetworkManagerPropertyActiveConnections, remaining)
+
+	if s.manager.get(gnm.NetworkManagerPropertyActivatingConnection) == ac.path {
+		s.manager.set(gnm.NetworkManagerPropertyActivatingConnection, dbus.ObjectPath("/"))
+	}
+	if s.manager.get(gnm.NetworkManagerPropertyPrimaryConnection) == ac.path {
+		s.manager.set(gnm.NetworkManagerPropertyPrimaryConnection, dbus.ObjectPath("/"))
+		s.manager.set(gnm.NetworkManagerPropertyPrimaryConnectionType, "")
+	}
+	if len(remaining) == 0 {
+		s.setManagerState(gnm.NmStateDisconnected)
+	}
+
+	s.removeObject(ac)
+}
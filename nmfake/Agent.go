@@ -0,0 +1,158 @@
+package nmfake
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+const (
+	agentManagerErrorPermissionDenied  = gnm.AgentManagerInterface + ".PermissionDenied"
+	agentManagerErrorInvalidIdentifier = gnm.AgentManagerInterface + ".InvalidIdentifier"
+	agentManagerErrorNotRegistered     = gnm.AgentManagerInterface + ".NotRegistered"
+)
+
+// ErrNoAgent is returned by GetAgentSecrets when no secret agent is
+// registered.
+var ErrNoAgent = errors.New("nmfake: no secret agent registered")
+
+type agent struct {
+	sender       string
+	identifier   string
+	capabilities gnm.NmSecretAgentCapabilities
+}
+
+// Agents returns the identifiers of the registered secret agents, in
+// registration order.
+func (s *Server) Agents() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identifiers := make([]string, len(s.agents))
+	for i, a := range s.agents {
+		identifiers[i] = a.identifier
+	}
+	return identifiers
+}
+
+// GetAgentSecrets asks the first registered secret agent for the secrets of
+// a setting of the connection profile c, like NetworkManager does when
+// activating a connection lacking them. Canceling ctx sends CancelGetSecrets
+// to the agent.
+func (s *Server) GetAgentSecrets(ctx context.Context, c *Object, settingName string, hints []string, flags gnm.NmSecretAgentGetSecretsFlags) (gnm.ConnectionSettings, error) {
+	obj, err := s.agentObject()
+	if err != nil {
+		return nil, err
+	}
+
+	call := obj.GoWithContext(context.Background(), gnm.SecretAgentGetSecrets, 0, nil,
+		toVariantSettings(c.ConnectionSettings()), c.Path(), settingName, hints, uint32(flags))
+
+	select {
+	case <-call.Done:
+	case <-ctx.Done():
+		obj.Call(gnm.SecretAgentCancelGetSecrets, 0, c.Path(), settingName)
+		<-call.Done
+	}
+
+	var secrets map[string]map[string]dbus.Variant
+	if err := call.Store(&secrets); err != nil {
+		return nil, err
+	}
+
+	rv := make(gnm.ConnectionSettings)
+	for name, values := range secrets {
+		rv[name] = make(map[string]interface{})
+		for key, value := range values {
+			rv[name][key] = value.Value()
+		}
+	}
+	return rv, nil
+}
+
+// SaveAgentSecrets asks the first registered secret agent to save the
+// secrets of the connection profile c.
+func (s *Server) SaveAgentSecrets(c *Object) error {
+	obj, err := s.agentObject()
+	if err != nil {
+		return err
+	}
+	return obj.Call(gnm.SecretAgentSaveSecrets, 0, toVariantSettings(c.ConnectionSettings()), c.Path()).Err
+}
+
+// DeleteAgentSecrets asks the first registered secret agent to delete the
+// secrets of the connection profile c.
+func (s *Server) DeleteAgentSecrets(c *Object) error {
+	obj, err := s.agentObject()
+	if err != nil {
+		return err
+	}
+	return obj.Call(gnm.SecretAgentDeleteSecrets, 0, toVariantSettings(c.ConnectionSettings()), c.Path()).Err
+}
+
+func (s *Server) agentObject() (dbus.BusObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.agents) == 0 {
+		return nil, ErrNoAgent
+	}
+	return s.conn.Object(s.agents[0].sender, gnm.SecretAgentObjectPath), nil
+}
+
+func (s *Server) agentManagerRegister(msg dbus.Message, identifier string) *dbus.Error {
+	return s.registerAgent(msg, identifier, gnm.NmSecretAgentCapabilitiesNone)
+}
+
+func (s *Server) agentManagerRegisterWithCapabilities(msg dbus.Message, identifier string, capabilities uint32) *dbus.Error {
+	return s.registerAgent(msg, identifier, gnm.NmSecretAgentCapabilities(capabilities))
+}
+
+func (s *Server) registerAgent(msg dbus.Message, identifier string, capabilities gnm.NmSecretAgentCapabilities) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, derr := s.lookup(msgPath(msg), gnm.AgentManagerInterface); derr != nil {
+		return derr
+	}
+
+	if len(identifier) < 3 || len(identifier) > 255 {
+		return dbus.NewError(agentManagerErrorInvalidIdentifier, []interface{}{"invalid identifier " + identifier})
+	}
+
+	sender := msgSender(msg)
+	for _, a := range s.agents {
+		if a.sender == sender || a.identifier == identifier {
+			return dbus.NewError(agentManagerErrorPermissionDenied, []interface{}{"agent already registered"})
+		}
+	}
+
+	s.agents = append(s.agents, agent{sender: sender, identifier: identifier, capabilities: capabilities})
+	return nil
+}
+
+func (s *Server) agentManagerUnregister(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, derr := s.lookup(msgPath(msg), gnm.AgentManagerInterface); derr != nil {
+		return derr
+	}
+
+	sender := msgSender(msg)
+	for i, a := range s.agents {
+		if a.sender == sender {
+			s.agents = append(s.agents[:i:i], s.agents[i+1:]...)
+			return nil
+		}
+	}
+	return dbus.NewError(agentManagerErrorNotRegistered, []interface{}{"agent not registered"})
+}
+
+func msgSender(msg dbus.Message) string {
+	sender, _ := msg.Headers[dbus.FieldSender].Value().(string)
+	return sender
+}
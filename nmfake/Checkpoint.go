@@ -0,0 +1,288 @@
+package nmfake
+
+import (
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+const managerErrorInvalidArguments = gnm.NetworkManagerInterface + ".InvalidArguments"
+
+// checkpoint is the state saved by CheckpointCreate: the connection profile
+// active on each device, and the settings of every profile.
+type checkpoint struct {
+	object      *Object
+	flags       gnm.NmCheckpointCreateFlags
+	devices     map[dbus.ObjectPath]dbus.ObjectPath
+	connections map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+	timer       *time.Timer
+}
+
+func (s *Server) createCheckpoint(devices []dbus.ObjectPath, rollbackTimeout uint32, flags gnm.NmCheckpointCreateFlags) (*checkpoint, *dbus.Error) {
+	if len(devices) == 0 {
+		devices = s.manager.get(gnm.NetworkManagerPropertyDevices).([]dbus.ObjectPath)
+	}
+	for _, path := range devices {
+		if _, derr := s.lookup(path, gnm.DeviceInterface); derr != nil {
+			return nil, derr
+		}
+	}
+
+	if flags&gnm.NmCheckpointCreateFlagsDestroyAll != 0 {
+		for _, cp := range s.checkpoints {
+			s.destroyCheckpoint(cp)
+		}
+	} else if flags&gnm.NmCheckpointCreateFlagsAllowOverlapping == 0 {
+		for _, cp := range s.checkpoints {
+			for _, path := range devices {
+				if _, ok := cp.devices[path]; ok {
+					return nil, dbus.NewError(managerErrorInvalidArguments, []interface{}{"a checkpoint for '" + string(path) + "' already exists"})
+				}
+			}
+		}
+	}
+
+	cp := &checkpoint{
+		flags:       flags,
+		devices:     make(map[dbus.ObjectPath]dbus.ObjectPath),
+		connections: make(map[dbus.ObjectPath]map[string]map[string]dbus.Variant),
+	}
+	for _, path := range devices {
+		cp.devices[path] = dbus.ObjectPath("/")
+		if ac, ok := s.objects[s.objects[path].get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; ok {
+			cp.devices[path] = ac.get(gnm.ActiveConnectionPropertyConnection).(dbus.ObjectPath)
+		}
+	}
+	for _, c := range s.objectsImplementing(gnm.ConnectionInterface) {
+		cp.connections[c.path] = copySettings(c.settings)
+	}
+
+	cp.object = s.newObject(s.nextPath("Checkpoint"), map[string]map[string]interface{}{
+		gnm.CheckpointInterface: {
+			"Devices":         devices,
+			"Created":         s.uptime(),
+			"RollbackTimeout": rollbackTimeout,
+		},
+	})
+	s.checkpoints = append(s.checkpoints, cp)
+	s.manager.set(gnm.NetworkManagerPropertyCheckpoints, appendPath(s.manager.get(gnm.NetworkManagerPropertyCheckpoints).([]dbus.ObjectPath), cp.object.path))
+
+	s.scheduleRollback(cp, rollbackTimeout)
+	return cp, nil
+}
+
+// scheduleRollback rolls the checkpoint back after timeout seconds, or never
+// when the timeout is zero.
+func (s *Server) scheduleRollback(cp *checkpoint, timeout uint32) {
+	if cp.timer != nil {
+		cp.timer.Stop()
+		cp.timer = nil
+	}
+	if timeout == 0 {
+		return
+	}
+
+	cp.timer = time.AfterFunc(time.Duration(timeout)*time.Second, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.lookupCheckpoint(cp.object.path) == cp {
+			s.rollbackCheckpoint(cp)
+		}
+	})
+}
+
+func (s *Server) lookupCheckpoint(path dbus.ObjectPath) *checkpoint {
+	for _, cp := range s.checkpoints {
+		if cp.object.path == path {
+			return cp
+		}
+	}
+	return nil
+}
+
+func (s *Server) destroyCheckpoint(cp *checkpoint) {
+	if cp.timer != nil {
+		cp.timer.Stop()
+	}
+
+	remaining := s.checkpoints[:0:0]
+	for _, other := range s.checkpoints {
+		if other != cp {
+			remaining = append(remaining, other)
+		}
+	}
+	s.checkpoints = remaining
+
+	s.manager.set(gnm.NetworkManagerPropertyCheckpoints, removePath(s.manager.get(gnm.NetworkManagerPropertyCheckpoints).([]dbus.ObjectPath), cp.object.path))
+	s.removeObject(cp.object)
+}
+
+// rollbackCheckpoint restores the saved profiles and the activation of the
+// devices, then destroys the checkpoint.
+func (s *Server) rollbackCheckpoint(cp *checkpoint) map[string]uint32 {
+	for _, c := range s.objectsImplementing(gnm.ConnectionInterface) {
+		if settings, ok := cp.connections[c.path]; ok {
+			if !sameSettings(c.settings, settings) {
+				s.updateConnection(c, copySettings(settings), c.unsaved)
+			}
+		} else if cp.flags&gnm.NmCheckpointCreateFlagsDeleteNewConnections != 0 {
+			s.removeConnection(c)
+		}
+	}
+
+	results := make(map[string]uint32)
+	for path, connection := range cp.devices {
+		dev, ok := s.objects[path]
+		if !ok {
+			results[string(path)] = uint32(gnm.NmRollbackResultErrNoDevice)
+			continue
+		}
+
+		current := dbus.ObjectPath("/")
+		ac, active := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]
+		if active {
+			current = ac.get(gnm.ActiveConnectionPropertyConnection).(dbus.ObjectPath)
+		}
+
+		if current != connection {
+			if active {
+				s.deactivate(ac, gnm.NmActiveConnectionStateReasonUnknown)
+			}
+			if c, ok := s.objects[connection]; ok {
+				s.startActivation(c, dev, dbus.ObjectPath("/"))
+			}
+		}
+		results[string(path)] = uint32(gnm.NmRollbackResultOk)
+	}
+
+	if cp.flags&gnm.NmCheckpointCreateFlagsDisconnectNewDevices != 0 {
+		for _, dev := range s.objectsImplementing(gnm.DeviceInterface) {
+			if _, ok := cp.devices[dev.path]; ok {
+				continue
+			}
+			if ac, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; ok {
+				s.deactivate(ac, gnm.NmActiveConnectionStateReasonUnknown)
+			}
+		}
+	}
+
+	s.destroyCheckpoint(cp)
+	return results
+}
+
+func copySettings(settings map[string]map[string]dbus.Variant) map[string]map[string]dbus.Variant {
+	rv := make(map[string]map[string]dbus.Variant, len(settings))
+	for name, setting := range settings {
+		rv[name] = make(map[string]dbus.Variant, len(setting))
+		for key, value := range setting {
+			rv[name][key] = value
+		}
+	}
+	return rv
+}
+
+func sameSettings(a, b map[string]map[string]dbus.Variant) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, setting := range a {
+		other, ok := b[name]
+		if !ok || len(setting) != len(other) {
+			return false
+		}
+		for key, value := range setting {
+			if value.String() != other[key].String() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) managerCheckpointCreate(msg dbus.Message, devices []dbus.ObjectPath, rollbackTimeout uint32, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return "", derr
+	}
+
+	cp, derr := s.createCheckpoint(devices, rollbackTimeout, gnm.NmCheckpointCreateFlags(flags))
+	if derr != nil {
+		return "", derr
+	}
+	return cp.object.path, nil
+}
+
+func (s *Server) managerCheckpointDestroy(msg dbus.Message, checkpoint dbus.ObjectPath) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+
+	if checkpoint == "" || checkpoint == "/" {
+		for _, cp := range s.checkpoints {
+			s.destroyCheckpoint(cp)
+		}
+		return nil
+	}
+
+	cp, derr := s.lookupCheckpointArg(checkpoint)
+	if derr != nil {
+		return derr
+	}
+	s.destroyCheckpoint(cp)
+	return nil
+}
+
+func (s *Server) managerCheckpointRollback(msg dbus.Message, checkpoint dbus.ObjectPath) (map[string]uint32, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return nil, derr
+	}
+
+	cp, derr := s.lookupCheckpointArg(checkpoint)
+	if derr != nil {
+		return nil, derr
+	}
+	return s.rollbackCheckpoint(cp), nil
+}
+
+func (s *Server) managerCheckpointAdjustRollbackTimeout(msg dbus.Message, checkpoint dbus.ObjectPath, addTimeout uint32) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+
+	cp, derr := s.lookupCheckpointArg(checkpoint)
+	if derr != nil {
+		return derr
+	}
+
+	// RollbackTimeout stays relative to Created.
+	timeout := addTimeout
+	if timeout != 0 {
+		elapsed := (s.uptime() - cp.object.get(gnm.CheckpointPropertyCreated).(int64)) / 1000
+		timeout += uint32(elapsed)
+	}
+	cp.object.set(gnm.CheckpointPropertyRollbackTimeout, timeout)
+	s.scheduleRollback(cp, addTimeout)
+	return nil
+}
+
+func (s *Server) lookupCheckpointArg(path dbus.ObjectPath) (*checkpoint, *dbus.Error) {
+	cp := s.lookupCheckpoint(path)
+	if cp == nil {
+		return nil, dbus.NewError(managerErrorInvalidArguments, []interface{}{"checkpoint '" + string(path) + "' does not exist"})
+	}
+	return cp, nil
+}
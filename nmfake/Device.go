@@ -0,0 +1,580 @@
+package nmfake
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+const deviceErrorInvalidArgument = gnm.DeviceInterface + ".InvalidArgument"
+
+// deviceStateReason is the (uu) StateReason property of a device.
+type deviceStateReason struct {
+	State  uint32
+	Reason uint32
+}
+
+// AddDevice adds a realized, managed device in the disconnected state and
+// emits DeviceAdded. Ethernet devices also implement the Wired interface, Wi-Fi
+// devices the Wireless interface, and bond, bridge, team, VLAN, MACVLAN, VXLAN,
+// WireGuard, Open vSwitch and modem devices their own interface.
+func (s *Server) AddDevice(iface string, deviceType gnm.NmDeviceType) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.nextPath("Devices")
+	hwAddress := fmt.Sprintf("02:00:00:00:%02X:%02X", s.counters["Devices"]>>8&0xff, s.counters["Devices"]&0xff)
+
+	props := map[string]map[string]interface{}{
+		gnm.DeviceInterface: {
+			"Udi":                  "/sys/devices/virtual/net/" + iface,
+			"Interface":            iface,
+			"IpInterface":          "",
+			"Driver":               "nmfake",
+			"DriverVersion":        "",
+			"FirmwareVersion":      "",
+			"Capabilities":         uint32(1), // NM_DEVICE_CAP_NM_SUPPORTED
+			"State":                uint32(gnm.NmDeviceStateDisconnected),
+			"StateReason":          deviceStateReason{uint32(gnm.NmDeviceStateDisconnected), uint32(gnm.NmDeviceStateReasonNone)},
+			"ActiveConnection":     dbus.ObjectPath("/"),
+			"Ip4Config":            dbus.ObjectPath("/"),
+			"Dhcp4Config":          dbus.ObjectPath("/"),
+			"Ip6Config":            dbus.ObjectPath("/"),
+			"Dhcp6Config":          dbus.ObjectPath("/"),
+			"Managed":              true,
+			"Autoconnect":          true,
+			"FirmwareMissing":      false,
+			"NmPluginMissing":      false,
+			"DeviceType":           uint32(deviceType),
+			"AvailableConnections": []dbus.ObjectPath{},
+			"PhysicalPortId":       "",
+			"Mtu":                  uint32(1500),
+			"Metered":              uint32(gnm.NmMeteredUnknown),
+			"LldpNeighbors":        []map[string]dbus.Variant{},
+			"Real":                 true,
+			"Ip4Connectivity":      uint32(gnm.NmConnectivityUnknown),
+		},
+	}
+
+	switch deviceType {
+	case gnm.NmDeviceTypeEthernet:
+		props[gnm.DeviceWiredInterface] = map[string]interface{}{
+			"HwAddress":       hwAddress,
+			"PermHwAddress":   hwAddress,
+			"Speed":           uint32(1000),
+			"S390Subchannels": []string{},
+			"Carrier":         true,
+		}
+	case gnm.NmDeviceTypeWifi:
+		props[gnm.DeviceWirelessInterface] = map[string]interface{}{
+			"HwAddress":            hwAddress,
+			"PermHwAddress":        hwAddress,
+			"Mode":                 uint32(gnm.Nm80211ModeInfra),
+			"Bitrate":              uint32(0),
+			"AccessPoints":         []dbus.ObjectPath{},
+			"ActiveAccessPoint":    dbus.ObjectPath("/"),
+			"WirelessCapabilities": uint32(0),
+			"LastScan":             int64(-1),
+		}
+	case gnm.NmDeviceTypeBond, gnm.NmDeviceTypeBridge, gnm.NmDeviceTypeTeam:
+		master := map[string]interface{}{
+			"HwAddress": hwAddress,
+			"Carrier":   false,
+			"Slaves":    []dbus.ObjectPath{},
+		}
+		switch deviceType {
+		case gnm.NmDeviceTypeBond:
+			props[gnm.DeviceBondInterface] = master
+		case gnm.NmDeviceTypeBridge:
+			props[gnm.DeviceBridgeInterface] = master
+		case gnm.NmDeviceTypeTeam:
+			master["Config"] = "{}"
+			props[gnm.DeviceTeamInterface] = master
+		}
+	case gnm.NmDeviceTypeModem:
+		capabilities := uint32(gnm.NmDeviceModemCapabilitiesGsmUmts | gnm.NmDeviceModemCapabilitiesLte)
+		props[gnm.DeviceModemInterface] = map[string]interface{}{
+			"ModemCapabilities":   capabilities,
+			"CurrentCapabilities": capabilities,
+			"DeviceId":            "",
+			"OperatorCode":        "",
+			"Apn":                 "",
+		}
+	case gnm.NmDeviceTypeOvsBridge:
+		props[gnm.DeviceOvsBridgeInterface] = map[string]interface{}{
+			"Slaves": []dbus.ObjectPath{},
+		}
+	case gnm.NmDeviceTypeOvsPort:
+		props[gnm.DeviceOvsPortInterface] = map[string]interface{}{
+			"Slaves": []dbus.ObjectPath{},
+		}
+	case gnm.NmDeviceTypeOvsInterface:
+		props[gnm.DeviceOvsInterfaceInterface] = map[string]interface{}{}
+	case gnm.NmDeviceTypeVlan:
+		props[gnm.DeviceVlanInterface] = map[string]interface{}{
+			"HwAddress": hwAddress,
+			"Carrier":   true,
+			"Parent":    dbus.ObjectPath("/"),
+			"VlanId":    uint32(0),
+		}
+	case gnm.NmDeviceTypeMacvlan:
+		props[gnm.DeviceMacvlanInterface] = map[string]interface{}{
+			"Parent":    dbus.ObjectPath("/"),
+			"Mode":      "bridge",
+			"NoPromisc": false,
+			"Tap":       false,
+			"HwAddress": hwAddress,
+		}
+	case gnm.NmDeviceTypeWireguard:
+		props[gnm.DeviceWireGuardInterface] = map[string]interface{}{
+			"PublicKey":  make([]byte, 32),
+			"ListenPort": uint16(51820),
+			"FwMark":     uint32(0),
+		}
+	case gnm.NmDeviceTypeVxlan:
+		props[gnm.DeviceVxlanInterface] = map[string]interface{}{
+			"Parent":     dbus.ObjectPath("/"),
+			"HwAddress":  hwAddress,
+			"Id":         uint32(0),
+			"Group":      "",
+			"Local":      "",
+			"Tos":        uint8(0),
+			"Ttl":        uint8(0),
+			"Learning":   true,
+			"Ageing":     uint32(300),
+			"Limit":      uint32(0),
+			"DstPort":    uint16(8472),
+			"SrcPortMin": uint16(0),
+			"SrcPortMax": uint16(0),
+			"Proxy":      false,
+			"Rsc":        false,
+			"L2miss":     false,
+			"L3miss":     false,
+		}
+	}
+
+	dev := s.newObject(path, props)
+
+	s.manager.set(gnm.NetworkManagerPropertyDevices, appendPath(s.manager.get(gnm.NetworkManagerPropertyDevices).([]dbus.ObjectPath), path))
+	s.manager.set(gnm.NetworkManagerPropertyAllDevices, appendPath(s.manager.get(gnm.NetworkManagerPropertyAllDevices).([]dbus.ObjectPath), path))
+	s.manager.Emit(gnm.NetworkManagerInterface+".DeviceAdded", path)
+
+	return dev
+}
+
+// AppliedConnection returns the settings currently applied to a device, as
+// set by its activation and Reapply calls, and their version id.
+func (o *Object) AppliedConnection() (gnm.ConnectionSettings, uint64) {
+	o.server.mu.Lock()
+	defer o.server.mu.Unlock()
+
+	rv := make(gnm.ConnectionSettings)
+	for name, setting := range o.settings {
+		rv[name] = make(map[string]interface{})
+		for key, value := range setting {
+			rv[name][key] = value.Value()
+		}
+	}
+	return rv, o.version
+}
+
+// RemoveDevice deactivates and removes a device, along with its access
+// points, and emits DeviceRemoved.
+func (s *Server) RemoveDevice(dev *Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeDevice(dev)
+}
+
+// SetDeviceState moves a device to a new state and emits StateChanged.
+func (s *Server) SetDeviceState(dev *Object, state gnm.NmDeviceState, reason gnm.NmDeviceStateReason) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setDeviceState(dev, state, reason)
+}
+
+// AddAccessPoint adds an open infrastructure access point to a Wi-Fi device
+// and emits AccessPointAdded. An empty SSID makes a hidden access point.
+// Frequency is in MHz and strength in percent.
+func (s *Server) AddAccessPoint(dev *Object, ssid string, frequency uint32, strength uint8) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.nextPath("AccessPoint")
+	ap := s.newObject(path, map[string]map[string]interface{}{
+		gnm.AccessPointInterface: {
+			"Flags":      uint32(gnm.Nm80211APFlagsNone),
+			"WpaFlags":   uint32(gnm.Nm80211APSecNone),
+			"RsnFlags":   uint32(gnm.Nm80211APSecNone),
+			"Ssid":       []byte(ssid),
+			"Frequency":  frequency,
+			"HwAddress":  fmt.Sprintf("02:00:00:01:%02X:%02X", s.counters["AccessPoint"]>>8&0xff, s.counters["AccessPoint"]&0xff),
+			"Mode":       uint32(gnm.Nm80211ModeInfra),
+			"MaxBitrate": uint32(54000),
+			"Strength":   strength,
+			"LastSeen":   int32(s.uptime() / 1000),
+			"Bandwidth":  uint32(20),
+		},
+	})
+
+	dev.set(gnm.DeviceWirelessPropertyAccessPoints, appendPath(dev.get(gnm.DeviceWirelessPropertyAccessPoints).([]dbus.ObjectPath), path))
+	dev.Emit(gnm.DeviceWirelessInterface+".AccessPointAdded", path)
+
+	return ap
+}
+
+// RemoveAccessPoint removes an access point from a Wi-Fi device and emits
+// AccessPointRemoved.
+func (s *Server) RemoveAccessPoint(dev *Object, ap *Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeAccessPoint(dev, ap)
+}
+
+// AddIP4Config attaches a new IPv4 configuration to a device and, when it
+// has one, to its active connection.
+func (s *Server) AddIP4Config(dev *Object, addresses []gnm.IP4AddressData, gateway string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addressData := make([]map[string]dbus.Variant, 0, len(addresses))
+	for _, address := range addresses {
+		addressData = append(addressData, map[string]dbus.Variant{
+			"address": dbus.MakeVariant(address.Address),
+			"prefix":  dbus.MakeVariant(uint32(address.Prefix)),
+		})
+	}
+
+	config := s.newObject(s.nextPath("IP4Config"), map[string]map[string]interface{}{
+		gnm.IP4ConfigInterface: {
+			"Addresses":      [][]uint32{},
+			"AddressData":    addressData,
+			"Gateway":        gateway,
+			"Routes":         [][]uint32{},
+			"RouteData":      []map[string]dbus.Variant{},
+			"Nameservers":    []uint32{},
+			"NameserverData": []map[string]dbus.Variant{},
+			"Domains":        []string{},
+			"Searches":       []string{},
+			"DnsOptions":     []string{},
+			"DnsPriority":    int32(0),
+			"WinsServers":    []uint32{},
+			"WinsServerData": []string{},
+		},
+	})
+
+	s.attach(dev, gnm.DevicePropertyIp4Config, gnm.ActiveConnectionPropertyIp4Config, config)
+	return config
+}
+
+// AddIP6Config attaches a new IPv6 configuration to a device and, when it
+// has one, to its active connection.
+func (s *Server) AddIP6Config(dev *Object, addresses []gnm.IP6AddressData, gateway string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addressData := make([]map[string]dbus.Variant, 0, len(addresses))
+	for _, address := range addresses {
+		addressData = append(addressData, map[string]dbus.Variant{
+			"address": dbus.MakeVariant(address.Address),
+			"prefix":  dbus.MakeVariant(uint32(address.Prefix)),
+		})
+	}
+
+	type ip6Address struct {
+		Address []byte
+		Prefix  uint32
+		Gateway []byte
+	}
+	type ip6Route struct {
+		Destination []byte
+		Prefix      uint32
+		NextHop     []byte
+		Metric      uint32
+	}
+
+	config := s.newObject(s.nextPath("IP6Config"), map[string]map[string]interface{}{
+		gnm.IP6ConfigInterface: {
+			"Addresses":   []ip6Address{},
+			"AddressData": addressData,
+			"Gateway":     gateway,
+			"Routes":      []ip6Route{},
+			"RouteData":   []map[string]dbus.Variant{},
+			"Nameservers": [][]byte{},
+			"Domains":     []string{},
+			"Searches":    []string{},
+			"DnsOptions":  []string{},
+			"DnsPriority": int32(0),
+		},
+	})
+
+	s.attach(dev, gnm.DevicePropertyIp6Config, gnm.ActiveConnectionPropertyIp6Config, config)
+	return config
+}
+
+// AddDHCP4Config attaches a new DHCPv4 configuration to a device and, when
+// it has one, to its active connection.
+func (s *Server) AddDHCP4Config(dev *Object, options map[string]interface{}) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := s.newObject(s.nextPath("DHCP4Config"), map[string]map[string]interface{}{
+		gnm.DHCP4ConfigInterface: {
+			"Options": toVariantMap(options),
+		},
+	})
+
+	s.attach(dev, gnm.DevicePropertyDhcp4Config, gnm.ActiveConnectionPropertyDhcp4Config, config)
+	return config
+}
+
+// AddDHCP6Config attaches a new DHCPv6 configuration to a device and, when
+// it has one, to its active connection.
+func (s *Server) AddDHCP6Config(dev *Object, options map[string]interface{}) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := s.newObject(s.nextPath("DHCP6Config"), map[string]map[string]interface{}{
+		gnm.DHCP6ConfigInterface: {
+			"Options": toVariantMap(options),
+		},
+	})
+
+	s.attach(dev, gnm.DevicePropertyDhcp6Config, gnm.ActiveConnectionPropertyDhcp6Config, config)
+	return config
+}
+
+func toVariantMap(values map[string]interface{}) map[string]dbus.Variant {
+	rv := make(map[string]dbus.Variant, len(values))
+	for key, value := range values {
+		rv[key] = dbus.MakeVariant(value)
+	}
+	return rv
+}
+
+func (s *Server) attach(dev *Object, deviceProperty, activeConnectionProperty string, config *Object) {
+	dev.set(deviceProperty, config.path)
+
+	if ac, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; ok {
+		ac.set(activeConnectionProperty, config.path)
+	}
+}
+
+func (s *Server) uptime() int64 {
+	return int64(time.Since(s.started) / time.Millisecond)
+}
+
+func (s *Server) removeDevice(dev *Object) {
+	if ac, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; ok {
+		s.deactivate(ac, gnm.NmActiveConnectionStateReasonDeviceRemoved)
+	}
+
+	if _, ok := dev.props[gnm.DeviceWirelessInterface]; ok {
+		for _, path := range dev.get(gnm.DeviceWirelessPropertyAccessPoints).([]dbus.ObjectPath) {
+			s.removeAccessPoint(dev, s.objects[path])
+		}
+	}
+
+	s.manager.set(gnm.NetworkManagerPropertyDevices, removePath(s.manager.get(gnm.NetworkManagerPropertyDevices).([]dbus.ObjectPath), dev.path))
+	s.manager.set(gnm.NetworkManagerPropertyAllDevices, removePath(s.manager.get(gnm.NetworkManagerPropertyAllDevices).([]dbus.ObjectPath), dev.path))
+	s.manager.Emit(gnm.NetworkManagerInterface+".DeviceRemoved", dev.path)
+	s.removeObject(dev)
+}
+
+func (s *Server) setDeviceState(dev *Object, state gnm.NmDeviceState, reason gnm.NmDeviceStateReason) {
+	if dev == nil || s.objects[dev.path] != dev {
+		return
+	}
+
+	old := dev.get(gnm.DevicePropertyState).(uint32)
+	if old == uint32(state) {
+		return
+	}
+
+	dev.set(gnm.DevicePropertyState, uint32(state))
+	dev.set(gnm.DevicePropertyStateReason, deviceStateReason{uint32(state), uint32(reason)})
+	dev.Emit(gnm.DeviceInterface+".StateChanged", uint32(state), old, uint32(reason))
+}
+
+func (s *Server) removeAccessPoint(dev *Object, ap *Object) {
+	dev.set(gnm.DeviceWirelessPropertyAccessPoints, removePath(dev.get(gnm.DeviceWirelessPropertyAccessPoints).([]dbus.ObjectPath), ap.path))
+	if dev.get(gnm.DeviceWirelessPropertyActiveAccessPoint) == ap.path {
+		dev.set(gnm.DeviceWirelessPropertyActiveAccessPoint, dbus.ObjectPath("/"))
+	}
+	dev.Emit(gnm.DeviceWirelessInterface+".AccessPointRemoved", ap.path)
+	s.removeObject(ap)
+}
+
+func (s *Server) lookupDevice(msg dbus.Message) (*Object, *dbus.Error) {
+	return s.lookup(msgPath(msg), gnm.DeviceInterface)
+}
+
+func (s *Server) deviceDisconnect(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupDevice(msg)
+	if derr != nil {
+		return derr
+	}
+
+	ac, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]
+	if !ok {
+		return dbus.NewError(gnm.DeviceInterface+".NotActive", []interface{}{"This device is not active"})
+	}
+
+	s.deactivate(ac, gnm.NmActiveConnectionStateReasonUserDisconnected)
+	return nil
+}
+
+func (s *Server) lookupActiveDevice(msg dbus.Message) (*Object, *dbus.Error) {
+	dev, derr := s.lookupDevice(msg)
+	if derr != nil {
+		return nil, derr
+	}
+
+	if _, ok := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]; !ok {
+		return nil, dbus.NewError(gnm.DeviceInterface+".NotActive", []interface{}{"This device is not active"})
+	}
+	return dev, nil
+}
+
+func (s *Server) deviceReapply(msg dbus.Message, settings map[string]map[string]dbus.Variant, versionId uint64, flags uint32) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupActiveDevice(msg)
+	if derr != nil {
+		return derr
+	}
+
+	if versionId != 0 && versionId != dev.version {
+		return dbus.NewError(gnm.DeviceInterface+".VersionIdMismatch", []interface{}{"The version-id of the applied connection does not match"})
+	}
+
+	if len(settings) == 0 {
+		ac := s.objects[dev.get(gnm.DevicePropertyActiveConnection).(dbus.ObjectPath)]
+		settings = s.objects[ac.get(gnm.ActiveConnectionPropertyConnection).(dbus.ObjectPath)].settings
+	} else if settingString(settings, "connection", "uuid") != settingString(dev.settings, "connection", "uuid") {
+		return dbus.NewError(gnm.DeviceInterface+".IncompatibleConnection", []interface{}{"Cannot change the connection UUID on reapply"})
+	}
+
+	dev.settings = copySettings(settings)
+	dev.version++
+	return nil
+}
+
+func (s *Server) deviceGetAppliedConnection(msg dbus.Message, flags uint32) (map[string]map[string]dbus.Variant, uint64, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupActiveDevice(msg)
+	if derr != nil {
+		return nil, 0, derr
+	}
+
+	rv := make(map[string]map[string]dbus.Variant)
+	for name, setting := range dev.settings {
+		rv[name] = settingWithoutSecrets(setting)
+	}
+	return rv, dev.version, nil
+}
+
+func (s *Server) deviceDelete(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupDevice(msg)
+	if derr != nil {
+		return derr
+	}
+
+	s.removeDevice(dev)
+	return nil
+}
+
+func (s *Server) lookupWireless(msg dbus.Message) (*Object, *dbus.Error) {
+	return s.lookup(msgPath(msg), gnm.DeviceWirelessInterface)
+}
+
+func (s *Server) wirelessGetAccessPoints(msg dbus.Message) ([]dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupWireless(msg)
+	if derr != nil {
+		return nil, derr
+	}
+
+	paths := []dbus.ObjectPath{}
+	for _, path := range dev.get(gnm.DeviceWirelessPropertyAccessPoints).([]dbus.ObjectPath) {
+		if len(s.objects[path].get(gnm.AccessPointPropertySsid).([]byte)) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func (s *Server) wirelessGetAllAccessPoints(msg dbus.Message) ([]dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupWireless(msg)
+	if derr != nil {
+		return nil, derr
+	}
+	return dev.get(gnm.DeviceWirelessPropertyAccessPoints).([]dbus.ObjectPath), nil
+}
+
+func (s *Server) wirelessRequestScan(msg dbus.Message, options map[string]dbus.Variant) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dev, derr := s.lookupWireless(msg)
+	if derr != nil {
+		return derr
+	}
+
+	var ssids []string
+	if v, ok := options["ssids"]; ok {
+		values, ok := v.Value().([][]byte)
+		if !ok {
+			return dbus.NewError(deviceErrorInvalidArgument, []interface{}{"invalid 'ssids' scan option"})
+		}
+		for _, ssid := range values {
+			ssids = append(ssids, string(ssid))
+		}
+	}
+
+	if s.scan == nil {
+		s.scanDone(dev)
+		return nil
+	}
+
+	scan := s.scan
+	go func() {
+		scan(s, dev, ssids)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.scanDone(dev)
+	}()
+	return nil
+}
+
+// scanDone updates the LastScan property of a device at the end of a scan.
+// It always increases, even for two scans within the same millisecond, so
+// that clients waiting for it to change see every scan.
+func (s *Server) scanDone(dev *Object) {
+	lastScan := s.uptime()
+	if previous := dev.get(gnm.DeviceWirelessPropertyLastScan).(int64); lastScan <= previous {
+		lastScan = previous + 1
+	}
+	dev.set(gnm.DeviceWirelessPropertyLastScan, lastScan)
+}
@@ -0,0 +1,300 @@
+package nmfake
+
+import (
+	"reflect"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+func (s *Server) export() error {
+	tables := map[string]map[string]interface{}{
+		dbusPropertiesInterface: {
+			"Get":    s.propertiesGet,
+			"GetAll": s.propertiesGetAll,
+			"Set":    s.propertiesSet,
+		},
+		dbusObjectManagerInterface: {
+			"GetManagedObjects": s.objectManagerGetManagedObjects,
+		},
+		gnm.NetworkManagerInterface: {
+			"Reload":                          s.managerReload,
+			"GetDevices":                      s.managerGetDevices,
+			"GetAllDevices":                   s.managerGetAllDevices,
+			"GetDeviceByIpIface":              s.managerGetDeviceByIpIface,
+			"ActivateConnection":              s.managerActivateConnection,
+			"AddAndActivateConnection":        s.managerAddAndActivateConnection,
+			"DeactivateConnection":            s.managerDeactivateConnection,
+			"Sleep":                           s.managerSleep,
+			"Enable":                          s.managerEnable,
+			"CheckConnectivity":               s.managerCheckConnectivity,
+			"state":                           s.managerState,
+			"CheckpointCreate":                s.managerCheckpointCreate,
+			"CheckpointDestroy":               s.managerCheckpointDestroy,
+			"CheckpointRollback":              s.managerCheckpointRollback,
+			"CheckpointAdjustRollbackTimeout": s.managerCheckpointAdjustRollbackTimeout,
+		},
+		gnm.SettingsInterface: {
+			"ListConnections":      s.settingsListConnections,
+			"GetConnectionByUuid":  s.settingsGetConnectionByUuid,
+			"AddConnection":        s.settingsAddConnection,
+			"AddConnectionUnsaved": s.settingsAddConnectionUnsaved,
+			"SaveHostname":         s.settingsSaveHostname,
+			"LoadConnections":      s.settingsLoadConnections,
+			"ReloadConnections":    s.settingsReloadConnections,
+		},
+		gnm.AgentManagerInterface: {
+			"Register":                 s.agentManagerRegister,
+			"RegisterWithCapabilities": s.agentManagerRegisterWithCapabilities,
+			"Unregister":               s.agentManagerUnregister,
+		},
+		gnm.ConnectionInterface: {
+			"Update":        s.connectionUpdate,
+			"UpdateUnsaved": s.connectionUpdateUnsaved,
+			"Update2":       s.connectionUpdate2,
+			"Delete":        s.connectionDelete,
+			"GetSettings":   s.connectionGetSettings,
+			"GetSecrets":    s.connectionGetSecrets,
+			"ClearSecrets":  s.connectionClearSecrets,
+			"Save":          s.connectionSave,
+		},
+		gnm.DeviceInterface: {
+			"Reapply":              s.deviceReapply,
+			"GetAppliedConnection": s.deviceGetAppliedConnection,
+			"Disconnect":           s.deviceDisconnect,
+			"Delete":               s.deviceDelete,
+		},
+		gnm.DeviceWirelessInterface: {
+			"GetAccessPoints":    s.wirelessGetAccessPoints,
+			"GetAllAccessPoints": s.wirelessGetAllAccessPoints,
+			"RequestScan":        s.wirelessRequestScan,
+		},
+	}
+
+	s.removed = make(map[string]bool)
+	for iface, methods := range tables {
+		removable := make(map[string]interface{}, len(methods))
+		for name, method := range methods {
+			removable[name] = s.removable(iface+"."+name, method)
+		}
+		if err := s.conn.ExportSubtreeMethodTable(removable, exportRoot, iface); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveMethod makes a method, e.g. gonetworkmanager.ConnectionUpdate2, fail
+// with org.freedesktop.DBus.Error.UnknownMethod, to mimic an older
+// NetworkManager.
+func (s *Server) RemoveMethod(method string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removed[method] = true
+	return nil
+}
+
+// removable wraps the handler of a method so that it fails once removed by
+// RemoveMethod. godbus does not support exporting the methods again while it
+// dispatches calls.
+func (s *Server) removable(method string, handler interface{}) interface{} {
+	fn := reflect.ValueOf(handler)
+	t := fn.Type()
+
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		s.mu.Lock()
+		removed := s.removed[method]
+		s.mu.Unlock()
+
+		if !removed {
+			return fn.Call(args)
+		}
+
+		// Every handler returns a *dbus.Error last.
+		out := make([]reflect.Value, t.NumOut())
+		for i := range out {
+			out[i] = reflect.Zero(t.Out(i))
+		}
+		out[len(out)-1] = reflect.ValueOf(dbus.NewError("org.freedesktop.DBus.Error.UnknownMethod", []interface{}{"no such method '" + method + "'"}))
+		return out
+	}).Interface()
+}
+
+func msgPath(msg dbus.Message) dbus.ObjectPath {
+	return msg.Headers[dbus.FieldPath].Value().(dbus.ObjectPath)
+}
+
+func (s *Server) lookupManager(msg dbus.Message) *dbus.Error {
+	_, derr := s.lookup(msgPath(msg), gnm.NetworkManagerInterface)
+	return derr
+}
+
+func (s *Server) managerReload(msg dbus.Message, flags uint32) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+	// Configuration, DNS update and DNS plugin restart.
+	if flags&^0x07 != 0 {
+		return dbus.NewError(managerErrorInvalidArguments, []interface{}{"Invalid flags for reload"})
+	}
+	return nil
+}
+
+func (s *Server) managerGetDevices(msg dbus.Message) ([]dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return nil, derr
+	}
+	return s.manager.get(gnm.NetworkManagerPropertyDevices).([]dbus.ObjectPath), nil
+}
+
+func (s *Server) managerGetAllDevices(msg dbus.Message) ([]dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return nil, derr
+	}
+	return s.manager.get(gnm.NetworkManagerPropertyAllDevices).([]dbus.ObjectPath), nil
+}
+
+func (s *Server) managerGetDeviceByIpIface(msg dbus.Message, iface string) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return "", derr
+	}
+
+	for _, path := range s.manager.get(gnm.NetworkManagerPropertyAllDevices).([]dbus.ObjectPath) {
+		dev := s.objects[path]
+		if dev.get(gnm.DevicePropertyIpInterface) == iface || dev.get(gnm.DevicePropertyInterface) == iface {
+			return path, nil
+		}
+	}
+
+	return "", dbus.NewError(gnm.NetworkManagerInterface+".UnknownDevice", []interface{}{"No device found for the requested iface."})
+}
+
+func (s *Server) managerActivateConnection(msg dbus.Message, connection, device, specificObject dbus.ObjectPath) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return "", derr
+	}
+
+	c, derr := s.lookup(connection, gnm.ConnectionInterface)
+	if derr != nil {
+		return "", derr
+	}
+
+	var dev *Object
+	if device != "/" {
+		if dev, derr = s.lookup(device, gnm.DeviceInterface); derr != nil {
+			return "", derr
+		}
+	}
+
+	return s.startActivation(c, dev, specificObject).path, nil
+}
+
+func (s *Server) managerAddAndActivateConnection(msg dbus.Message, settings map[string]map[string]dbus.Variant, device, specificObject dbus.ObjectPath) (dbus.ObjectPath, dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return "", "", derr
+	}
+
+	var dev *Object
+	var derr *dbus.Error
+	if device != "/" {
+		if dev, derr = s.lookup(device, gnm.DeviceInterface); derr != nil {
+			return "", "", derr
+		}
+	}
+
+	c := s.addConnection(settings, false)
+	ac := s.startActivation(c, dev, specificObject)
+
+	return c.path, ac.path, nil
+}
+
+func (s *Server) managerDeactivateConnection(msg dbus.Message, activeConnection dbus.ObjectPath) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+
+	ac, derr := s.lookup(activeConnection, gnm.ActiveConnectionInterface)
+	if derr != nil {
+		return derr
+	}
+
+	s.deactivate(ac, gnm.NmActiveConnectionStateReasonUserDisconnected)
+	return nil
+}
+
+func (s *Server) managerSleep(msg dbus.Message, sleep bool) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+
+	if sleep {
+		s.setManagerState(gnm.NmStateAsleep)
+	} else {
+		s.setManagerState(gnm.NmStateDisconnected)
+	}
+	return nil
+}
+
+func (s *Server) managerEnable(msg dbus.Message, enable bool) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return derr
+	}
+
+	s.manager.set(gnm.NetworkManagerPropertyNetworkingEnabled, enable)
+	return nil
+}
+
+func (s *Server) managerCheckConnectivity(msg dbus.Message) (uint32, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return 0, derr
+	}
+	return s.manager.get(gnm.NetworkManagerPropertyConnectivity).(uint32), nil
+}
+
+func (s *Server) managerState(msg dbus.Message) (uint32, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupManager(msg); derr != nil {
+		return 0, derr
+	}
+	return s.manager.get(gnm.NetworkManagerPropertyState).(uint32), nil
+}
+
+func (s *Server) setManagerState(state gnm.NmState) {
+	s.manager.set(gnm.NetworkManagerPropertyState, uint32(state))
+	s.manager.Emit(gnm.NetworkManagerInterface+".StateChanged", uint32(state))
+}
@@ -0,0 +1,53 @@
+package nmfake
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+// AddModemManagerModem adds an enabled LTE modem to the fake ModemManager,
+// registered on its home network, and makes it the modem of a modem device
+// by setting the device Udi to its object path. Its state, signal quality
+// and operator can be changed with Object.Set.
+func (s *Server) AddModemManagerModem(dev *Object) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ModemManager numbers its modems from 0.
+	index := s.counters["Modem"]
+	s.counters["Modem"]++
+	path := dbus.ObjectPath(fmt.Sprintf("%s/Modem/%d", gnm.ModemManagerObjectPath, index))
+	imei := fmt.Sprintf("3589990000%05d", index)
+
+	modem := s.newObject(path, map[string]map[string]interface{}{
+		gnm.ModemManagerModemInterface: {
+			"Manufacturer":        "nmfake",
+			"Model":               "LTE modem",
+			"Revision":            "1.0",
+			"EquipmentIdentifier": imei,
+			"OwnNumbers":          []string{},
+			"State":               int32(gnm.MmModemStateRegistered),
+			"AccessTechnologies":  uint32(gnm.MmModemAccessTechnologyLte),
+			"SignalQuality":       signalQuality{75, true},
+		},
+		gnm.ModemManagerModem3gppInterface: {
+			"Imei":              imei,
+			"RegistrationState": uint32(gnm.MmModem3gppRegistrationStateHome),
+			"OperatorCode":      "00101",
+			"OperatorName":      "nmfake",
+		},
+	})
+
+	dev.set(gnm.DevicePropertyUdi, string(path))
+
+	return modem
+}
+
+// signalQuality is the (ub) SignalQuality property of a modem.
+type signalQuality struct {
+	Quality uint32
+	Recent  bool
+}
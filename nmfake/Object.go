@@ -0,0 +1,155 @@
+package nmfake
+
+import (
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Object is one object of the fake tree. Properties are addressed with
+// their fully qualified name, the same way the gonetworkmanager property
+// constants are, e.g. gonetworkmanager.DevicePropertyState.
+type Object struct {
+	server *Server
+	path   dbus.ObjectPath
+	props  map[string]map[string]dbus.Variant
+
+	// Only used by connection profiles, and by devices for their applied
+	// connection and its version id.
+	settings map[string]map[string]dbus.Variant
+	unsaved  bool
+	version  uint64
+}
+
+// Path returns the object path.
+func (o *Object) Path() dbus.ObjectPath {
+	return o.path
+}
+
+// Get returns the current value of a property.
+func (o *Object) Get(property string) dbus.Variant {
+	o.server.mu.Lock()
+	defer o.server.mu.Unlock()
+
+	iface, name := splitName(property)
+	return o.props[iface][name]
+}
+
+// Set changes a property and emits PropertiesChanged. The value must have
+// the Go type matching the D-Bus signature of the property, e.g. uint32 for
+// "u" or []dbus.ObjectPath for "ao".
+func (o *Object) Set(property string, value interface{}) {
+	o.server.mu.Lock()
+	defer o.server.mu.Unlock()
+
+	o.set(property, value)
+}
+
+// Emit emits a signal from the object. The signal name must be fully
+// qualified, e.g. "org.freedesktop.NetworkManager.Device.StateChanged".
+func (o *Object) Emit(signal string, args ...interface{}) error {
+	return o.server.conn.Emit(o.path, signal, args...)
+}
+
+func (o *Object) get(property string) interface{} {
+	iface, name := splitName(property)
+	return o.props[iface][name].Value()
+}
+
+func (o *Object) set(property string, value interface{}) {
+	iface, name := splitName(property)
+	if o.props[iface] == nil {
+		o.props[iface] = make(map[string]dbus.Variant)
+	}
+
+	v := dbus.MakeVariant(value)
+	o.props[iface][name] = v
+
+	o.Emit(dbusPropertiesChanged, iface, map[string]dbus.Variant{name: v}, []string{})
+}
+
+func (o *Object) implements(iface string) bool {
+	if iface == dbusPropertiesInterface {
+		return true
+	}
+	_, ok := o.props[iface]
+	return ok
+}
+
+func splitName(name string) (string, string) {
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return "", name
+	}
+	return name[:idx], name[idx+1:]
+}
+
+func (s *Server) propertiesGet(msg dbus.Message, iface, name string) (dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, derr := s.lookup(msg.Headers[dbus.FieldPath].Value().(dbus.ObjectPath), iface)
+	if derr != nil {
+		return dbus.Variant{}, derr
+	}
+
+	v, ok := o.props[iface][name]
+	if !ok {
+		return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{"No such property: " + name})
+	}
+
+	return v, nil
+}
+
+func (s *Server) propertiesGetAll(msg dbus.Message, iface string) (map[string]dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, derr := s.lookup(msg.Headers[dbus.FieldPath].Value().(dbus.ObjectPath), iface)
+	if derr != nil {
+		return nil, derr
+	}
+
+	props := make(map[string]dbus.Variant, len(o.props[iface]))
+	for name, v := range o.props[iface] {
+		props[name] = v
+	}
+
+	return props, nil
+}
+
+func (s *Server) propertiesSet(msg dbus.Message, iface, name string, value dbus.Variant) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, derr := s.lookup(msg.Headers[dbus.FieldPath].Value().(dbus.ObjectPath), iface)
+	if derr != nil {
+		return derr
+	}
+
+	current, ok := o.props[iface][name]
+	if !ok {
+		return dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{"No such property: " + name})
+	}
+	if current.Signature() != value.Signature() {
+		return dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{"Invalid type for property: " + name})
+	}
+
+	o.set(iface+"."+name, value.Value())
+	return nil
+}
+
+func (s *Server) objectManagerGetManagedObjects(msg dbus.Message) (map[dbus.ObjectPath]map[string]map[string]dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if msgPath(msg) != exportRoot {
+		return nil, dbus.NewError("org.freedesktop.DBus.Error.UnknownObject", []interface{}{"No such object: " + string(msgPath(msg))})
+	}
+
+	objects := make(map[dbus.ObjectPath]map[string]map[string]dbus.Variant, len(s.objects))
+	for path, o := range s.objects {
+		objects[path] = copySettings(o.props)
+	}
+	return objects, nil
+}
@@ -0,0 +1,365 @@
+// Package nmfake provides an in-process fake of the NetworkManager D-Bus
+// service.
+//
+// A Server starts a private dbus-daemon, claims the
+// org.freedesktop.NetworkManager name on it and serves a scriptable object
+// tree (devices, access points, connection profiles, active connections and
+// IP configurations). It also claims org.freedesktop.ModemManager1 to serve
+// the modems added with AddModemManagerModem. Clients connect with Dial and
+// hand the connection to the gonetworkmanager *WithConn constructors:
+//
+//	srv, err := nmfake.New()
+//	...
+//	defer srv.Close()
+//	srv.AddDevice("eth0", gonetworkmanager.NmDeviceTypeEthernet)
+//
+//	conn, err := srv.Dial()
+//	...
+//	nm, err := gonetworkmanager.NewNetworkManagerWithConn(conn)
+//
+// Only a dbus-daemon binary is required; NetworkManager does not need to be
+// installed.
+package nmfake
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+const (
+	dbusPropertiesInterface = "org.freedesktop.DBus.Properties"
+	dbusPropertiesChanged   = dbusPropertiesInterface + ".PropertiesChanged"
+
+	dbusObjectManagerInterface = "org.freedesktop.DBus.ObjectManager"
+	dbusInterfacesAdded        = dbusObjectManagerInterface + ".InterfacesAdded"
+	dbusInterfacesRemoved      = dbusObjectManagerInterface + ".InterfacesRemoved"
+
+	exportRoot = dbus.ObjectPath("/org/freedesktop")
+
+	busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+)
+
+// ActivationFunc decides how an activation proceeds once it has been
+// requested, either over D-Bus or through Server.Activate. It runs in its own
+// goroutine and drives the activation through SetDeviceState and
+// SetActiveConnectionState.
+type ActivationFunc func(s *Server, activeConnection *Object)
+
+// DefaultActivation brings both the device and the active connection to the
+// activated state.
+func DefaultActivation(s *Server, ac *Object) {
+	devices, _ := ac.Get(gnm.ActiveConnectionPropertyDevices).Value().([]dbus.ObjectPath)
+	for _, path := range devices {
+		s.SetDeviceState(s.Object(path), gnm.NmDeviceStateActivated, gnm.NmDeviceStateReasonNone)
+	}
+	s.SetActiveConnectionState(ac, gnm.NmActiveConnectionStateActivated, gnm.NmActiveConnectionStateReasonNone)
+}
+
+// ScanFunc decides what a scan requested over D-Bus finds, given the SSIDs
+// it probes for. It runs in its own goroutine and can add and remove access
+// points with AddAccessPoint and RemoveAccessPoint; the LastScan property of
+// the device is updated once it returns.
+type ScanFunc func(s *Server, device *Object, ssids []string)
+
+// Server is a fake NetworkManager service running on a private bus.
+type Server struct {
+	mu sync.Mutex
+
+	cmd     *exec.Cmd
+	dir     string
+	address string
+	conn    *dbus.Conn
+	started time.Time
+
+	objects  map[dbus.ObjectPath]*Object
+	counters map[string]int
+	activate ActivationFunc
+	scan     ScanFunc
+	agents   []agent
+
+	checkpoints []*checkpoint
+	removed     map[string]bool
+
+	manager  *Object
+	settings *Object
+}
+
+// New starts a private bus and exports an empty NetworkManager on it.
+func New() (*Server, error) {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "nmfake")
+	if err != nil {
+		return nil, err
+	}
+
+	socket := filepath.Join(dir, "bus")
+	config := filepath.Join(dir, "bus.conf")
+	err = ioutil.WriteFile(config, []byte(fmt.Sprintf(busConfig, socket)), 0600)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	s := &Server{
+		dir:      dir,
+		address:  "unix:path=" + socket,
+		objects:  make(map[dbus.ObjectPath]*Object),
+		counters: make(map[string]int),
+		activate: DefaultActivation,
+		started:  time.Now(),
+	}
+
+	s.cmd = exec.Command(daemon, "--nofork", "--nopidfile", "--config-file="+config)
+	if err = s.cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	if err = s.waitForBus(socket); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	if s.conn, err = s.Dial(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	reply, err := s.conn.RequestName(gnm.NetworkManagerInterface, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		s.Close()
+		return nil, errors.New("nmfake: could not own " + gnm.NetworkManagerInterface)
+	}
+
+	// Modems are looked up in ModemManager by the Udi of their device.
+	reply, err = s.conn.RequestName(gnm.ModemManagerInterface, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		s.Close()
+		return nil, errors.New("nmfake: could not own " + gnm.ModemManagerInterface)
+	}
+
+	if err = s.export(); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	s.manager = s.newObject(gnm.NetworkManagerObjectPath, map[string]map[string]interface{}{
+		gnm.NetworkManagerInterface: {
+			"Devices":                    []dbus.ObjectPath{},
+			"AllDevices":                 []dbus.ObjectPath{},
+			"Checkpoints":                []dbus.ObjectPath{},
+			"NetworkingEnabled":          true,
+			"WirelessEnabled":            true,
+			"WirelessHardwareEnabled":    true,
+			"WwanEnabled":                true,
+			"WwanHardwareEnabled":        true,
+			"WimaxEnabled":               false,
+			"WimaxHardwareEnabled":       false,
+			"ActiveConnections":          []dbus.ObjectPath{},
+			"PrimaryConnection":          dbus.ObjectPath("/"),
+			"PrimaryConnectionType":      "",
+			"Metered":                    uint32(gnm.NmMeteredUnknown),
+			"ActivatingConnection":       dbus.ObjectPath("/"),
+			"Startup":                    false,
+			"Version":                    "1.16.0",
+			"Capabilities":               []uint32{},
+			"State":                      uint32(gnm.NmStateDisconnected),
+			"Connectivity":               uint32(gnm.NmConnectivityNone),
+			"ConnectivityCheckAvailable": false,
+			"ConnectivityCheckEnabled":   false,
+			"GlobalDnsConfiguration":     map[string]dbus.Variant{},
+		},
+	})
+
+	s.settings = s.newObject(gnm.SettingsObjectPath, map[string]map[string]interface{}{
+		gnm.SettingsInterface: {
+			"Connections": []dbus.ObjectPath{},
+			"Hostname":    "nmfake",
+			"CanModify":   true,
+		},
+	})
+
+	s.newObject(gnm.AgentManagerObjectPath, map[string]map[string]interface{}{
+		gnm.AgentManagerInterface: {},
+	})
+
+	return s, nil
+}
+
+func (s *Server) waitForBus(socket string) error {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(socket); err == nil {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("nmfake: dbus-daemon did not come up")
+}
+
+// Address returns the D-Bus address of the private bus.
+func (s *Server) Address() string {
+	return s.address
+}
+
+// Dial opens a new, authenticated connection to the private bus.
+func (s *Server) Dial() (*dbus.Conn, error) {
+	conn, err := dbus.Dial(s.address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.Auth(nil); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err = conn.Hello(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Close stops the fake service and its bus.
+func (s *Server) Close() error {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+
+	if s.cmd != nil && s.cmd.Process != nil {
+		s.cmd.Process.Kill()
+		s.cmd.Wait()
+	}
+
+	return os.RemoveAll(s.dir)
+}
+
+// SetActivationFunc replaces the function deciding how activations proceed.
+// A nil function leaves new active connections in the activating state.
+func (s *Server) SetActivationFunc(f ActivationFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activate = f
+}
+
+// SetScanFunc sets the function deciding what scans find. A nil function,
+// the default, finishes scans immediately without changing the access
+// points.
+func (s *Server) SetScanFunc(f ScanFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scan = f
+}
+
+// NetworkManager returns the /org/freedesktop/NetworkManager object.
+func (s *Server) NetworkManager() *Object {
+	return s.manager
+}
+
+// Settings returns the /org/freedesktop/NetworkManager/Settings object.
+func (s *Server) Settings() *Object {
+	return s.settings
+}
+
+// Object returns the object exported at path, or nil.
+func (s *Server) Object(path dbus.ObjectPath) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.objects[path]
+}
+
+func (s *Server) nextPath(collection string) dbus.ObjectPath {
+	s.counters[collection]++
+	return dbus.ObjectPath(fmt.Sprintf("%s/%s/%d", gnm.NetworkManagerObjectPath, collection, s.counters[collection]))
+}
+
+func (s *Server) newObject(path dbus.ObjectPath, props map[string]map[string]interface{}) *Object {
+	o := &Object{
+		server: s,
+		path:   path,
+		props:  make(map[string]map[string]dbus.Variant),
+	}
+
+	for iface, values := range props {
+		o.props[iface] = make(map[string]dbus.Variant)
+		for name, value := range values {
+			o.props[iface][name] = dbus.MakeVariant(value)
+		}
+	}
+
+	s.objects[path] = o
+	s.conn.Emit(exportRoot, dbusInterfacesAdded, path, o.props)
+
+	return o
+}
+
+func (s *Server) removeObject(o *Object) {
+	delete(s.objects, o.path)
+
+	interfaces := make([]string, 0, len(o.props))
+	for iface := range o.props {
+		interfaces = append(interfaces, iface)
+	}
+	s.conn.Emit(exportRoot, dbusInterfacesRemoved, o.path, interfaces)
+}
+
+func (s *Server) lookup(path dbus.ObjectPath, iface string) (*Object, *dbus.Error) {
+	o, ok := s.objects[path]
+	if !ok || !o.implements(iface) {
+		return nil, dbus.NewError("org.freedesktop.DBus.Error.UnknownObject", []interface{}{"No such object: " + string(path)})
+	}
+	return o, nil
+}
+
+func appendPath(paths []dbus.ObjectPath, path dbus.ObjectPath) []dbus.ObjectPath {
+	return append(append([]dbus.ObjectPath{}, paths...), path)
+}
+
+func removePath(paths []dbus.ObjectPath, path dbus.ObjectPath) []dbus.ObjectPath {
+	out := make([]dbus.ObjectPath, 0, len(paths))
+	for _, p := range paths {
+		if p != path {
+			out = append(out, p)
+		}
+	}
+	return out
+}
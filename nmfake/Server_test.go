@@ -0,0 +1,255 @@
+package nmfake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+	"github.com/Wifx/gonetworkmanager/nmfake"
+)
+
+// start starts a fake NetworkManager and connects a client to it, skipping
+// the test when no dbus-daemon is available to run it.
+func start(t *testing.T) (*nmfake.Server, *dbus.Conn, gnm.NetworkManager) {
+	t.Helper()
+
+	srv, err := nmfake.New()
+	if err != nil {
+		t.Skipf("cannot start the fake NetworkManager: %v", err)
+	}
+
+	conn, err := srv.Dial()
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+
+	nm, err := gnm.NewNetworkManagerWithConn(conn)
+	if err != nil {
+		conn.Close()
+		srv.Close()
+		t.Fatal(err)
+	}
+	return srv, conn, nm
+}
+
+// eventually calls cond until it returns true, failing the test after a few
+// seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDevices(t *testing.T) {
+	srv, conn, nm := start(t)
+	defer srv.Close()
+	defer conn.Close()
+
+	eth := srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+	wlan := srv.AddDevice("wlan0", gnm.NmDeviceTypeWifi)
+	ap := srv.AddAccessPoint(wlan, "home", 2412, 70)
+
+	devices, err := nm.GetDevices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 || devices[0].GetPath() != eth.Path() || devices[1].GetPath() != wlan.Path() {
+		t.Fatalf("GetDevices() = %v, want %s and %s", devices, eth.Path(), wlan.Path())
+	}
+
+	d, err := nm.GetDeviceByIpIface("wlan0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, ok := d.(gnm.DeviceWireless)
+	if !ok {
+		t.Fatalf("GetDeviceByIpIface(wlan0) = %T, want a DeviceWireless", d)
+	}
+	aps, err := w.GetAccessPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aps) != 1 || aps[0].GetPath() != ap.Path() {
+		t.Fatalf("GetAccessPoints() = %v, want %s", aps, ap.Path())
+	}
+	if ssid, err := aps[0].GetPropertySSID(); err != nil || ssid != "home" {
+		t.Errorf("GetPropertySSID() = %q, %v, want home", ssid, err)
+	}
+
+	srv.RemoveDevice(eth)
+	if devices, err = nm.GetDevices(); err != nil || len(devices) != 1 {
+		t.Errorf("GetDevices() after RemoveDevice() = %v, %v, want only %s", devices, err, wlan.Path())
+	}
+}
+
+func TestConnections(t *testing.T) {
+	srv, conn, _ := start(t)
+	defer srv.Close()
+	defer conn.Close()
+
+	s, err := gnm.NewSettingsWithConn(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := srv.AddConnection(gnm.ConnectionSettings{
+		"connection": {"id": "fake", "type": "802-3-ethernet"},
+	})
+	c, err := s.AddConnection(gnm.ConnectionSettings{
+		"connection": {"id": "client", "type": "802-3-ethernet"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connections, err := s.ListConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connections) != 2 || connections[0].GetPath() != obj.Path() || connections[1].GetPath() != c.GetPath() {
+		t.Fatalf("ListConnections() = %v, want %s and %s", connections, obj.Path(), c.GetPath())
+	}
+
+	settings, err := c.GetSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uuid, _ := settings["connection"]["uuid"].(string); uuid == "" {
+		t.Error("no connection.uuid was generated")
+	}
+
+	settings["connection"]["id"] = "updated"
+	if err := c.Update(settings); err != nil {
+		t.Fatal(err)
+	}
+	if id := srv.Object(c.GetPath()).ConnectionSettings()["connection"]["id"]; id != "updated" {
+		t.Errorf("id = %v after Update(), want updated", id)
+	}
+
+	if err := c.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if connections, err = s.ListConnections(); err != nil || len(connections) != 1 {
+		t.Errorf("ListConnections() after Delete() = %v, %v, want only %s", connections, err, obj.Path())
+	}
+}
+
+func TestActivation(t *testing.T) {
+	srv, conn, nm := start(t)
+	defer srv.Close()
+	defer conn.Close()
+
+	dobj := srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+	cobj := srv.AddConnection(gnm.ConnectionSettings{
+		"connection": {"id": "fake", "type": "802-3-ethernet"},
+	})
+
+	d, err := gnm.NewDeviceWithConn(conn, dobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := gnm.NewConnectionWithConn(conn, cobj.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without an activation function the activation stays pending.
+	srv.SetActivationFunc(nil)
+	ac, err := nm.ActivateConnection(c, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivating {
+		t.Errorf("GetPropertyState() = %v, %v, want activating", state, err)
+	}
+
+	nmfake.DefaultActivation(srv, srv.Object(ac.GetPath()))
+	if state, err := ac.GetPropertyState(); err != nil || state != gnm.NmActiveConnectionStateActivated {
+		t.Errorf("GetPropertyState() = %v, %v, want activated", state, err)
+	}
+	if state, err := d.GetPropertyState(); err != nil || state != gnm.NmDeviceStateActivated {
+		t.Errorf("device GetPropertyState() = %v, %v, want activated", state, err)
+	}
+	if primary, err := nm.GetPropertyPrimaryConnection(); err != nil || primary.GetPath() != ac.GetPath() {
+		t.Errorf("GetPropertyPrimaryConnection() = %v, %v, want %s", primary, err, ac.GetPath())
+	}
+
+	// The default activation function completes activations on its own.
+	srv.SetActivationFunc(nmfake.DefaultActivation)
+	ac, err = nm.ActivateConnection(c, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the activation", func() bool {
+		state, err := ac.GetPropertyState()
+		return err == nil && state == gnm.NmActiveConnectionStateActivated
+	})
+}
+
+func TestPropertiesChanged(t *testing.T) {
+	srv, conn, _ := start(t)
+	defer srv.Close()
+	defer conn.Close()
+
+	dev := srv.AddDevice("eth0", gnm.NmDeviceTypeEthernet)
+
+	err := conn.AddMatchSignal(dbus.WithMatchObjectPath(dev.Path()), dbus.WithMatchInterface("org.freedesktop.DBus.Properties"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 10)
+	conn.Signal(signals)
+
+	dev.Set(gnm.DevicePropertyMtu, uint32(9000))
+
+	if mtu := dev.Get(gnm.DevicePropertyMtu).Value(); mtu != uint32(9000) {
+		t.Errorf("Get() = %v, want 9000", mtu)
+	}
+
+	select {
+	case signal := <-signals:
+		if signal.Name != "org.freedesktop.DBus.Properties.PropertiesChanged" || signal.Body[0] != gnm.DeviceInterface {
+			t.Fatalf("signal = %s %v, want PropertiesChanged of %s", signal.Name, signal.Body, gnm.DeviceInterface)
+		}
+		changed := signal.Body[1].(map[string]dbus.Variant)
+		if mtu := changed["Mtu"].Value(); mtu != uint32(9000) {
+			t.Errorf("changed Mtu = %v, want 9000", mtu)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no PropertiesChanged signal")
+	}
+}
+
+func TestRequestScanLastScan(t *testing.T) {
+	srv, conn, _ := start(t)
+	defer srv.Close()
+	defer conn.Close()
+
+	dev := srv.AddDevice("wlan0", gnm.NmDeviceTypeWifi)
+	w, err := gnm.NewDeviceWirelessWithConn(conn, dev.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Scans completing within the same millisecond still move LastScan.
+	previous := int64(-1)
+	for i := 0; i < 10; i++ {
+		if err := w.RequestScan(); err != nil {
+			t.Fatal(err)
+		}
+		lastScan := dev.Get(gnm.DeviceWirelessPropertyLastScan).Value().(int64)
+		if lastScan <= previous {
+			t.Fatalf("LastScan = %d after scan %d, want more than %d", lastScan, i, previous)
+		}
+		previous = lastScan
+	}
+}
@@ -0,0 +1,438 @@
+package nmfake
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+
+	gnm "github.com/Wifx/gonetworkmanager"
+)
+
+const settingsErrorInvalidArguments = gnm.SettingsInterface + ".InvalidArguments"
+
+// secretKeys lists the setting keys GetSettings leaves out and GetSecrets
+// returns.
+var secretKeys = map[string]bool{
+	"psk":                         true,
+	"wep-key0":                    true,
+	"wep-key1":                    true,
+	"wep-key2":                    true,
+	"wep-key3":                    true,
+	"leap-password":               true,
+	"password":                    true,
+	"pin":                         true,
+	"private-key":                 true,
+	"private-key-password":        true,
+	"phase2-private-key-password": true,
+	"preshared-key":               true,
+}
+
+// settingWithoutSecrets returns a copy of a setting without its secrets,
+// including the preshared keys of the WireGuard peers.
+func settingWithoutSecrets(setting map[string]dbus.Variant) map[string]dbus.Variant {
+	rv := make(map[string]dbus.Variant)
+	for key, value := range setting {
+		if secretKeys[key] {
+			continue
+		}
+		if peers, ok := value.Value().([]map[string]dbus.Variant); ok && key == "peers" {
+			stripped := make([]map[string]dbus.Variant, 0, len(peers))
+			for _, peer := range peers {
+				p := make(map[string]dbus.Variant)
+				for k, v := range peer {
+					if !secretKeys[k] {
+						p[k] = v
+					}
+				}
+				stripped = append(stripped, p)
+			}
+			value = dbus.MakeVariant(stripped)
+		}
+		rv[key] = value
+	}
+	return rv
+}
+
+// settingSecrets returns the secrets of a setting. The WireGuard peers having
+// a preshared key are returned with their public key and preshared key.
+func settingSecrets(setting map[string]dbus.Variant) map[string]dbus.Variant {
+	rv := make(map[string]dbus.Variant)
+	for key, value := range setting {
+		if secretKeys[key] {
+			rv[key] = value
+			continue
+		}
+		if peers, ok := value.Value().([]map[string]dbus.Variant); ok && key == "peers" {
+			var secrets []map[string]dbus.Variant
+			for _, peer := range peers {
+				if psk, ok := peer["preshared-key"]; ok {
+					secrets = append(secrets, map[string]dbus.Variant{"public-key": peer["public-key"], "preshared-key": psk})
+				}
+			}
+			if secrets != nil {
+				rv[key] = dbus.MakeVariant(secrets)
+			}
+		}
+	}
+	return rv
+}
+
+// AddConnection adds a saved connection profile. A connection.uuid is
+// generated when the settings do not carry one.
+func (s *Server) AddConnection(settings gnm.ConnectionSettings) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addConnection(toVariantSettings(settings), false)
+}
+
+// RemoveConnection deletes a connection profile.
+func (s *Server) RemoveConnection(c *Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeConnection(c)
+}
+
+// ConnectionSettings returns the settings, secrets included, currently
+// stored for a connection profile.
+func (o *Object) ConnectionSettings() gnm.ConnectionSettings {
+	o.server.mu.Lock()
+	defer o.server.mu.Unlock()
+
+	rv := make(gnm.ConnectionSettings)
+	for name, setting := range o.settings {
+		rv[name] = make(map[string]interface{})
+		for key, value := range setting {
+			rv[name][key] = value.Value()
+		}
+	}
+	return rv
+}
+
+func toVariantSettings(settings gnm.ConnectionSettings) map[string]map[string]dbus.Variant {
+	rv := make(map[string]map[string]dbus.Variant)
+	for name, setting := range settings {
+		rv[name] = make(map[string]dbus.Variant)
+		for key, value := range setting {
+			rv[name][key] = dbus.MakeVariant(value)
+		}
+	}
+	return rv
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func settingString(settings map[string]map[string]dbus.Variant, name, key string) string {
+	v, _ := settings[name][key].Value().(string)
+	return v
+}
+
+func (s *Server) addConnection(settings map[string]map[string]dbus.Variant, unsaved bool) *Object {
+	if settings["connection"] == nil {
+		settings["connection"] = make(map[string]dbus.Variant)
+	}
+	if settingString(settings, "connection", "uuid") == "" {
+		settings["connection"]["uuid"] = dbus.MakeVariant(newUUID())
+	}
+
+	path := s.nextPath("Settings")
+	c := s.newObject(path, map[string]map[string]interface{}{
+		gnm.ConnectionInterface: {
+			"Unsaved":  unsaved,
+			"Flags":    uint32(0),
+			"Filename": "",
+		},
+	})
+	c.settings = settings
+	c.unsaved = unsaved
+
+	s.settings.set(gnm.SettingsPropertyConnections, appendPath(s.settings.get(gnm.SettingsPropertyConnections).([]dbus.ObjectPath), path))
+	s.settings.Emit(gnm.SettingsInterface+".NewConnection", path)
+
+	return c
+}
+
+func (s *Server) removeConnection(c *Object) {
+	for _, ac := range s.objectsImplementing(gnm.ActiveConnectionInterface) {
+		if ac.get(gnm.ActiveConnectionPropertyConnection) == c.path {
+			s.deactivate(ac, gnm.NmActiveConnectionStateReasonConnectionRemoved)
+		}
+	}
+
+	c.Emit(gnm.ConnectionInterface + ".Removed")
+	s.settings.set(gnm.SettingsPropertyConnections, removePath(s.settings.get(gnm.SettingsPropertyConnections).([]dbus.ObjectPath), c.path))
+	s.settings.Emit(gnm.SettingsInterface+".ConnectionRemoved", c.path)
+	s.removeObject(c)
+}
+
+func (s *Server) objectsImplementing(iface string) []*Object {
+	var rv []*Object
+	for _, o := range s.objects {
+		if _, ok := o.props[iface]; ok {
+			rv = append(rv, o)
+		}
+	}
+	return rv
+}
+
+func (s *Server) lookupSettings(msg dbus.Message) *dbus.Error {
+	_, derr := s.lookup(msgPath(msg), gnm.SettingsInterface)
+	return derr
+}
+
+func (s *Server) settingsListConnections(msg dbus.Message) ([]dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return nil, derr
+	}
+	return s.settings.get(gnm.SettingsPropertyConnections).([]dbus.ObjectPath), nil
+}
+
+func (s *Server) settingsGetConnectionByUuid(msg dbus.Message, uuid string) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return "", derr
+	}
+
+	for _, path := range s.settings.get(gnm.SettingsPropertyConnections).([]dbus.ObjectPath) {
+		if settingString(s.objects[path].settings, "connection", "uuid") == uuid {
+			return path, nil
+		}
+	}
+
+	return "", dbus.NewError(gnm.SettingsInterface+".InvalidConnection", []interface{}{"No connection with the UUID was found."})
+}
+
+func (s *Server) settingsAddConnection(msg dbus.Message, settings map[string]map[string]dbus.Variant) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return "", derr
+	}
+	return s.addConnection(settings, false).path, nil
+}
+
+func (s *Server) settingsAddConnectionUnsaved(msg dbus.Message, settings map[string]map[string]dbus.Variant) (dbus.ObjectPath, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return "", derr
+	}
+	return s.addConnection(settings, true).path, nil
+}
+
+func (s *Server) settingsSaveHostname(msg dbus.Message, hostname string) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return derr
+	}
+
+	s.settings.set(gnm.SettingsPropertyHostname, hostname)
+	return nil
+}
+
+// settingsLoadConnections fails for every file, since the fake has no
+// connection files.
+func (s *Server) settingsLoadConnections(msg dbus.Message, filenames []string) (bool, []string, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return false, nil, derr
+	}
+
+	return len(filenames) == 0, append([]string{}, filenames...), nil
+}
+
+func (s *Server) settingsReloadConnections(msg dbus.Message) (bool, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if derr := s.lookupSettings(msg); derr != nil {
+		return false, derr
+	}
+
+	return true, nil
+}
+
+func (s *Server) lookupConnection(msg dbus.Message) (*Object, *dbus.Error) {
+	return s.lookup(msgPath(msg), gnm.ConnectionInterface)
+}
+
+func (s *Server) updateConnection(c *Object, settings map[string]map[string]dbus.Variant, unsaved bool) {
+	if settings["connection"] == nil {
+		settings["connection"] = make(map[string]dbus.Variant)
+	}
+	if settingString(settings, "connection", "uuid") == "" {
+		settings["connection"]["uuid"] = c.settings["connection"]["uuid"]
+	}
+
+	c.settings = settings
+	c.unsaved = unsaved
+	c.set(gnm.ConnectionPropertyUnsaved, unsaved)
+	c.Emit(gnm.ConnectionInterface + ".Updated")
+}
+
+func (s *Server) connectionUpdate(msg dbus.Message, settings map[string]map[string]dbus.Variant) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return derr
+	}
+
+	s.updateConnection(c, settings, false)
+	return nil
+}
+
+func (s *Server) connectionUpdateUnsaved(msg dbus.Message, settings map[string]map[string]dbus.Variant) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return derr
+	}
+
+	s.updateConnection(c, settings, true)
+	return nil
+}
+
+func (s *Server) connectionUpdate2(msg dbus.Message, settings map[string]map[string]dbus.Variant, flags uint32, args map[string]dbus.Variant) (map[string]dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return nil, derr
+	}
+
+	for key := range args {
+		return nil, dbus.NewError(settingsErrorInvalidArguments, []interface{}{"unsupported argument '" + key + "'"})
+	}
+
+	inMemory := gnm.NmSettingsUpdate2FlagsInMemory | gnm.NmSettingsUpdate2FlagsInMemoryDetached | gnm.NmSettingsUpdate2FlagsInMemoryOnly
+	unsaved := c.unsaved
+	switch {
+	case gnm.NmSettingsUpdate2Flags(flags)&gnm.NmSettingsUpdate2FlagsToDisk != 0:
+		unsaved = false
+	case gnm.NmSettingsUpdate2Flags(flags)&inMemory != 0:
+		unsaved = true
+	}
+
+	if len(settings) == 0 {
+		settings = c.settings
+	}
+	s.updateConnection(c, settings, unsaved)
+
+	if gnm.NmSettingsUpdate2Flags(flags)&gnm.NmSettingsUpdate2FlagsVolatile != 0 {
+		active := false
+		for _, ac := range s.objectsImplementing(gnm.ActiveConnectionInterface) {
+			active = active || ac.get(gnm.ActiveConnectionPropertyConnection) == c.path
+		}
+		if !active {
+			s.removeConnection(c)
+		}
+	}
+
+	return map[string]dbus.Variant{}, nil
+}
+
+func (s *Server) connectionDelete(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return derr
+	}
+
+	s.removeConnection(c)
+	return nil
+}
+
+func (s *Server) connectionGetSettings(msg dbus.Message) (map[string]map[string]dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return nil, derr
+	}
+
+	rv := make(map[string]map[string]dbus.Variant)
+	for name, setting := range c.settings {
+		rv[name] = settingWithoutSecrets(setting)
+	}
+	return rv, nil
+}
+
+func (s *Server) connectionGetSecrets(msg dbus.Message, settingName string) (map[string]map[string]dbus.Variant, *dbus.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return nil, derr
+	}
+
+	rv := make(map[string]map[string]dbus.Variant)
+	for name, setting := range c.settings {
+		if settingName != "" && name != settingName {
+			continue
+		}
+		rv[name] = settingSecrets(setting)
+	}
+	return rv, nil
+}
+
+func (s *Server) connectionClearSecrets(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return derr
+	}
+
+	for _, setting := range c.settings {
+		for key := range setting {
+			if secretKeys[key] {
+				delete(setting, key)
+			}
+		}
+	}
+	c.Emit(gnm.ConnectionInterface + ".Updated")
+	return nil
+}
+
+func (s *Server) connectionSave(msg dbus.Message) *dbus.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, derr := s.lookupConnection(msg)
+	if derr != nil {
+		return derr
+	}
+
+	c.unsaved = false
+	c.set(gnm.ConnectionPropertyUnsaved, false)
+	return nil
+}
@@ -1,45 +1,74 @@
 package gonetworkmanager
 
 import (
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 )
 
 const (
-	dbusMethodAddMatch = "org.freedesktop.DBus.AddMatch"
+	dbusMethodAddMatch         = "org.freedesktop.DBus.AddMatch"
+	dbusMethodRemoveMatch      = "org.freedesktop.DBus.RemoveMatch"
+	dbusMethodPropertiesGet    = "org.freedesktop.DBus.Properties.Get"
+	dbusMethodPropertiesGetAll = "org.freedesktop.DBus.Properties.GetAll"
+	dbusMethodPropertiesSet    = "org.freedesktop.DBus.Properties.Set"
 )
 
 type dbusBase struct {
 	conn *dbus.Conn
 	obj  dbus.BusObject
+
+	// uncached makes the property getters ignore the Cache enabled on conn.
+	uncached bool
 }
 
-func (d *dbusBase) init(iface string, objectPath dbus.ObjectPath) error {
-	var err error
+// cleanupTimeout bounds the calls undoing an operation that failed or is
+// over, e.g. a rollback or the reactivation of the previous connection. They
+// do not use the context of the operation, so that they still run when it
+// was cancelled or expired. A reactivation waits for DHCP, hence the margin.
+const cleanupTimeout = 30 * time.Second
 
-	d.conn, err = dbus.SystemBus()
+func cleanupContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), cleanupTimeout)
+}
+
+func (d *dbusBase) init(iface string, objectPath dbus.ObjectPath) error {
+	conn, err := dbus.SystemBus()
 	if err != nil {
 		return err
 	}
 
-	d.obj = d.conn.Object(iface, objectPath)
+	return d.initWithConn(conn, iface, objectPath)
+}
+
+func (d *dbusBase) initWithConn(conn *dbus.Conn, iface string, objectPath dbus.ObjectPath) error {
+	if conn == nil {
+		return errors.New("nil D-Bus connection")
+	}
+
+	d.conn = conn
+	d.obj = conn.Object(iface, objectPath)
 
 	return nil
 }
 
-func (d *dbusBase) call(method string, args ...interface{}) error {
-	return d.obj.Call(method, 0, args...).Err
+func (d *dbusBase) call(ctx context.Context, method string, args ...interface{}) error {
+	return d.obj.CallWithContext(ctx, method, 0, args...).Err
 }
 
-func (d *dbusBase) callWithReturn(ret interface{}, method string, args ...interface{}) error {
-	return d.obj.Call(method, 0, args...).Store(ret)
+func (d *dbusBase) callWithReturn(ctx context.Context, ret interface{}, method string, args ...interface{}) error {
+	return d.obj.CallWithContext(ctx, method, 0, args...).Store(ret)
 }
 
-func (d *dbusBase) callWithReturn2(ret1 interface{}, ret2 interface{}, method string, args ...interface{}) error {
-	return d.obj.Call(method, 0, args...).Store(ret1, ret2)
+func (d *dbusBase) callWithReturn2(ctx context.Context, ret1 interface{}, ret2 interface{}, method string, args ...interface{}) error {
+	return d.obj.CallWithContext(ctx, method, 0, args...).Store(ret1, ret2)
 }
 
 func (d *dbusBase) subscribe(iface, member string) {
@@ -48,18 +77,122 @@ func (d *dbusBase) subscribe(iface, member string) {
 	d.conn.BusObject().Call(dbusMethodAddMatch, 0, rule)
 }
 
-func (d *dbusBase) subscribeNamespace(namespace string) {
+func (d *dbusBase) subscribeNamespace(namespace string) error {
+	return d.subscribeNamespaceContext(context.Background(), namespace)
+}
+
+func (d *dbusBase) subscribeNamespaceContext(ctx context.Context, namespace string) error {
 	rule := fmt.Sprintf("type='signal',path_namespace='%s'", namespace)
-	d.conn.BusObject().Call(dbusMethodAddMatch, 0, rule)
+	return d.conn.BusObject().CallWithContext(ctx, dbusMethodAddMatch, 0, rule).Err
+}
+
+func (d *dbusBase) unsubscribeNamespace(namespace string) error {
+	rule := fmt.Sprintf("type='signal',path_namespace='%s'", namespace)
+	return d.conn.BusObject().Call(dbusMethodRemoveMatch, 0, rule).Err
 }
 
-func (d *dbusBase) getProperty(iface string) (interface{}, error) {
-	variant, err := d.obj.GetProperty(iface)
+// getProperty reads a property from the Cache enabled on the connection, or
+// through org.freedesktop.DBus.Properties.Get when there is none or the
+// object was made with WithoutCache. The property name must be
+// fully qualified ("<interface>.<property>").
+func (d *dbusBase) getProperty(ctx context.Context, iface string) (interface{}, error) {
+	idx := strings.LastIndex(iface, ".")
+	if idx == -1 || idx+1 == len(iface) {
+		return nil, fmt.Errorf("invalid property name '%s'", iface)
+	}
+
+	if !d.uncached {
+		if value, ok := cachedProperty(d.conn, d.obj.Path(), iface[:idx], iface[idx+1:]); ok {
+			return value, nil
+		}
+	}
+
+	var variant dbus.Variant
+	err := d.obj.CallWithContext(ctx, dbusMethodPropertiesGet, 0, iface[:idx], iface[idx+1:]).Store(&variant)
 	return variant.Value(), err
 }
 
-func (d *dbusBase) getObjectProperty(iface string) (value dbus.ObjectPath, err error) {
-	prop, err := d.getProperty(iface)
+// setProperty writes a property through org.freedesktop.DBus.Properties.Set.
+// The property name must be fully qualified ("<interface>.<property>").
+func (d *dbusBase) setProperty(ctx context.Context, iface string, value interface{}) error {
+	idx := strings.LastIndex(iface, ".")
+	if idx == -1 || idx+1 == len(iface) {
+		return fmt.Errorf("invalid property name '%s'", iface)
+	}
+
+	return d.obj.CallWithContext(ctx, dbusMethodPropertiesSet, 0, iface[:idx], iface[idx+1:], dbus.MakeVariant(value)).Err
+}
+
+// getAllProperties reads every property of an interface in one round-trip
+// through org.freedesktop.DBus.Properties.GetAll, or from the Cache enabled
+// on the connection unless the object was made with WithoutCache.
+func (d *dbusBase) getAllProperties(ctx context.Context, iface string) (map[string]dbus.Variant, error) {
+	if !d.uncached {
+		if props, ok := cachedProperties(d.conn, d.obj.Path(), iface); ok {
+			return props, nil
+		}
+	}
+
+	call := d.obj.CallWithContext(ctx, dbusMethodPropertiesGetAll, 0, iface)
+	if call.Err != nil {
+		return nil, call.Err
+	}
+
+	// The body is read as is: Store would rebuild the variants and lose the
+	// signature of the struct properties, e.g. (uu) would become av.
+	if len(call.Body) != 1 {
+		return nil, makeErrVariantType(iface)
+	}
+	props, ok := call.Body[0].(map[string]dbus.Variant)
+	if !ok {
+		return nil, makeErrVariantType(iface)
+	}
+	return props, nil
+}
+
+// decodeProperties stores the properties of iface into the exported fields of
+// the struct pointed to by v that have the same name. The type of each field
+// must match the D-Bus signature of its property; fields tagged `dbus:"-"` are
+// left to the caller. Properties without a field are ignored, and the fields
+// of properties an older NetworkManager does not have keep their zero value.
+func decodeProperties(iface string, props map[string]dbus.Variant, v interface{}) error {
+	value := reflect.ValueOf(v).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get("dbus") == "-" {
+			continue
+		}
+
+		prop, ok := props[field.Name]
+		if !ok {
+			continue
+		}
+		if prop.Signature() != dbus.SignatureOfType(field.Type) {
+			return makeErrVariantType(iface + "." + field.Name)
+		}
+		if err := dbus.Store([]interface{}{prop.Value()}, value.Field(i).Addr().Interface()); err != nil {
+			return makeErrVariantType(iface + "." + field.Name)
+		}
+	}
+	return nil
+}
+
+// sliceMapStringVariantValue returns the aa{sv} property name of iface from
+// the result of getAllProperties, or nil if it is missing.
+func sliceMapStringVariantValue(iface string, props map[string]dbus.Variant, name string) ([]map[string]dbus.Variant, error) {
+	v, ok := props[name]
+	if !ok {
+		return nil, nil
+	}
+	value, ok := v.Value().([]map[string]dbus.Variant)
+	if !ok {
+		return nil, makeErrVariantType(iface + "." + name)
+	}
+	return value, nil
+}
+
+func (d *dbusBase) getObjectProperty(ctx context.Context, iface string) (value dbus.ObjectPath, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -71,8 +204,8 @@ func (d *dbusBase) getObjectProperty(iface string) (value dbus.ObjectPath, err e
 	return
 }
 
-func (d *dbusBase) getSliceObjectProperty(iface string) (value []dbus.ObjectPath, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceObjectProperty(ctx context.Context, iface string) (value []dbus.ObjectPath, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -84,8 +217,8 @@ func (d *dbusBase) getSliceObjectProperty(iface string) (value []dbus.ObjectPath
 	return
 }
 
-func (d *dbusBase) getBoolProperty(iface string) (value bool, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getBoolProperty(ctx context.Context, iface string) (value bool, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -97,8 +230,8 @@ func (d *dbusBase) getBoolProperty(iface string) (value bool, err error) {
 	return
 }
 
-func (d *dbusBase) getStringProperty(iface string) (value string, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getStringProperty(ctx context.Context, iface string) (value string, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -110,8 +243,8 @@ func (d *dbusBase) getStringProperty(iface string) (value string, err error) {
 	return
 }
 
-func (d *dbusBase) getSliceStringProperty(iface string) (value []string, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceStringProperty(ctx context.Context, iface string) (value []string, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -123,8 +256,8 @@ func (d *dbusBase) getSliceStringProperty(iface string) (value []string, err err
 	return
 }
 
-func (d *dbusBase) getSliceSliceByteProperty(iface string) (value [][]byte, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceSliceByteProperty(ctx context.Context, iface string) (value [][]byte, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -136,8 +269,8 @@ func (d *dbusBase) getSliceSliceByteProperty(iface string) (value [][]byte, err
 	return
 }
 
-func (d *dbusBase) getMapStringVariantProperty(iface string) (value map[string]dbus.Variant, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getMapStringVariantProperty(ctx context.Context, iface string) (value map[string]dbus.Variant, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -149,8 +282,8 @@ func (d *dbusBase) getMapStringVariantProperty(iface string) (value map[string]d
 	return
 }
 
-func (d *dbusBase) getUint8Property(iface string) (value uint8, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getUint8Property(ctx context.Context, iface string) (value uint8, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -162,8 +295,21 @@ func (d *dbusBase) getUint8Property(iface string) (value uint8, err error) {
 	return
 }
 
-func (d *dbusBase) getUint32Property(iface string) (value uint32, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getUint16Property(ctx context.Context, iface string) (value uint16, err error) {
+	prop, err := d.getProperty(ctx, iface)
+	if err != nil {
+		return
+	}
+	value, ok := prop.(uint16)
+	if !ok {
+		err = makeErrVariantType(iface)
+		return
+	}
+	return
+}
+
+func (d *dbusBase) getUint32Property(ctx context.Context, iface string) (value uint32, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -175,8 +321,21 @@ func (d *dbusBase) getUint32Property(iface string) (value uint32, err error) {
 	return
 }
 
-func (d *dbusBase) getInt64Property(iface string) (value int64, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getInt32Property(ctx context.Context, iface string) (value int32, err error) {
+	prop, err := d.getProperty(ctx, iface)
+	if err != nil {
+		return
+	}
+	value, ok := prop.(int32)
+	if !ok {
+		err = makeErrVariantType(iface)
+		return
+	}
+	return
+}
+
+func (d *dbusBase) getInt64Property(ctx context.Context, iface string) (value int64, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -188,8 +347,8 @@ func (d *dbusBase) getInt64Property(iface string) (value int64, err error) {
 	return
 }
 
-func (d *dbusBase) getUint64Property(iface string) (value uint64, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getUint64Property(ctx context.Context, iface string) (value uint64, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -201,8 +360,8 @@ func (d *dbusBase) getUint64Property(iface string) (value uint64, err error) {
 	return
 }
 
-func (d *dbusBase) getSliceUint32Property(iface string) (value []uint32, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceUint32Property(ctx context.Context, iface string) (value []uint32, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -214,8 +373,8 @@ func (d *dbusBase) getSliceUint32Property(iface string) (value []uint32, err err
 	return
 }
 
-func (d *dbusBase) getSliceSliceUint32Property(iface string) (value [][]uint32, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceSliceUint32Property(ctx context.Context, iface string) (value [][]uint32, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -227,8 +386,8 @@ func (d *dbusBase) getSliceSliceUint32Property(iface string) (value [][]uint32,
 	return
 }
 
-func (d *dbusBase) getSliceMapStringVariantProperty(iface string) (value []map[string]dbus.Variant, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceMapStringVariantProperty(ctx context.Context, iface string) (value []map[string]dbus.Variant, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -240,8 +399,8 @@ func (d *dbusBase) getSliceMapStringVariantProperty(iface string) (value []map[s
 	return
 }
 
-func (d *dbusBase) getSliceByteProperty(iface string) (value []byte, err error) {
-	prop, err := d.getProperty(iface)
+func (d *dbusBase) getSliceByteProperty(ctx context.Context, iface string) (value []byte, err error) {
+	prop, err := d.getProperty(ctx, iface)
 	if err != nil {
 		return
 	}
@@ -262,3 +421,35 @@ func ip4ToString(ip uint32) string {
 	binary.LittleEndian.PutUint32(bs, ip)
 	return net.IP(bs).String()
 }
+
+func ip4FromBytes(ip net.IP) uint32 {
+	return binary.LittleEndian.Uint32(ip.To4())
+}
+
+func settingsFromVariants(settings map[string]map[string]dbus.Variant) ConnectionSettings {
+	rv := make(ConnectionSettings)
+
+	for k1, v1 := range settings {
+		rv[k1] = make(map[string]interface{})
+
+		for k2, v2 := range v1 {
+			rv[k1][k2] = v2.Value()
+		}
+	}
+
+	return rv
+}
+
+// isUnknownMethod tells whether a call failed because the daemon does not
+// implement the method, e.g. because it predates it.
+func isUnknownMethod(err error) bool {
+	dbusErr, ok := err.(dbus.Error)
+	return ok && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownMethod"
+}
+
+// isUnknownObject tells whether a call failed because the object is gone,
+// e.g. because NetworkManager removed it since it was listed.
+func isUnknownObject(err error) bool {
+	dbusErr, ok := err.(dbus.Error)
+	return ok && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownObject"
+}